@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"strings"
+)
+
+// arrayTransport is a http.RoundTripper setting the symid header to the array addressed by the
+// request. Resources can manage another array than the one of the provider, so the header is
+// taken from the request path and defaults to the serial number of the provider.
+type arrayTransport struct {
+	next         http.RoundTripper
+	serialNumber string
+}
+
+// newArrayTransport wraps the given transport with the symid header of serialNumber by default.
+func newArrayTransport(next http.RoundTripper, serialNumber string) *arrayTransport {
+	return &arrayTransport{
+		next:         next,
+		serialNumber: serialNumber,
+	}
+}
+
+// RoundTrip sends the request with the symid header of the addressed array.
+func (t *arrayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	serialNumber := arraySerialNumber(req)
+	if serialNumber == "" {
+		serialNumber = t.serialNumber
+	}
+	if serialNumber == "" || req.Header.Get("symid") == serialNumber {
+		return t.next.RoundTrip(req)
+	}
+	arrayReq := req.Clone(req.Context())
+	arrayReq.Header.Set("symid", serialNumber)
+	return t.next.RoundTrip(arrayReq)
+}
+
+// arraySerialNumber returns the serial number in a path like /univmax/restapi/100/sloprovisioning/symmetrix/{id}/...
+func arraySerialNumber(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "symmetrix" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
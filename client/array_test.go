@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayTransportSetsSymIDHeader(t *testing.T) {
+	var symid string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symid = r.Header.Get("symid")
+	}))
+	t.Cleanup(server.Close)
+	transport := newArrayTransport(http.DefaultTransport, "000000000001")
+
+	tests := map[string]string{
+		"/univmax/restapi/100/sloprovisioning/symmetrix/000000000002/storagegroup/sg1": "000000000002",
+		"/univmax/restapi/100/replication/symmetrix/000000000003":                      "000000000003",
+		"/univmax/restapi/version":                                                     "000000000001",
+	}
+	for path, expected := range tests {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, expected, symid, path)
+	}
+}
+
+func TestWithSerialNumber(t *testing.T) {
+	c := &Client{SymmetrixID: "000000000001"}
+	assert.Same(t, c, c.WithSerialNumber(""))
+	assert.Same(t, c, c.WithSerialNumber("000000000001"))
+
+	array := c.WithSerialNumber("000000000002")
+	assert.Equal(t, "000000000002", array.SymmetrixID)
+	assert.Equal(t, "000000000001", c.SymmetrixID)
+}
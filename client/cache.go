@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cacheTransport is a http.RoundTripper serving the reads of the objects of an array from memory
+// for ttl, so that the objects read by several resources and data sources during a plan are only
+// fetched once. Any mutation of an array drops the cached reads of the array: the objects of
+// Unisphere reference each other, a volume lists its storage groups and a storage group its
+// masking views, so the mutated object is not the only one to change.
+type cacheTransport struct {
+	next http.RoundTripper
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	// generations count the invalidations of each array, "" counting those of all arrays, so that
+	// a read sent before a mutation is not cached after it.
+	generations map[string]uint64
+	// jobs count the jobs running on each array. The array changes until they complete, its reads are
+	// not cached meanwhile.
+	jobs map[string]int
+}
+
+// cacheEntry is a cached response of Unisphere.
+type cacheEntry struct {
+	serialNumber string
+	expires      time.Time
+	status       string
+	statusCode   int
+	header       http.Header
+	body         []byte
+}
+
+// newCacheTransport wraps the given transport with a cache of the reads, or returns it as is when ttl is not positive.
+func newCacheTransport(next http.RoundTripper, ttl time.Duration) http.RoundTripper {
+	if ttl <= 0 {
+		return next
+	}
+	return &cacheTransport{
+		next:        next,
+		ttl:         ttl,
+		entries:     make(map[string]*cacheEntry),
+		generations: make(map[string]uint64),
+		jobs:        make(map[string]int),
+	}
+}
+
+// TrackJob tells the cache of the client that a job runs on its array: the reads of the array are not
+// cached until the returned function is called once the job completed, which drops the cached reads.
+func (c *Client) TrackJob() func() {
+	if c.cache == nil {
+		return func() {}
+	}
+	serialNumber := c.SymmetrixID
+	c.cache.startJob(serialNumber)
+	return func() {
+		c.cache.endJob(serialNumber)
+	}
+}
+
+// RoundTrip answers the reads of an array from the cache when possible, and drops the cache of the
+// array on mutations.
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	serialNumber := arraySerialNumber(req)
+	if req.Method != http.MethodGet {
+		// Dropped before and after, the reads sent while the mutation runs may see the old object
+		t.invalidate(serialNumber)
+		defer t.invalidate(serialNumber)
+		return t.next.RoundTrip(req)
+	}
+	// Only the objects of an array are cached, not the jobs polled until they complete
+	if serialNumber == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	if entry := t.lookup(key); entry != nil {
+		tflog.Trace(req.Context(), "Served PowerMax request from the cache", map[string]interface{}{
+			"url": key,
+		})
+		return entry.response(req), nil
+	}
+
+	generation := t.generation(serialNumber)
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	entry := &cacheEntry{
+		serialNumber: serialNumber,
+		expires:      time.Now().Add(t.ttl),
+		status:       resp.Status,
+		statusCode:   resp.StatusCode,
+		header:       resp.Header.Clone(),
+		body:         body,
+	}
+	// A cached session cookie would replace the current one in the jar
+	entry.header.Del("Set-Cookie")
+	t.store(key, entry, generation)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// lookup returns the entry of key which has not expired yet, or nil.
+func (t *cacheTransport) lookup(key string) *cacheEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, ok := t.entries[key]
+	if !ok || t.jobs[entry.serialNumber] > 0 {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(t.entries, key)
+		return nil
+	}
+	return entry
+}
+
+// generation returns the number of invalidations of the array serialNumber.
+func (t *cacheTransport) generation(serialNumber string) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.generations[serialNumber] + t.generations[""]
+}
+
+// store caches entry for key and drops the expired entries. The entry is not cached when its array
+// was invalidated since generation was taken.
+func (t *cacheTransport) store(key string, entry *cacheEntry, generation uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.generations[entry.serialNumber]+t.generations[""] != generation || t.jobs[entry.serialNumber] > 0 {
+		return
+	}
+	now := time.Now()
+	for k, e := range t.entries {
+		if now.After(e.expires) {
+			delete(t.entries, k)
+		}
+	}
+	t.entries[key] = entry
+}
+
+// invalidate drops the cached reads of the array serialNumber, or of all arrays when it is empty.
+func (t *cacheTransport) invalidate(serialNumber string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.generations[serialNumber]++
+	for key, entry := range t.entries {
+		if serialNumber == "" || entry.serialNumber == serialNumber {
+			delete(t.entries, key)
+		}
+	}
+}
+
+// startJob stops caching the reads of the array serialNumber until endJob is called.
+func (t *cacheTransport) startJob(serialNumber string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.jobs[serialNumber]++
+}
+
+// endJob drops the cached reads of the array serialNumber, changed by the job which completed.
+func (t *cacheTransport) endJob(serialNumber string) {
+	t.mu.Lock()
+	t.jobs[serialNumber]--
+	if t.jobs[serialNumber] <= 0 {
+		delete(t.jobs, serialNumber)
+	}
+	t.mu.Unlock()
+	t.invalidate(serialNumber)
+}
+
+// response returns a copy of the cached response answering req.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        e.status,
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
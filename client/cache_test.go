@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingServer answers the path of the request and counts the requests received per method and path.
+func countingServer(t *testing.T) (*httptest.Server, func(method, path string) int) {
+	var mu sync.Mutex
+	counts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		counts[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session"})
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	t.Cleanup(server.Close)
+	return server, func(method, path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return counts[method+" "+path]
+	}
+}
+
+func roundTrip(t *testing.T, transport http.RoundTripper, method, url string) *http.Response {
+	req, err := http.NewRequest(method, url, nil)
+	assert.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+func TestCacheTransportServesReadsOfArrays(t *testing.T) {
+	server, count := countingServer(t)
+	transport := newCacheTransport(http.DefaultTransport, time.Minute)
+	sgPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000001/storagegroup/sg1"
+
+	for i := 0; i < 3; i++ {
+		resp := roundTrip(t, transport, http.MethodGet, server.URL+sgPath)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, sgPath, readBody(t, resp))
+		if i > 0 {
+			assert.Empty(t, resp.Header.Values("Set-Cookie"))
+		}
+	}
+	assert.Equal(t, 1, count(http.MethodGet, sgPath))
+
+	// The jobs are polled until they complete and the errors are not cached
+	for i := 0; i < 2; i++ {
+		readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+"/univmax/restapi/100/system/job/1"))
+		readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+"/missing"))
+	}
+	assert.Equal(t, 2, count(http.MethodGet, "/univmax/restapi/100/system/job/1"))
+	assert.Equal(t, 2, count(http.MethodGet, "/missing"))
+}
+
+func TestCacheTransportInvalidatesOnMutation(t *testing.T) {
+	server, count := countingServer(t)
+	transport := newCacheTransport(http.DefaultTransport, time.Minute)
+	volumePath := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000001/volume/00001"
+	otherArrayPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000002/volume/00001"
+	sgPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000001/storagegroup/sg1"
+
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+volumePath))
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+otherArrayPath))
+	// Adding the volume to a storage group changes the volume too
+	readBody(t, roundTrip(t, transport, http.MethodPut, server.URL+sgPath))
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+volumePath))
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+otherArrayPath))
+	assert.Equal(t, 2, count(http.MethodGet, volumePath))
+	assert.Equal(t, 1, count(http.MethodGet, otherArrayPath))
+
+	// A mutation outside of the arrays drops the reads of all arrays
+	readBody(t, roundTrip(t, transport, http.MethodPost, server.URL+"/univmax/restapi/100/system/tag"))
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+otherArrayPath))
+	assert.Equal(t, 2, count(http.MethodGet, otherArrayPath))
+}
+
+func TestCacheTransportExpires(t *testing.T) {
+	server, count := countingServer(t)
+	transport := newCacheTransport(http.DefaultTransport, 10*time.Millisecond)
+	path := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000001/portgroup/pg1"
+
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+path))
+	time.Sleep(20 * time.Millisecond)
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+path))
+	assert.Equal(t, 2, count(http.MethodGet, path))
+
+	assert.Equal(t, http.DefaultTransport, newCacheTransport(http.DefaultTransport, 0))
+}
+
+func TestCacheTransportDuringJob(t *testing.T) {
+	server, count := countingServer(t)
+	transport := newCacheTransport(http.DefaultTransport, time.Minute)
+	pmaxClient := &Client{SymmetrixID: "000120000001", cache: transport.(*cacheTransport)}
+	sgPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000001/storagegroup/sg1"
+	otherArrayPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000120000002/storagegroup/sg1"
+
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+sgPath))
+	readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+otherArrayPath))
+
+	// The reads of the array sent while the job runs reach Unisphere and are not cached
+	done := pmaxClient.TrackJob()
+	for i := 0; i < 2; i++ {
+		readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+sgPath))
+		readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+otherArrayPath))
+	}
+	assert.Equal(t, 3, count(http.MethodGet, sgPath))
+	assert.Equal(t, 1, count(http.MethodGet, otherArrayPath))
+
+	// Once the job completed, the array is read again and cached
+	done()
+	for i := 0; i < 2; i++ {
+		readBody(t, roundTrip(t, transport, http.MethodGet, server.URL+sgPath))
+	}
+	assert.Equal(t, 4, count(http.MethodGet, sgPath))
+
+	// The client without a cache has nothing to track
+	(&Client{SymmetrixID: "000120000001"}).TrackJob()()
+}
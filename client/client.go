@@ -19,10 +19,7 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/http/cookiejar"
@@ -37,11 +34,38 @@ import (
 type Client struct {
 	PmaxOpenapiClient *pmaxop.APIClient
 	SymmetrixID       string
+	// Version is the Unisphere version detected by NegotiateVersion.
+	Version *UnisphereVersion
+	session *sessionTransport
+	limits  *limitTransport
+	cache   *cacheTransport
+}
+
+// ClientOptions holds the optional settings of the client.
+type ClientOptions struct {
+	// Retry configures the retries of transient failures.
+	Retry RetryConfig
+	// TLS configures the certificates used to verify Unisphere and authenticate the client.
+	TLS TLSOptions
+	// Transport configures the proxy and the connection pool.
+	Transport TransportOptions
+	// Timeout bounds every request sent to Unisphere. DefaultTimeout is used when unset.
+	Timeout time.Duration
+	// MaxConcurrentRequests bounds the requests in flight. DefaultMaxConcurrentRequests is used when unset.
+	MaxConcurrentRequests int
+	// Recorder records the exchanges with Unisphere to a cassette or replays them, for tests.
+	Recorder RecorderOptions
+	// CacheTTL is how long the reads of the objects of an array are served from memory, until a
+	// mutation of the array. The reads are not cached when it is zero.
+	CacheTTL time.Duration
+	// Trace logs every request and response at the DEBUG level. They are logged at the TRACE level
+	// when TF_LOG enables it.
+	Trace bool
 }
 
 // NewClient returns the client.
-func NewClient(ctx context.Context, endpoint, username, password, serialNumber, pmaxVersion string, insecure bool) (*Client, error) {
-	openapiClient, err := NewOpenApiClient(ctx, endpoint, username, password, serialNumber, pmaxVersion, insecure)
+func NewClient(ctx context.Context, endpoint, username, password, serialNumber, pmaxVersion string, insecure bool, opts ClientOptions) (*Client, error) {
+	openapiClient, err := NewOpenApiClient(ctx, endpoint, username, password, serialNumber, pmaxVersion, insecure, opts)
 	if err != nil {
 		tflog.Error(ctx, "Error Creating Client")
 		return nil, err
@@ -50,11 +74,44 @@ func NewClient(ctx context.Context, endpoint, username, password, serialNumber,
 		SymmetrixID:       serialNumber,
 		PmaxOpenapiClient: openapiClient,
 	}
+	transport := openapiClient.GetConfig().HTTPClient.Transport
+	if cache, ok := transport.(*cacheTransport); ok {
+		client.cache = cache
+		transport = cache.next
+	}
+	if session, ok := transport.(*sessionTransport); ok {
+		client.session = session
+		registerSession(&client)
+		transport = session.next
+	}
+	if array, ok := transport.(*arrayTransport); ok {
+		client.limits, _ = array.next.(*limitTransport)
+	}
 	return &client, nil
 }
 
+// WithSerialNumber returns a client managing the array serialNumber through the same Unisphere
+// session. It returns c when serialNumber is empty or already the array of c.
+func (c *Client) WithSerialNumber(serialNumber string) *Client {
+	if serialNumber == "" || serialNumber == c.SymmetrixID {
+		return c
+	}
+	array := *c
+	array.SymmetrixID = serialNumber
+	return &array
+}
+
+// Close logs out of the Unisphere session of the client and releases its connections.
+func (c *Client) Close(ctx context.Context) {
+	if c.session != nil {
+		c.session.close(ctx)
+		unregisterSession(c.session)
+	}
+	c.PmaxOpenapiClient.GetConfig().HTTPClient.CloseIdleConnections()
+}
+
 // NewClient returns the OpenAPI client.
-func NewOpenApiClient(ctx context.Context, endpoint, username, password, serialNumber, pmaxVersion string, insecure bool) (*pmaxop.APIClient, error) {
+func NewOpenApiClient(ctx context.Context, endpoint, username, password, serialNumber, pmaxVersion string, insecure bool, opts ClientOptions) (*pmaxop.APIClient, error) {
 	// Setup a User-Agent for your API client (replace the provider name for yours):
 	userAgent := "terraform-powermax-provider/1.0.0"
 	jar, err := cookiejar.New(nil)
@@ -63,41 +120,45 @@ func NewOpenApiClient(ctx context.Context, endpoint, username, password, serialN
 	}
 
 	httpclient := &http.Client{
-		Timeout: (time.Duration(60) * time.Second),
-		Jar:     jar,
+		Jar: jar,
 	}
-	if insecure {
-		/* #nosec */
-		httpclient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				MinVersion:         tls.VersionTLS12,
-				InsecureSkipVerify: true,
-			},
-		}
-	} else {
-		// Loading system certs by default if insecure is set to false
-		pool, err := x509.SystemCertPool()
-		if err != nil {
-			errSysCerts := errors.New("unable to initialize cert pool from system")
-			return nil, errSysCerts
-		}
-		httpclient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				MinVersion:         tls.VersionTLS12,
-				RootCAs:            pool,
-				InsecureSkipVerify: false,
-			},
-		}
+	tlsConfig, err := newTLSConfig(insecure, opts.TLS)
+	if err != nil {
+		return nil, err
 	}
+	transport, err := newTransport(tlsConfig, opts.Transport)
+	if err != nil {
+		return nil, err
+	}
+	httpclient.Transport = transport
+	// Record or replay the exchanges on the wire, retries and authentication included
+	httpclient.Transport, err = newRecorderTransport(httpclient.Transport, username, password, serialNumber, opts.Recorder)
+	if err != nil {
+		return nil, err
+	}
+	// Log every attempt as sent on the wire, secrets redacted
+	httpclient.Transport = newTraceTransport(httpclient.Transport, opts.Trace, password)
+	base := httpclient.Transport
+
+	// Bound every attempt with the request timeout and retry transient failures on top of it
+	httpclient.Transport = newRetryTransport(newTimeoutTransport(httpclient.Transport, opts.Timeout), opts.Retry)
+	// Limit the requests in flight and serialize the mutations of a same object, retries included
+	httpclient.Transport = newLimitTransport(httpclient.Transport, opts.MaxConcurrentRequests)
+	// Tell Unisphere which array is addressed, the serial number can be overridden per resource
+	httpclient.Transport = newArrayTransport(httpclient.Transport, serialNumber)
+	// Authenticate once and reuse the session cookie stored in the jar
+	httpclient.Transport = newSessionTransport(httpclient.Transport, base, jar, username, password, endpoint+LogoutPath)
+	// Serve the reads repeated during a plan from memory, before sending anything
+	httpclient.Transport = newCacheTransport(httpclient.Transport, opts.CacheTTL)
 
 	url := fmt.Sprintf("%s/univmax/restapi", endpoint)
-	basicAuthString := basicAuth(username, password)
 
 	cfg := &pmaxop.Configuration{
 		HTTPClient:    httpclient,
 		DefaultHeader: make(map[string]string),
 		UserAgent:     userAgent,
-		Debug:         false,
+		// The SDK would dump the requests with their credentials, the trace transport logs them redacted
+		Debug: false,
 		Servers: pmaxop.ServerConfigurations{
 			{
 				URL:         url,
@@ -107,10 +168,6 @@ func NewOpenApiClient(ctx context.Context, endpoint, username, password, serialN
 		OperationServers: map[string]pmaxop.ServerConfigurations{},
 	}
 	cfg.DefaultHeader = getHeaders()
-	cfg.AddDefaultHeader("Authorization", "Basic "+basicAuthString)
-	if serialNumber != "" {
-		cfg.AddDefaultHeader("symid", serialNumber)
-	}
 
 	apiClient := pmaxop.NewAPIClient(cfg)
 	return apiClient, nil
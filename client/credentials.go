@@ -0,0 +1,150 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultCredentialProcessTimeout is how long the credential process is allowed to run.
+const DefaultCredentialProcessTimeout = time.Minute
+
+// Credentials are the Unisphere credentials returned by a credential process.
+// Username is empty when the process only returned a password.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ReadPasswordFile returns the password stored in the file at path, like a mounted secret.
+// The trailing line break of the file is not part of the password.
+func ReadPasswordFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the password file: %w", err)
+	}
+	password := strings.TrimRight(string(content), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("the password file %s is empty", path)
+	}
+	return password, nil
+}
+
+// RunCredentialProcess runs command and returns the credentials it writes to stdout, either as a
+// JSON object with the password and optionally the username, or as the raw password. The command
+// is split into arguments like a shell would, with single and double quotes, but no shell is run.
+func RunCredentialProcess(ctx context.Context, command string) (Credentials, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if len(args) == 0 {
+		return Credentials{}, errors.New("the credential process is empty")
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultCredentialProcessTimeout)
+		defer cancel()
+	}
+	// The command is configured by the user on purpose.
+	/* #nosec */
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return Credentials{}, fmt.Errorf("the credential process %s failed: %w: %s", args[0], err, message)
+		}
+		return Credentials{}, fmt.Errorf("the credential process %s failed: %w", args[0], err)
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	var credentials Credentials
+	if strings.HasPrefix(output, "{") {
+		if err := json.Unmarshal([]byte(output), &credentials); err != nil {
+			return Credentials{}, fmt.Errorf("unable to decode the output of the credential process %s: %w", args[0], err)
+		}
+	} else {
+		credentials.Password = output
+	}
+	if credentials.Password == "" {
+		return Credentials{}, fmt.Errorf("the credential process %s returned no password", args[0])
+	}
+	return credentials, nil
+}
+
+// splitCommand splits a command line into arguments separated by spaces. Single quotes keep their
+// content as is, double quotes and backslashes escape the next character.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, c := range command {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+			escaped = false
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("the credential process has an unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCredentialProcessHelper is the credential process run by the tests, it prints the output
+// selected by POWERMAX_TEST_CREDENTIAL_PROCESS and does nothing otherwise.
+func TestCredentialProcessHelper(t *testing.T) {
+	switch os.Getenv("POWERMAX_TEST_CREDENTIAL_PROCESS") {
+	case "raw":
+		fmt.Println("s3cr3t")
+	case "json":
+		fmt.Println(`{"username": "smc", "password": "s3cr3t"}`)
+	case "empty":
+		fmt.Println(`{"username": "smc"}`)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "vault is sealed")
+		os.Exit(2)
+	default:
+		return
+	}
+	os.Exit(0)
+}
+
+func credentialProcess(t *testing.T, mode string) string {
+	t.Setenv("POWERMAX_TEST_CREDENTIAL_PROCESS", mode)
+	return fmt.Sprintf("'%s' -test.run '^TestCredentialProcessHelper$'", os.Args[0])
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	assert.NoError(t, os.WriteFile(passwordFile, []byte("s3cr3t \n"), 0o600))
+	password, err := ReadPasswordFile(passwordFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t ", password)
+
+	emptyFile := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(emptyFile, []byte("\n"), 0o600))
+	_, err = ReadPasswordFile(emptyFile)
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = ReadPasswordFile(filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "unable to read the password file")
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	ctx := context.Background()
+	credentials, err := RunCredentialProcess(ctx, credentialProcess(t, "raw"))
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Password: "s3cr3t"}, credentials)
+
+	credentials, err = RunCredentialProcess(ctx, credentialProcess(t, "json"))
+	assert.NoError(t, err)
+	assert.Equal(t, Credentials{Username: "smc", Password: "s3cr3t"}, credentials)
+
+	_, err = RunCredentialProcess(ctx, credentialProcess(t, "empty"))
+	assert.ErrorContains(t, err, "returned no password")
+
+	_, err = RunCredentialProcess(ctx, credentialProcess(t, "fail"))
+	assert.ErrorContains(t, err, "vault is sealed")
+
+	_, err = RunCredentialProcess(ctx, "  ")
+	assert.ErrorContains(t, err, "is empty")
+}
+
+func TestSplitCommand(t *testing.T) {
+	args, err := splitCommand(`vault kv get -field="pass word" 'secret/power max' a\ b`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vault", "kv", "get", "-field=pass word", "secret/power max", "a b"}, args)
+
+	args, err = splitCommand(`cmd '' ""`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cmd", "", ""}, args)
+
+	_, err = splitCommand(`cmd "unterminated`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// ErrorCategory classifies the errors returned by Unisphere.
+type ErrorCategory string
+
+// Categories of the errors returned by Unisphere.
+const (
+	CategoryNotFound   ErrorCategory = "not found"
+	CategoryConflict   ErrorCategory = "conflict"
+	CategoryValidation ErrorCategory = "validation"
+	CategoryAuth       ErrorCategory = "authentication"
+	CategoryTransient  ErrorCategory = "transient"
+	CategoryUnknown    ErrorCategory = "unknown"
+)
+
+// APIError is a decoded Unisphere REST error.
+type APIError struct {
+	// StatusCode is the HTTP status of the response, 0 when no response was received.
+	StatusCode int
+	// Message is the error message reported by Unisphere.
+	Message string
+	// JobID is the ID of the Unisphere job which failed, if any.
+	JobID string
+	// Object is the name of the object the error is about, if Unisphere reported it.
+	Object   string
+	Category ErrorCategory
+	Err      error
+}
+
+// Error returns the Unisphere message followed by the HTTP status and job details.
+func (e *APIError) Error() string {
+	var details []string
+	if e.StatusCode != 0 {
+		details = append(details, fmt.Sprintf("HTTP %d", e.StatusCode))
+	}
+	if e.JobID != "" {
+		details = append(details, "job "+e.JobID)
+	}
+	if e.Object != "" {
+		details = append(details, "object "+e.Object)
+	}
+	if len(details) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(details, ", "))
+}
+
+// Unwrap returns the error returned by the SDK.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// errorBody is the error payload returned by Unisphere.
+type errorBody struct {
+	Message string `json:"message"`
+	JobID   string `json:"jobId"`
+	Result  string `json:"result"`
+	Object  string `json:"object"`
+}
+
+// ParseAPIError decodes an error returned by the SDK into an APIError.
+// It returns nil when err is nil.
+func ParseAPIError(err error) *APIError {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	apiErr = &APIError{
+		Message:  err.Error(),
+		Category: CategoryUnknown,
+		Err:      err,
+	}
+
+	var openAPIErr interface {
+		error
+		Body() []byte
+	}
+	if errors.As(err, &openAPIErr) {
+		apiErr.StatusCode = parseStatusCode(openAPIErr.Error())
+		var body errorBody
+		if json.Unmarshal(openAPIErr.Body(), &body) == nil {
+			switch {
+			case body.Message != "":
+				apiErr.Message = body.Message
+			case body.Result != "":
+				apiErr.Message = body.Result
+			}
+			apiErr.JobID = body.JobID
+			apiErr.Object = body.Object
+		}
+	}
+	apiErr.Category = categorize(apiErr.StatusCode, err)
+	return apiErr
+}
+
+// parseStatusCode reads the HTTP status from an SDK error message like "404 Not Found".
+func parseStatusCode(message string) int {
+	fields := strings.Fields(message)
+	if len(fields) == 0 {
+		return 0
+	}
+	status, err := strconv.Atoi(fields[0])
+	if err != nil || status < 100 || status > 599 {
+		return 0
+	}
+	return status
+}
+
+// categorize maps the HTTP status or transport error to an ErrorCategory.
+func categorize(status int, err error) ErrorCategory {
+	switch {
+	case status == http.StatusNotFound:
+		return CategoryNotFound
+	case status == http.StatusConflict:
+		return CategoryConflict
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return CategoryValidation
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return CategoryAuth
+	case status == http.StatusTooManyRequests, status >= http.StatusInternalServerError:
+		return CategoryTransient
+	case status == 0 && errors.Is(err, context.DeadlineExceeded):
+		return CategoryTransient
+	}
+	return CategoryUnknown
+}
+
+// IsNotFound reports whether err is a Unisphere "not found" error.
+func IsNotFound(err error) bool {
+	apiErr := ParseAPIError(err)
+	return apiErr != nil && apiErr.Category == CategoryNotFound
+}
+
+// ErrorDiagnostic converts an error into a diagnostic with the given summary. The detail starts
+// with prefix, followed by the decoded Unisphere error and a hint for its category. Validation
+// and conflict errors are attached to attributePath when it is not empty, so that Terraform
+// highlights the offending attribute.
+func ErrorDiagnostic(summary, prefix string, err error, attributePath path.Path) diag.Diagnostic {
+	apiErr := ParseAPIError(err)
+	detail := apiErr.Error()
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		detail = prefix + " " + detail
+	}
+	switch apiErr.Category {
+	case CategoryNotFound:
+		detail += "\nThe object was not found on the PowerMax array."
+	case CategoryAuth:
+		detail += "\nPlease validate the credentials and the roles of the user."
+	case CategoryTransient:
+		if errors.Is(err, context.DeadlineExceeded) {
+			detail += "\nThe operation did not complete in time, the timeout can be increased with the timeouts attribute."
+			break
+		}
+		detail += "\nThe PowerMax array is busy or unreachable, please retry the operation."
+	}
+
+	if !attributePath.Equal(path.Empty()) && (apiErr.Category == CategoryValidation || apiErr.Category == CategoryConflict) {
+		return diag.NewAttributeErrorDiagnostic(attributePath, summary, detail)
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
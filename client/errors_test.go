@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func getSymmError(t *testing.T, status int, body string) error {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", false, ClientOptions{})
+	assert.NoError(t, err)
+	_, _, err = client.PmaxOpenapiClient.SystemApi.GetSymm(context.Background(), "000000000001").Execute()
+	assert.Error(t, err)
+	return err
+}
+
+func TestParseAPIError(t *testing.T) {
+	err := getSymmError(t, http.StatusConflict, `{"message":"Storage group sg1 already exists","jobId":"1234","object":"sg1"}`)
+	apiErr := ParseAPIError(err)
+	assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	assert.Equal(t, "Storage group sg1 already exists", apiErr.Message)
+	assert.Equal(t, "1234", apiErr.JobID)
+	assert.Equal(t, "sg1", apiErr.Object)
+	assert.Equal(t, CategoryConflict, apiErr.Category)
+	assert.Equal(t, "Storage group sg1 already exists (HTTP 409, job 1234, object sg1)", apiErr.Error())
+	assert.Same(t, apiErr, ParseAPIError(fmt.Errorf("wrapped: %w", apiErr)))
+
+	apiErr = ParseAPIError(getSymmError(t, http.StatusBadGateway, `<html>Bad Gateway</html>`))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, CategoryTransient, apiErr.Category)
+
+	apiErr = ParseAPIError(errors.New("mock error"))
+	assert.Equal(t, CategoryUnknown, apiErr.Category)
+	assert.Equal(t, "mock error", apiErr.Error())
+
+	assert.Nil(t, ParseAPIError(nil))
+}
+
+func TestIsNotFound(t *testing.T) {
+	notFound := getSymmError(t, http.StatusNotFound, `{"message":"Cannot find Storage Group sg1"}`)
+	assert.True(t, IsNotFound(notFound))
+	assert.True(t, IsNotFound(fmt.Errorf("StorageGroup sg1 is not on the powermax: %w", notFound)))
+	assert.False(t, IsNotFound(getSymmError(t, http.StatusUnauthorized, `{"message":"Unauthorized"}`)))
+	assert.False(t, IsNotFound(errors.New("mock error")))
+	assert.False(t, IsNotFound(nil))
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	err := getSymmError(t, http.StatusBadRequest, `{"message":"Invalid storage group name"}`)
+	d := ErrorDiagnostic("Error creating storage group", "Could not create sg1:", err, path.Root("name"))
+	withPath, ok := d.(diag.DiagnosticWithPath)
+	assert.True(t, ok)
+	assert.Equal(t, path.Root("name"), withPath.Path())
+	assert.Equal(t, "Error creating storage group", d.Summary())
+	assert.Equal(t, "Could not create sg1: Invalid storage group name (HTTP 400)", d.Detail())
+
+	d = ErrorDiagnostic("Error reading storage group", "", getSymmError(t, http.StatusServiceUnavailable, `{}`), path.Root("name"))
+	_, ok = d.(diag.DiagnosticWithPath)
+	assert.False(t, ok)
+	assert.Contains(t, d.Detail(), "please retry the operation")
+
+	d = ErrorDiagnostic("Error creating volume", "", fmt.Errorf("Post \"https://unisphere/\": %w", context.DeadlineExceeded), path.Empty())
+	assert.Contains(t, d.Detail(), "increased with the timeouts attribute")
+	assert.NotContains(t, d.Detail(), "please retry the operation")
+}
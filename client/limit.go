@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMaxConcurrentRequests is the number of requests sent in parallel when the provider does not configure it.
+const DefaultMaxConcurrentRequests = 8
+
+// StorageGroupObject is the kind of the storage groups locked by LockObject.
+const StorageGroupObject = "storagegroup"
+
+// lockedObjects are the path segments of the objects whose mutations are serialized.
+var lockedObjects = map[string]bool{
+	"storagegroup":    true,
+	"host":            true,
+	"hostgroup":       true,
+	"portgroup":       true,
+	"maskingview":     true,
+	"volume":          true,
+	"snapshot_policy": true,
+}
+
+// limitTransport is a http.RoundTripper bounding the number of requests in flight, and
+// serializing the mutations of a same object: Unisphere answers lock errors when a storage group,
+// host or port group is modified by concurrent requests.
+type limitTransport struct {
+	next      http.RoundTripper
+	semaphore chan struct{}
+
+	mu      sync.Mutex
+	objects map[string]chan struct{}
+}
+
+// heldObjectsKey is the context key of the objects locked by LockObject.
+type heldObjectsKey struct{}
+
+// holdsObject reports whether the object is locked by the context.
+func holdsObject(ctx context.Context, key string) bool {
+	held, _ := ctx.Value(heldObjectsKey{}).(map[string]bool)
+	return held[key]
+}
+
+// newLimitTransport wraps the given transport with maxConcurrent requests in flight at most.
+func newLimitTransport(next http.RoundTripper, maxConcurrent int) *limitTransport {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentRequests
+	}
+	return &limitTransport{
+		next:      next,
+		semaphore: make(chan struct{}, maxConcurrent),
+		objects:   make(map[string]chan struct{}),
+	}
+}
+
+// RoundTrip sends the request once the object it mutates is free and a request slot is available.
+// The requests sent with a context holding the lock of the object, from LockObject, do not wait for it.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if key := objectKey(req); key != "" && !holdsObject(req.Context(), key) {
+		unlock, err := t.lockObject(req.Context(), key)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	select {
+	case t.semaphore <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.semaphore }()
+	return t.next.RoundTrip(req)
+}
+
+// lockObject waits until the object is free or the context is done, and returns the function
+// releasing the object.
+func (t *limitTransport) lockObject(ctx context.Context, key string) (func(), error) {
+	lock := t.objectLock(key)
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	tflog.Trace(ctx, "Acquired PowerMax object lock", map[string]interface{}{
+		"object": key,
+	})
+	return func() { <-lock }, nil
+}
+
+// objectLock returns the lock of the object, a channel holding one value while it is locked,
+// creating it on first use.
+func (t *limitTransport) objectLock(key string) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lock, ok := t.objects[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		t.objects[key] = lock
+	}
+	return lock
+}
+
+// LockObject locks an object of the array of the client beyond a single request, like an
+// asynchronous edit of a storage group and the job it starts, so that the other mutations of the
+// object wait for the job. kind is the path segment of the object, like StorageGroupObject. The
+// requests sent with the returned context are not blocked by the lock, which unlock releases.
+func (c *Client) LockObject(ctx context.Context, kind, name string) (context.Context, func(), error) {
+	key := c.SymmetrixID + "/" + kind + "/" + name
+	if c.limits == nil || holdsObject(ctx, key) {
+		return ctx, func() {}, nil
+	}
+	unlock, err := c.limits.lockObject(ctx, key)
+	if err != nil {
+		return ctx, nil, err
+	}
+	held := map[string]bool{key: true}
+	if parent, ok := ctx.Value(heldObjectsKey{}).(map[string]bool); ok {
+		for k := range parent {
+			held[k] = true
+		}
+	}
+	return context.WithValue(ctx, heldObjectsKey{}, held), unlock, nil
+}
+
+// objectKey returns the object mutated by the request, like "000120000001/storagegroup/sg1", or "" for reads and
+// for requests which do not address a single object.
+func objectKey(req *http.Request) string {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ""
+	}
+	// Paths look like /univmax/restapi/100/sloprovisioning/symmetrix/{id}/storagegroup/{name}/...
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "symmetrix" && i+3 < len(segments) && lockedObjects[segments[i+2]] {
+			return segments[i+1] + "/" + segments[i+2] + "/" + segments[i+3]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,157 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyServer answers after a short delay and records the peak of requests in flight per path.
+func concurrencyServer(t *testing.T) (*httptest.Server, func(path string) int32) {
+	var mu sync.Mutex
+	inFlight := map[string]*int32{}
+	peak := map[string]*int32{}
+	counter := func(m map[string]*int32, key string) *int32 {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := m[key]; !ok {
+			m[key] = new(int32)
+		}
+		return m[key]
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, key := range []string{r.URL.Path, "all"} {
+			current := atomic.AddInt32(counter(inFlight, key), 1)
+			defer atomic.AddInt32(counter(inFlight, key), -1)
+			for {
+				highest := atomic.LoadInt32(counter(peak, key))
+				if current <= highest || atomic.CompareAndSwapInt32(counter(peak, key), highest, current) {
+					break
+				}
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(server.Close)
+	return server, func(path string) int32 { return atomic.LoadInt32(counter(peak, path)) }
+}
+
+func sendParallel(t *testing.T, transport http.RoundTripper, method string, urls []string) {
+	var wg sync.WaitGroup
+	for _, url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			req, err := http.NewRequest(method, url, nil)
+			assert.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+		}(url)
+	}
+	wg.Wait()
+}
+
+func TestLimitTransportSerializesObjectMutations(t *testing.T) {
+	server, peak := concurrencyServer(t)
+	transport := newLimitTransport(http.DefaultTransport, 10)
+	sgPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/storagegroup/sg1"
+
+	urls := make([]string, 0, 8)
+	for i := 0; i < 4; i++ {
+		urls = append(urls, server.URL+sgPath, server.URL+"/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/host/host"+strings.Repeat("1", i+1))
+	}
+	sendParallel(t, transport, http.MethodPut, urls)
+	assert.Equal(t, int32(1), peak(sgPath))
+	assert.Greater(t, peak("all"), int32(1))
+}
+
+func TestLimitTransportBoundsRequestsInFlight(t *testing.T) {
+	server, peak := concurrencyServer(t)
+	transport := newLimitTransport(http.DefaultTransport, 2)
+	sgPath := "/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/storagegroup/sg1"
+
+	urls := make([]string, 6)
+	for i := range urls {
+		urls[i] = server.URL + sgPath
+	}
+	sendParallel(t, transport, http.MethodGet, urls)
+	assert.Equal(t, int32(2), peak("all"))
+}
+
+func TestObjectKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "https://pmax:8443/univmax/restapi/100/replication/symmetrix/000000000001/storagegroup/sg1/snapshot/snap1", nil)
+	assert.Equal(t, "000000000001/storagegroup/sg1", objectKey(req))
+
+	req = httptest.NewRequest(http.MethodGet, "https://pmax:8443/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/storagegroup/sg1", nil)
+	assert.Equal(t, "", objectKey(req))
+
+	req = httptest.NewRequest(http.MethodPost, "https://pmax:8443/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/storagegroup", nil)
+	assert.Equal(t, "", objectKey(req))
+}
+
+func TestLockObject(t *testing.T) {
+	server, _ := concurrencyServer(t)
+	client, err := NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", false, ClientOptions{})
+	assert.NoError(t, err)
+	sgURL := server.URL + "/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/storagegroup/sg1"
+	put := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, sgURL, nil)
+		assert.NoError(t, err)
+		resp, err := client.PmaxOpenapiClient.GetConfig().HTTPClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	lockCtx, unlock, err := client.LockObject(context.Background(), StorageGroupObject, "sg1")
+	assert.NoError(t, err)
+	// The requests of the holder are not blocked by the lock
+	assert.NoError(t, put(lockCtx))
+	nested, unlockNested, err := client.LockObject(lockCtx, StorageGroupObject, "sg1")
+	assert.NoError(t, err)
+	unlockNested()
+	assert.NoError(t, put(nested))
+
+	// The others wait for it, until their context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, put(ctx), context.DeadlineExceeded)
+	_, _, err = client.LockObject(ctx, StorageGroupObject, "sg1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	done := make(chan error, 1)
+	go func() { done <- put(context.Background()) }()
+	select {
+	case <-done:
+		t.Fatal("the request did not wait for the lock")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,306 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RecorderMode selects whether the exchanges with Unisphere are recorded or replayed.
+type RecorderMode string
+
+// Modes of the recorder.
+const (
+	// RecorderDisabled sends the requests to Unisphere without recording them.
+	RecorderDisabled RecorderMode = ""
+	// RecorderRecord sends the requests to Unisphere and records the exchanges to the cassette.
+	RecorderRecord RecorderMode = "record"
+	// RecorderReplay answers the requests from the cassette, nothing is sent to Unisphere.
+	RecorderReplay RecorderMode = "replay"
+)
+
+// redacted replaces the credentials and secrets in the cassettes.
+const redacted = "REDACTED"
+
+// ErrNotRecorded is returned in replay mode for a request which is not in the cassette.
+var ErrNotRecorded = errors.New("no matching request recorded in the cassette")
+
+// RecorderOptions configures the record and replay of the exchanges with Unisphere, which lets
+// tests capture a run against an array once and replay it without hardware.
+type RecorderOptions struct {
+	Mode RecorderMode
+	// Cassette is the JSON file the exchanges are recorded to and replayed from.
+	Cassette string
+	// Secrets are scrubbed from the cassette wherever they appear, in addition to the username and
+	// password of the client.
+	Secrets []string
+	// SerialNumbers are the arrays addressed besides the serial number of the client.
+	// Like the serial number of the client, they are recorded as placeholder serial numbers
+	// so a cassette can be replayed with any serial number.
+	SerialNumbers []string
+}
+
+// cassette is the content of a cassette file.
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+// interaction is a recorded request and the response of Unisphere.
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+
+	// replayed is set once the interaction has answered a request.
+	replayed bool
+}
+
+// recordedRequest is a scrubbed request: the host and the headers, credentials included, are not recorded.
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	recordedBody
+}
+
+// recordedResponse is a scrubbed response of Unisphere.
+type recordedResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	recordedBody
+}
+
+// recordedBody keeps JSON bodies as JSON so that cassettes can be reviewed, other bodies as text.
+type recordedBody struct {
+	JSON json.RawMessage `json:"json,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+// newRecordedBody returns the recorded form of body.
+func newRecordedBody(body []byte) recordedBody {
+	var compacted bytes.Buffer
+	if len(body) > 0 && json.Compact(&compacted, body) == nil {
+		return recordedBody{JSON: compacted.Bytes()}
+	}
+	return recordedBody{Text: string(body)}
+}
+
+// bytes returns the recorded body, JSON bodies are compacted since cassettes may be edited by hand.
+func (b recordedBody) bytes() []byte {
+	if len(b.JSON) > 0 {
+		var compacted bytes.Buffer
+		if json.Compact(&compacted, b.JSON) == nil {
+			return compacted.Bytes()
+		}
+		return b.JSON
+	}
+	return []byte(b.Text)
+}
+
+// scrubber replaces the credentials, secrets and serial numbers in the recorded exchanges.
+type scrubber struct {
+	scrub   *strings.Replacer
+	restore *strings.Replacer
+}
+
+// newScrubber returns a scrubber redacting secrets and recording the serial numbers as
+// placeholders, 000000000001 for the first one, 000000000002 for the second one and so on.
+func newScrubber(secrets []string, serialNumbers []string) *scrubber {
+	var scrub, restore []string
+	for _, secret := range secrets {
+		if secret != "" {
+			scrub = append(scrub, secret, redacted)
+		}
+	}
+	for i, serialNumber := range serialNumbers {
+		if serialNumber == "" {
+			continue
+		}
+		placeholder := fmt.Sprintf("%012d", i+1)
+		scrub = append(scrub, serialNumber, placeholder)
+		restore = append(restore, placeholder, serialNumber)
+	}
+	return &scrubber{
+		scrub:   strings.NewReplacer(scrub...),
+		restore: strings.NewReplacer(restore...),
+	}
+}
+
+// recorderTransport is a http.RoundTripper recording the exchanges with Unisphere to a
+// cassette, or replaying them from it.
+type recorderTransport struct {
+	next     http.RoundTripper
+	mode     RecorderMode
+	path     string
+	scrubber *scrubber
+
+	mu       sync.Mutex
+	cassette cassette
+}
+
+// newRecorderTransport wraps the given transport with the recorder. It returns next when
+// recording is disabled, and fails in replay mode when the cassette cannot be read.
+func newRecorderTransport(next http.RoundTripper, username, password, serialNumber string, opts RecorderOptions) (http.RoundTripper, error) {
+	switch opts.Mode {
+	case RecorderDisabled:
+		return next, nil
+	case RecorderRecord, RecorderReplay:
+	default:
+		return nil, fmt.Errorf("invalid recorder mode %q, must be %q or %q", opts.Mode, RecorderRecord, RecorderReplay)
+	}
+	if opts.Cassette == "" {
+		return nil, errors.New("the recorder requires a cassette file")
+	}
+
+	t := &recorderTransport{
+		next:     next,
+		mode:     opts.Mode,
+		path:     opts.Cassette,
+		scrubber: newScrubber(append([]string{username, password}, opts.Secrets...), append([]string{serialNumber}, opts.SerialNumbers...)),
+	}
+	if opts.Mode == RecorderReplay {
+		content, err := os.ReadFile(opts.Cassette)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the cassette: %w", err)
+		}
+		if err := json.Unmarshal(content, &t.cassette); err != nil {
+			return nil, fmt.Errorf("unable to decode the cassette %s: %w", opts.Cassette, err)
+		}
+	}
+	return t, nil
+}
+
+// RoundTrip records the exchange with Unisphere, or answers the request from the cassette.
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := recordedRequest{
+		Method:       req.Method,
+		URL:          t.scrubber.scrub.Replace(req.URL.RequestURI()),
+		recordedBody: newRecordedBody([]byte(t.scrubber.scrub.Replace(string(body)))),
+	}
+
+	if t.mode == RecorderReplay {
+		return t.replay(req, recorded)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	err = t.record(&interaction{
+		Request: recorded,
+		Response: recordedResponse{
+			StatusCode:   resp.StatusCode,
+			ContentType:  resp.Header.Get("Content-Type"),
+			recordedBody: newRecordedBody([]byte(t.scrubber.scrub.Replace(string(respBody)))),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// record appends the interaction to the cassette. The cassette is saved after every
+// interaction so that an interrupted run keeps what it recorded.
+func (t *recorderTransport) record(recorded *interaction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, recorded)
+	content, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o750); err != nil {
+		return fmt.Errorf("unable to save the cassette: %w", err)
+	}
+	if err := os.WriteFile(t.path, append(content, '\n'), 0o600); err != nil {
+		return fmt.Errorf("unable to save the cassette: %w", err)
+	}
+	return nil
+}
+
+// replay answers the request with the first interaction of the cassette which was not replayed
+// yet and has the same method, URL and body. Interactions are replayed in the recorded order,
+// so polling a same URL gets the successive recorded responses.
+func (t *recorderTransport) replay(req *http.Request, recorded recordedRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, candidate := range t.cassette.Interactions {
+		if candidate.replayed || candidate.Request.Method != recorded.Method || candidate.Request.URL != recorded.URL ||
+			!bytes.Equal(candidate.Request.bytes(), recorded.bytes()) {
+			continue
+		}
+		candidate.replayed = true
+		tflog.Trace(req.Context(), "Replaying recorded PowerMax response", map[string]interface{}{
+			"method": recorded.Method,
+			"url":    recorded.URL,
+		})
+
+		header := make(http.Header)
+		if candidate.Response.ContentType != "" {
+			header.Set("Content-Type", candidate.Response.ContentType)
+		}
+		body := t.scrubber.restore.Replace(string(candidate.Response.bytes()))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", candidate.Response.StatusCode, http.StatusText(candidate.Response.StatusCode)),
+			StatusCode:    candidate.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, recorded.Method, recorded.URL)
+}
+
+// readRequestBody reads the body of the request and makes it readable again for the next transport.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
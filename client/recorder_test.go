@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-powermax/client/unispheretest"
+	"testing"
+
+	pmax "dell/powermax-go-client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	ctx := context.Background()
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "storagegroup.json")
+	server := unispheretest.NewServerForArray("000197600123")
+	t.Cleanup(server.Close)
+
+	recording, err := NewClient(ctx, server.URL, unispheretest.Username, unispheretest.Password, server.SerialNumber, "", true, ClientOptions{
+		Recorder: RecorderOptions{Mode: RecorderRecord, Cassette: cassettePath},
+	})
+	require.NoError(t, err)
+	require.NoError(t, recording.ValidateConnection(ctx, ""))
+	_, _, err = recording.PmaxOpenapiClient.SLOProvisioningApi.CreateStorageGroup(ctx, recording.SymmetrixID).
+		CreateStorageGroupParam(pmax.CreateStorageGroupParam{StorageGroupId: "sg1", SrpId: pmax.PtrString(unispheretest.SRP)}).Execute()
+	require.NoError(t, err)
+	recorded, _, err := recording.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, recording.SymmetrixID, "sg1").Execute()
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(cassettePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), server.SerialNumber)
+	assert.NotContains(t, string(content), unispheretest.Password)
+	assert.NotContains(t, string(content), "Authorization")
+	assert.Contains(t, string(content), "/symmetrix/000000000001/storagegroup/sg1")
+
+	// The cassette is replayed for another array without reaching Unisphere
+	replaying, err := NewClient(ctx, "https://unisphere.invalid:8443", "user", "secret", "000197600999", "", true, ClientOptions{
+		Recorder: RecorderOptions{Mode: RecorderReplay, Cassette: cassettePath},
+	})
+	require.NoError(t, err)
+	require.NoError(t, replaying.ValidateConnection(ctx, ""))
+	_, _, err = replaying.PmaxOpenapiClient.SLOProvisioningApi.CreateStorageGroup(ctx, replaying.SymmetrixID).
+		CreateStorageGroupParam(pmax.CreateStorageGroupParam{StorageGroupId: "sg1", SrpId: pmax.PtrString(unispheretest.SRP)}).Execute()
+	require.NoError(t, err)
+	replayed, _, err := replaying.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, replaying.SymmetrixID, "sg1").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, recorded.GetStorageGroupId(), replayed.GetStorageGroupId())
+	assert.Equal(t, recorded.GetSrp(), replayed.GetSrp())
+
+	// Every interaction is replayed once
+	_, _, err = replaying.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, replaying.SymmetrixID, "sg1").Execute()
+	assert.True(t, errors.Is(err, ErrNotRecorded), err)
+}
+
+func TestRecorderReplaysInRecordedOrder(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "jobs.json")
+	require.NoError(t, os.WriteFile(cassettePath, []byte(`{"interactions": [
+		{"request": {"method": "GET", "url": "/univmax/restapi/100/system/job/1"}, "response": {"status_code": 200, "json": {"status": "RUNNING"}}},
+		{"request": {"method": "GET", "url": "/univmax/restapi/100/system/job/1"}, "response": {"status_code": 200, "json": {"status": "SUCCEEDED"}}},
+		{"request": {"method": "POST", "url": "/univmax/restapi/100/system/job", "json": {"name": "a"}}, "response": {"status_code": 409, "text": "conflict"}}
+	]}`), 0o600))
+	transport, err := newRecorderTransport(nil, "", "", "000000000001", RecorderOptions{Mode: RecorderReplay, Cassette: cassettePath})
+	require.NoError(t, err)
+
+	for _, expected := range []string{`{"status":"RUNNING"}`, `{"status":"SUCCEEDED"}`} {
+		req, err := http.NewRequest(http.MethodGet, "https://unisphere.invalid/univmax/restapi/100/system/job/1", nil)
+		require.NoError(t, err)
+		resp, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+		assert.Equal(t, expected, readBody(t, resp))
+	}
+
+	// Request bodies are compared once compacted
+	req, err := http.NewRequest(http.MethodPost, "https://unisphere.invalid/univmax/restapi/100/system/job", strings.NewReader(`{ "name": "a" }`))
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "conflict", readBody(t, resp))
+
+	req, err = http.NewRequest(http.MethodPost, "https://unisphere.invalid/univmax/restapi/100/system/job", strings.NewReader(`{"name": "b"}`))
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestRecorderOptionsValidation(t *testing.T) {
+	transport, err := newRecorderTransport(http.DefaultTransport, "", "", "", RecorderOptions{})
+	assert.NoError(t, err)
+	assert.Same(t, http.DefaultTransport, transport)
+
+	_, err = newRecorderTransport(http.DefaultTransport, "", "", "", RecorderOptions{Mode: "rewind", Cassette: "cassette.json"})
+	assert.ErrorContains(t, err, "invalid recorder mode")
+	_, err = newRecorderTransport(http.DefaultTransport, "", "", "", RecorderOptions{Mode: RecorderRecord})
+	assert.ErrorContains(t, err, "cassette")
+	_, err = newRecorderTransport(http.DefaultTransport, "", "", "", RecorderOptions{Mode: RecorderReplay, Cassette: filepath.Join(t.TempDir(), "missing.json")})
+	assert.ErrorContains(t, err, "unable to read the cassette")
+}
+
+func TestScrubber(t *testing.T) {
+	s := newScrubber([]string{"admin", "", "s3cret"}, []string{"000197600123", "", "000197600456"})
+	scrubbed := s.scrub.Replace("admin:s3cret 000197600123 000197600456")
+	assert.Equal(t, "REDACTED:REDACTED 000000000001 000000000003", scrubbed)
+	assert.Equal(t, "REDACTED:REDACTED 000197600123 000197600456", s.restore.Replace(scrubbed))
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
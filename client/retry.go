@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default retry settings used when the provider does not configure them.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryMinWait = 1 * time.Second
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// RetryConfig holds the retry and backoff settings of the client.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// MinWait is the base wait time used for the exponential backoff.
+	MinWait time.Duration
+	// MaxWait caps the wait time between two attempts.
+	MaxWait time.Duration
+}
+
+// retryTransport is a http.RoundTripper retrying transient failures with exponential backoff and jitter.
+type retryTransport struct {
+	next  http.RoundTripper
+	retry RetryConfig
+}
+
+// newRetryTransport wraps the given transport with the retry settings, filling in defaults for unset values.
+func newRetryTransport(next http.RoundTripper, retry RetryConfig) *retryTransport {
+	if retry.MaxRetries < 0 {
+		retry.MaxRetries = 0
+	}
+	if retry.MinWait <= 0 {
+		retry.MinWait = DefaultRetryMinWait
+	}
+	if retry.MaxWait <= 0 {
+		retry.MaxWait = DefaultRetryMaxWait
+	}
+	if retry.MaxWait < retry.MinWait {
+		retry.MaxWait = retry.MinWait
+	}
+	return &retryTransport{
+		next:  next,
+		retry: retry,
+	}
+}
+
+// RoundTrip executes the request and retries it while the failure is transient and safe to repeat.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.retry.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			// The body cannot be replayed, so the request cannot be sent again.
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		tflog.Debug(ctx, "Retrying PowerMax request", map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+		if resp != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait time before the next attempt, honoring a Retry-After header when present.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			wait := time.Duration(seconds) * time.Second
+			if wait > t.retry.MaxWait {
+				wait = t.retry.MaxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.retry.MinWait
+	for i := 0; i < attempt && wait < t.retry.MaxWait; i++ {
+		wait *= 2
+	}
+	if wait > t.retry.MaxWait {
+		wait = t.retry.MaxWait
+	}
+	// Equal jitter: keep half of the backoff and randomize the other half.
+	half := wait / 2
+	/* #nosec */
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// shouldRetry reports whether the outcome of a request is transient and the request is safe to send again.
+// Non idempotent requests (POST, PUT, ...) are only retried when the array did not process them,
+// so that a request creating volumes is never executed twice.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, req.Context().Err()) && req.Context().Err() != nil {
+			return false
+		}
+		if isIdempotent(req.Method) {
+			return isTransientError(err)
+		}
+		return isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// isIdempotent reports whether a request with the given method can be repeated without side effects.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isTransientError reports whether a transport error may not happen again: a timeout, a connection reset or
+// closed by the array, or a failure to connect.
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	return isDialError(err)
+}
+
+// isDialError reports whether the error happened while connecting, before the request was sent.
+// Certificate errors and unknown host names fail the same way on every attempt and are not dial errors.
+func isDialError(err error) bool {
+	if isPermanentError(err) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	return false
+}
+
+// isPermanentError reports whether the transport error is a TLS certificate error or an unknown host name.
+func isPermanentError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return true
+	}
+	var verifyErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verifyErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRetryClient(maxRetries int) *http.Client {
+	return &http.Client{
+		Transport: newRetryTransport(http.DefaultTransport, RetryConfig{
+			MaxRetries: maxRetries,
+			MinWait:    time.Millisecond,
+			MaxWait:    5 * time.Millisecond,
+		}),
+	}
+}
+
+func TestRetryTransportRetriesIdempotentRequest(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(3).Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportStopsAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(2).Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportDoesNotRepeatProcessedPost(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(3).Post(server.URL, "application/json", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportReplaysPostBodyOnTooManyRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"sg"}`, string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	resp, err := newTestRetryClient(3).Post(server.URL, "application/json", strings.NewReader(`{"name":"sg"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryTransportBackoffIsBounded(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, RetryConfig{
+		MaxRetries: 10,
+		MinWait:    time.Second,
+		MaxWait:    4 * time.Second,
+	})
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := transport.backoff(attempt, nil)
+		assert.GreaterOrEqual(t, wait, 500*time.Millisecond)
+		assert.LessOrEqual(t, wait, 4*time.Second)
+	}
+}
+
+func TestShouldRetryTransportErrors(t *testing.T) {
+	get := httptest.NewRequest(http.MethodGet, "https://unisphere/univmax/restapi/version", nil)
+	post := httptest.NewRequest(http.MethodPost, "https://unisphere/univmax/restapi/logout", nil)
+	dial := func(err error) error { return &net.OpError{Op: "dial", Net: "tcp", Err: err} }
+
+	tests := []struct {
+		name string
+		err  error
+		get  bool
+		post bool
+	}{
+		{"timeout", &net.DNSError{Err: "i/o timeout", IsTimeout: true}, true, false},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, true, false},
+		{"connection closed", io.EOF, true, false},
+		{"truncated response", io.ErrUnexpectedEOF, true, false},
+		{"connection refused", dial(syscall.ECONNREFUSED), true, true},
+		{"unknown host", dial(&net.DNSError{Err: "no such host", Name: "unisphere", IsNotFound: true}), false, false},
+		{"unknown authority", x509.UnknownAuthorityError{}, false, false},
+		{"invalid host name", x509.HostnameError{Host: "unisphere"}, false, false},
+		{"other error", errors.New("malformed HTTP response"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.get, shouldRetry(get, nil, tt.err), "GET")
+			assert.Equal(t, tt.post, shouldRetry(post, nil, tt.err), "POST")
+		})
+	}
+}
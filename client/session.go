@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// errSessionClosed is returned for requests sent after the session has been closed.
+var errSessionClosed = errors.New("the PowerMax session has been closed")
+
+// LogoutPath is the Unisphere endpoint ending the session of the cookie it is sent with.
+const LogoutPath = "/univmax/restapi/logout"
+
+// sessionTransport is a http.RoundTripper authenticating against Unisphere once and reusing the
+// session cookie afterwards. Basic credentials are only sent while no session is established,
+// and the session is transparently re-established when Unisphere answers 401.
+type sessionTransport struct {
+	next      http.RoundTripper
+	base      http.RoundTripper
+	jar       http.CookieJar
+	logoutURL string
+
+	mu       sync.RWMutex
+	username string
+	password string
+	closed   bool
+}
+
+// newSessionTransport wraps the given transport with session handling for the credentials. The session is
+// ended by a POST to logoutURL sent once through base, under the retries, when the transport is closed.
+func newSessionTransport(next, base http.RoundTripper, jar http.CookieJar, username, password, logoutURL string) *sessionTransport {
+	return &sessionTransport{
+		next:      next,
+		base:      base,
+		jar:       jar,
+		logoutURL: logoutURL,
+		username:  username,
+		password:  password,
+	}
+}
+
+// RoundTrip sends the request with the session cookie, or with Basic credentials when no session exists yet.
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	username, password, closed := t.username, t.password, t.closed
+	t.mu.RUnlock()
+	if closed {
+		return nil, errSessionClosed
+	}
+
+	// The http.Client adds the cookies of the jar before calling the transport.
+	if req.Header.Get("Cookie") == "" {
+		return t.next.RoundTrip(withBasicAuth(req, username, password))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	// The session expired or was invalidated, authenticate again.
+	tflog.Debug(req.Context(), "PowerMax session expired, authenticating again")
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	t.expireSession(req)
+
+	retry := withBasicAuth(req, username, password)
+	retry.Header.Del("Cookie")
+	if req.Body != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return t.next.RoundTrip(retry)
+}
+
+// expireSession removes the session cookies sent with the request from the cookie jar.
+func (t *sessionTransport) expireSession(req *http.Request) {
+	if t.jar == nil {
+		return
+	}
+	cookies := req.Cookies()
+	for _, cookie := range cookies {
+		cookie.MaxAge = -1
+	}
+	t.jar.SetCookies(req.URL, cookies)
+}
+
+// close ends the session: Unisphere is logged out of, the credentials are dropped and no further
+// request is sent.
+func (t *sessionTransport) close(ctx context.Context) {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+	t.closed = true
+	t.username = ""
+	t.password = ""
+	t.mu.Unlock()
+	t.logout(ctx)
+	tflog.Debug(ctx, "PowerMax session closed")
+}
+
+// logout ends the Unisphere session of the cookie jar, if one was established. The session expires
+// on its own when the logout fails.
+func (t *sessionTransport) logout(ctx context.Context) {
+	if t.jar == nil || t.logoutURL == "" {
+		return
+	}
+	logoutURL, err := url.Parse(t.logoutURL)
+	if err != nil {
+		return
+	}
+	cookies := t.jar.Cookies(logoutURL)
+	if len(cookies) == 0 {
+		return
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.logoutURL, nil)
+	if err != nil {
+		return
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to log out of Unisphere", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		tflog.Debug(ctx, "Unable to log out of Unisphere", map[string]interface{}{
+			"status": resp.StatusCode,
+		})
+	}
+	t.expireSession(req)
+}
+
+// withBasicAuth returns a copy of the request carrying the Basic credentials.
+func withBasicAuth(req *http.Request, username, password string) *http.Request {
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", "Basic "+basicAuth(username, password))
+	return authReq
+}
+
+// sessions tracks the open sessions so they can be closed when the provider shuts down.
+var sessions = struct {
+	sync.Mutex
+	open map[*sessionTransport]*Client
+}{open: map[*sessionTransport]*Client{}}
+
+// registerSession records a client whose session must be closed on shutdown.
+func registerSession(client *Client) {
+	sessions.Lock()
+	defer sessions.Unlock()
+	sessions.open[client.session] = client
+}
+
+// unregisterSession forgets the session once it is closed.
+func unregisterSession(session *sessionTransport) {
+	sessions.Lock()
+	defer sessions.Unlock()
+	delete(sessions.open, session)
+}
+
+// CloseSessions closes the sessions of every client created by the provider and not closed yet.
+// It is called once the provider server stops.
+func CloseSessions(ctx context.Context) {
+	sessions.Lock()
+	open := make([]*Client, 0, len(sessions.open))
+	for _, client := range sessions.open {
+		open = append(open, client)
+	}
+	sessions.Unlock()
+	for _, client := range open {
+		client.Close(ctx)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newSessionServer returns a server issuing a session cookie on Basic authentication.
+// The session is invalidated when invalidate is set.
+func newSessionServer(t *testing.T, logins *int32, invalidate *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("JSESSIONID"); err == nil && atomic.LoadInt32(invalidate) == 0 {
+			assert.Equal(t, "session-1", cookie.Value)
+			assert.Empty(t, r.Header.Get("Authorization"))
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+			return
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "admin" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		atomic.AddInt32(logins, 1)
+		atomic.StoreInt32(invalidate, 0)
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session-1", Path: "/"})
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+}
+
+func newSessionClient(jar http.CookieJar, session *sessionTransport) *http.Client {
+	return &http.Client{Jar: jar, Transport: session}
+}
+
+func TestSessionTransportReusesSession(t *testing.T) {
+	var logins, invalidate int32
+	server := newSessionServer(t, &logins, &invalidate)
+	defer server.Close()
+
+	jar, _ := cookiejar.New(nil)
+	httpClient := newSessionClient(jar, newSessionTransport(http.DefaultTransport, http.DefaultTransport, jar, "admin", "secret", ""))
+	for i := 0; i < 3; i++ {
+		resp, err := httpClient.Get(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+}
+
+func TestSessionTransportReauthenticatesOnUnauthorized(t *testing.T) {
+	var logins, invalidate int32
+	server := newSessionServer(t, &logins, &invalidate)
+	defer server.Close()
+
+	jar, _ := cookiejar.New(nil)
+	httpClient := newSessionClient(jar, newSessionTransport(http.DefaultTransport, http.DefaultTransport, jar, "admin", "secret", ""))
+	resp, err := httpClient.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	atomic.StoreInt32(&invalidate, 1)
+	resp, err = httpClient.Post(server.URL, "application/json", strings.NewReader(`{"name":"sg"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, `{"name":"sg"}`, string(body))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+}
+
+func TestSessionTransportClose(t *testing.T) {
+	var logins, invalidate int32
+	server := newSessionServer(t, &logins, &invalidate)
+	defer server.Close()
+
+	jar, _ := cookiejar.New(nil)
+	session := newSessionTransport(http.DefaultTransport, http.DefaultTransport, jar, "admin", "secret", "")
+	session.close(context.Background())
+	_, err := newSessionClient(jar, session).Get(server.URL)
+	assert.ErrorIs(t, err, errSessionClosed)
+	assert.Empty(t, session.password)
+}
+
+func TestClientCloseLogsOut(t *testing.T) {
+	var logouts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == LogoutPath {
+			cookie, err := r.Cookie("JSESSIONID")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "session-1", cookie.Value)
+			}
+			assert.Equal(t, http.MethodPost, r.Method)
+			atomic.AddInt32(&logouts, 1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session-1", Path: "/"})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"symmetrixId":"000000000001"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", false, ClientOptions{})
+	assert.NoError(t, err)
+	_, _, err = client.PmaxOpenapiClient.SystemApi.GetSymm(context.Background(), "000000000001").Execute()
+	assert.NoError(t, err)
+	sessions.Lock()
+	assert.Contains(t, sessions.open, client.session)
+	sessions.Unlock()
+
+	client.Close(context.Background())
+	client.Close(context.Background())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logouts))
+	sessions.Lock()
+	assert.NotContains(t, sessions.open, client.session)
+	sessions.Unlock()
+	_, _, err = client.PmaxOpenapiClient.SystemApi.GetSymm(context.Background(), "000000000001").Execute()
+	assert.ErrorIs(t, err, errSessionClosed)
+}
+
+func TestClientCloseLogsOutOnce(t *testing.T) {
+	var logouts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == LogoutPath {
+			atomic.AddInt32(&logouts, 1)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session-1", Path: "/"})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"symmetrixId":"000000000001"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", false, ClientOptions{
+		Retry: RetryConfig{MaxRetries: 3, MinWait: time.Millisecond},
+	})
+	assert.NoError(t, err)
+	_, _, err = client.PmaxOpenapiClient.SystemApi.GetSymm(context.Background(), "000000000001").Execute()
+	assert.NoError(t, err)
+
+	// The logout is not retried, Terraform waits for the provider to exit
+	client.Close(context.Background())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logouts))
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the request timeout used when the provider does not configure one.
+const DefaultTimeout = 60 * time.Second
+
+// timeoutTransport is a http.RoundTripper bounding every request with a deadline on its context.
+// Unlike http.Client.Timeout, a shorter deadline already set on the request context (for example
+// by a resource timeouts block) is kept.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+// newTimeoutTransport wraps the given transport with the request timeout, using DefaultTimeout when unset.
+func newTimeoutTransport(next http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &timeoutTransport{
+		next:    next,
+		timeout: timeout,
+	}
+}
+
+// RoundTrip executes the request with the timeout applied to its context.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return resp, err
+	}
+	// The context must stay alive until the body has been read.
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the request context once the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the request context.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
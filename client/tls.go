@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errFingerprintMismatch is returned when the Unisphere certificate does not match the pinned fingerprint.
+var errFingerprintMismatch = errors.New("certificate fingerprint mismatch")
+
+// TLSOptions holds the certificate settings used to verify Unisphere and authenticate the client.
+// Certificates and keys are either PEM encoded content or a path to a PEM file.
+type TLSOptions struct {
+	// CACertificate is a CA bundle trusted in addition to the system cert pool.
+	CACertificate string
+	// ClientCertificate and ClientKey enable mutual TLS when both are set.
+	ClientCertificate string
+	ClientKey         string
+	// Fingerprint is the SHA-256 fingerprint of the Unisphere certificate. When set, the
+	// certificate is pinned and only a matching certificate is accepted.
+	Fingerprint string
+}
+
+// newTLSConfig builds the TLS configuration of the client transport.
+func newTLSConfig(insecure bool, opts TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if opts.ClientCertificate != "" || opts.ClientKey != "" {
+		if opts.ClientCertificate == "" || opts.ClientKey == "" {
+			return nil, errors.New("both client_certificate and client_key must be set to use mutual TLS")
+		}
+		certPEM, err := loadPEM(opts.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		keyPEM, err := loadPEM(opts.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse client certificate and key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.Fingerprint != "" {
+		fingerprint, err := parseFingerprint(opts.Fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		// The chain is not verified against a CA, the pinned fingerprint is checked instead.
+		/* #nosec */
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("no certificate presented by the PowerMax host")
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			if subtle.ConstantTimeCompare(sum[:], fingerprint) != 1 {
+				return fmt.Errorf("%w: certificate fingerprint %s of the PowerMax host does not match the pinned fingerprint", errFingerprintMismatch, formatFingerprint(sum[:]))
+			}
+			return nil
+		}
+		return tlsConfig, nil
+	}
+
+	if insecure {
+		/* #nosec */
+		tlsConfig.InsecureSkipVerify = true
+		return tlsConfig, nil
+	}
+
+	// Loading system certs by default if insecure is set to false
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, errors.New("unable to initialize cert pool from system")
+	}
+	if opts.CACertificate != "" {
+		caPEM, err := loadPEM(opts.CACertificate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load CA certificate: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("no valid PEM certificate found in the CA certificate")
+		}
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// loadPEM returns the PEM content of value, reading it from a file when value is not PEM encoded content.
+func loadPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	/* #nosec */
+	return os.ReadFile(value)
+}
+
+// parseFingerprint decodes a SHA-256 fingerprint written in hex, with or without colons.
+func parseFingerprint(value string) ([]byte, error) {
+	fingerprint, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(value), ":", ""))
+	if err != nil || len(fingerprint) != sha256.Size {
+		return nil, errors.New("certificate_fingerprint must be a SHA-256 fingerprint in hex, for example AB:CD:...")
+	}
+	return fingerprint, nil
+}
+
+// formatFingerprint writes a fingerprint as colon separated upper case hex.
+func formatFingerprint(fingerprint []byte) string {
+	parts := make([]string, len(fingerprint))
+	for i, b := range fingerprint {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func serverCertificatePEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func getWithTLS(url string, insecure bool, opts TLSOptions) error {
+	tlsConfig, err := newTLSConfig(insecure, opts)
+	if err != nil {
+		return err
+	}
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestTLSConfigRejectsUnknownCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	assert.Error(t, getWithTLS(server.URL, false, TLSOptions{}))
+}
+
+func TestTLSConfigTrustsInlineCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	assert.NoError(t, getWithTLS(server.URL, false, TLSOptions{CACertificate: serverCertificatePEM(server)}))
+}
+
+func TestTLSConfigTrustsCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte(serverCertificatePEM(server)), 0o600))
+	assert.NoError(t, getWithTLS(server.URL, false, TLSOptions{CACertificate: caFile}))
+}
+
+func TestTLSConfigPinnedFingerprint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	assert.NoError(t, getWithTLS(server.URL, false, TLSOptions{Fingerprint: formatFingerprint(sum[:])}))
+
+	sum[0]++
+	assert.ErrorContains(t, getWithTLS(server.URL, false, TLSOptions{Fingerprint: formatFingerprint(sum[:])}), "does not match the pinned fingerprint")
+
+	_, err := newTLSConfig(false, TLSOptions{Fingerprint: "not-a-fingerprint"})
+	assert.Error(t, err)
+}
+
+func TestTLSConfigMutualTLS(t *testing.T) {
+	certPEM, keyPEM := newClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	assert.True(t, clientCAs.AppendCertsFromPEM(certPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	ca := serverCertificatePEM(server)
+	assert.Error(t, getWithTLS(server.URL, false, TLSOptions{CACertificate: ca}))
+	assert.NoError(t, getWithTLS(server.URL, false, TLSOptions{
+		CACertificate:     ca,
+		ClientCertificate: string(certPEM),
+		ClientKey:         string(keyPEM),
+	}))
+
+	_, err := newTLSConfig(false, TLSOptions{ClientCertificate: string(certPEM)})
+	assert.Error(t, err)
+}
+
+func newClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
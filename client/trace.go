@@ -0,0 +1,195 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// TraceSubsystem is the tflog subsystem of the request and response traces. Its level can be
+// set with the environment variable TF_LOG_PROVIDER_POWERMAX_HTTP.
+const TraceSubsystem = "http"
+
+// maxTracedBody is the number of bytes of a body logged at most.
+const maxTracedBody = 64 * 1024
+
+// redactedHeaders are the headers whose value is never logged.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// sensitiveKeys are the parts of the JSON keys whose value is never logged, which covers
+// passwords and CHAP secrets.
+var sensitiveKeys = []string{"password", "secret", "passphrase", "chap"}
+
+// traceTransport is a http.RoundTripper logging the method, URL, status, latency and bodies of
+// every exchange with Unisphere, with the credentials and secrets redacted.
+type traceTransport struct {
+	next     http.RoundTripper
+	debug    bool
+	password string
+}
+
+// newTraceTransport wraps the given transport with the traces. The traces are logged at the DEBUG
+// level when debug is set, otherwise at the TRACE level, and only when TF_LOG enables TRACE: reading
+// the bodies is not free. next is returned when tracing is disabled.
+func newTraceTransport(next http.RoundTripper, debug bool, password string) http.RoundTripper {
+	if !debug && !traceFromEnv() {
+		return next
+	}
+	return &traceTransport{
+		next:     next,
+		debug:    debug,
+		password: password,
+	}
+}
+
+// traceFromEnv reports whether the Terraform logs are enabled at the TRACE level for the provider.
+func traceFromEnv() bool {
+	for _, name := range []string{"TF_LOG", "TF_LOG_PROVIDER", "TF_LOG_PROVIDER_POWERMAX", "TF_LOG_PROVIDER_POWERMAX_HTTP"} {
+		// TF_LOG=JSON logs at the TRACE level in the JSON format
+		if level := strings.ToUpper(os.Getenv(name)); level == "TRACE" || level == "JSON" {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundTrip sends the request and logs the exchange.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), TraceSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_POWERMAX", TraceSubsystem))
+	if t.password != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, TraceSubsystem, t.password)
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{
+		"method":          req.Method,
+		"url":             req.URL.String(),
+		"request_headers": redactHeaders(req.Header),
+	}
+	if len(body) > 0 {
+		fields["request_body"] = redactBody(body)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		t.log(ctx, "PowerMax request failed", fields)
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	fields["status"] = resp.StatusCode
+	fields["response_headers"] = redactHeaders(resp.Header)
+	if len(respBody) > 0 {
+		fields["response_body"] = redactBody(respBody)
+	}
+	t.log(ctx, "PowerMax request", fields)
+	return resp, nil
+}
+
+// log writes the trace at the configured level.
+func (t *traceTransport) log(ctx context.Context, msg string, fields map[string]interface{}) {
+	if t.debug {
+		tflog.SubsystemDebug(ctx, TraceSubsystem, msg, fields)
+		return
+	}
+	tflog.SubsystemTrace(ctx, TraceSubsystem, msg, fields)
+}
+
+// redactHeaders returns the headers with the credentials and session cookies redacted.
+func redactHeaders(header http.Header) map[string]string {
+	values := make(map[string]string, len(header))
+	for name, value := range header {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			values[name] = redacted
+			continue
+		}
+		values[name] = strings.Join(value, ", ")
+	}
+	return values
+}
+
+// redactBody returns the body with the values of the sensitive JSON keys redacted, truncated to maxTracedBody.
+func redactBody(body []byte) string {
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Keep the numbers as sent, float64 would round large integers
+	decoder.UseNumber()
+	if decoder.Decode(&decoded) == nil {
+		if encoded, err := json.Marshal(redactValue(decoded)); err == nil {
+			body = encoded
+		}
+	}
+	if len(body) > maxTracedBody {
+		return string(body[:maxTracedBody]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactValue redacts the sensitive keys of the decoded JSON value, recursively.
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
+
+// isSensitiveKey reports whether the value of the JSON key is a secret.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceTransportLogsRedactedExchanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), "s3cret", "the request sent is not redacted")
+		http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session"})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hostId": "host1", "chap_secret": "chap-s3cret", "capacity": 12345678901234567}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	transport := newTraceTransport(http.DefaultTransport, true, "s3cret")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL+"/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/host/host1",
+		strings.NewReader(`{"editHostActionParam": {"setPassword": {"password": "s3cret"}}, "note": "password is s3cret"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Basic "+basicAuth("admin", "s3cret"))
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "chap-s3cret", "the response returned is not redacted")
+
+	assert.NotContains(t, output.String(), "s3cret")
+	assert.NotContains(t, output.String(), basicAuth("admin", "s3cret"))
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "debug", entry["@level"])
+	assert.Equal(t, "provider."+TraceSubsystem, entry["@module"])
+	assert.Equal(t, http.MethodPut, entry["method"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.Contains(t, entry, "latency_ms")
+	assert.Equal(t, redacted, entry["request_headers"].(map[string]interface{})["Authorization"])
+	assert.Equal(t, redacted, entry["response_headers"].(map[string]interface{})["Set-Cookie"])
+	assert.Equal(t, `{"editHostActionParam":{"setPassword":"REDACTED"},"note":"password is ***"}`, entry["request_body"])
+	assert.Equal(t, `{"capacity":12345678901234567,"chap_secret":"REDACTED","hostId":"host1"}`, entry["response_body"])
+}
+
+func TestTraceTransportEnabled(t *testing.T) {
+	for _, name := range []string{"TF_LOG", "TF_LOG_PROVIDER", "TF_LOG_PROVIDER_POWERMAX", "TF_LOG_PROVIDER_POWERMAX_HTTP"} {
+		t.Setenv(name, "")
+	}
+	assert.Same(t, http.DefaultTransport, newTraceTransport(http.DefaultTransport, false, ""))
+	assert.IsType(t, &traceTransport{}, newTraceTransport(http.DefaultTransport, true, ""))
+
+	t.Setenv("TF_LOG", "debug")
+	assert.Same(t, http.DefaultTransport, newTraceTransport(http.DefaultTransport, false, ""))
+	t.Setenv("TF_LOG", "trace")
+	assert.IsType(t, &traceTransport{}, newTraceTransport(http.DefaultTransport, false, ""))
+	t.Setenv("TF_LOG", "")
+	t.Setenv("TF_LOG_PROVIDER_POWERMAX_HTTP", "TRACE")
+	assert.IsType(t, &traceTransport{}, newTraceTransport(http.DefaultTransport, false, ""))
+}
+
+func TestRedactBodyTruncates(t *testing.T) {
+	assert.Equal(t, "not json", redactBody([]byte("not json")))
+	long := redactBody([]byte(strings.Repeat("a", maxTracedBody+10)))
+	assert.True(t, strings.HasSuffix(long, "...(truncated)"))
+	assert.Len(t, long, maxTracedBody+len("...(truncated)"))
+}
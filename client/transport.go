@@ -0,0 +1,105 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Default connection settings used when the provider does not configure them.
+const (
+	DefaultMaxIdleConns = 10
+	DefaultKeepAlive    = 30 * time.Second
+)
+
+// TransportOptions holds the proxy and connection pool settings of the client.
+type TransportOptions struct {
+	// ProxyURL is the proxy used for all requests. The HTTP_PROXY, HTTPS_PROXY and
+	// NO_PROXY environment variables are honored when unset.
+	ProxyURL string
+	// NoProxy is a comma separated list of hosts which are reached without the proxy.
+	// It overrides the NO_PROXY environment variable.
+	NoProxy string
+	// MaxIdleConns is the number of idle connections kept open to Unisphere.
+	MaxIdleConns int
+	// KeepAlive is the TCP keep-alive period of the connections.
+	KeepAlive time.Duration
+}
+
+// newTransport builds the base transport of the client from the TLS configuration and the transport settings.
+func newTransport(tlsConfig *tls.Config, opts TransportOptions) (*http.Transport, error) {
+	proxy, err := proxyFunc(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	maxIdleConns := opts.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	keepAlive := opts.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = DefaultKeepAlive
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: keepAlive,
+	}
+
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConns,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}, nil
+}
+
+// proxyFunc returns the proxy selection of the transport, defaulting to the environment proxy.
+func proxyFunc(opts TransportOptions) (func(*http.Request) (*url.URL, error), error) {
+	if opts.ProxyURL == "" && opts.NoProxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	cfg := httpproxy.FromEnvironment()
+	if opts.ProxyURL != "" {
+		if _, err := url.Parse(opts.ProxyURL); err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		cfg.HTTPProxy = opts.ProxyURL
+		cfg.HTTPSProxy = opts.ProxyURL
+	}
+	if opts.NoProxy != "" {
+		cfg.NoProxy = opts.NoProxy
+	}
+	proxy := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}, nil
+}
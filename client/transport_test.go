@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyFuncUsesConfiguredProxy(t *testing.T) {
+	proxy, err := proxyFunc(TransportOptions{
+		ProxyURL: "http://proxy.example.com:3128",
+		NoProxy:  "unisphere.internal",
+	})
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://unisphere.example.com:8443/univmax/restapi", nil)
+	proxyURL, err := proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, &url.URL{Scheme: "http", Host: "proxy.example.com:3128"}, proxyURL)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://unisphere.internal:8443/univmax/restapi", nil)
+	proxyURL, err = proxy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
+
+func TestNewTransportDefaults(t *testing.T) {
+	transport, err := newTransport(nil, TransportOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, transport.Proxy)
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConnsPerHost)
+}
@@ -0,0 +1,636 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unispheretest
+
+import (
+	"dell/powermax-go-client"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const sloPrefix = apiPrefix + "/sloprovisioning/symmetrix/{symid}"
+
+// host is a host of the array.
+type host struct {
+	initiators []string
+	flags      *powermax.HostFlags
+}
+
+// hostGroup is a host group of the array.
+type hostGroup struct {
+	hosts []string
+	flags *powermax.HostFlags
+}
+
+// portGroup is a port group of the array.
+type portGroup struct {
+	ports    []powermax.SymmetrixPortKey
+	protocol string
+}
+
+// maskingView is a masking view of the array, it references either a host or a host group.
+type maskingView struct {
+	host         string
+	hostGroup    string
+	portGroup    string
+	storageGroup string
+}
+
+// routeProvisioning registers the host, host group, port group and masking view endpoints.
+func (s *Server) routeProvisioning(mux *http.ServeMux) {
+	s.handle(mux, "GET "+sloPrefix+"/host", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.ListHostResult{HostId: sortedKeys(s.hosts)})
+	})
+	s.handle(mux, "POST "+sloPrefix+"/host", s.createHost)
+	s.handle(mux, "GET "+sloPrefix+"/host/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findHost(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.hostResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "PUT "+sloPrefix+"/host/{id}", s.editHost)
+	s.handle(mux, "DELETE "+sloPrefix+"/host/{id}", s.deleteHost)
+
+	s.handle(mux, "GET "+sloPrefix+"/hostgroup", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.ListHostGroupResult{HostGroupId: sortedKeys(s.hostGroups)})
+	})
+	s.handle(mux, "POST "+sloPrefix+"/hostgroup", s.createHostGroup)
+	s.handle(mux, "GET "+sloPrefix+"/hostgroup/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findHostGroup(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.hostGroupResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "PUT "+sloPrefix+"/hostgroup/{id}", s.editHostGroup)
+	s.handle(mux, "DELETE "+sloPrefix+"/hostgroup/{id}", s.deleteHostGroup)
+
+	s.handle(mux, "GET "+sloPrefix+"/portgroup", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.ListPortGroupResult{PortGroupId: sortedKeys(s.portGroups)})
+	})
+	s.handle(mux, "POST "+sloPrefix+"/portgroup", s.createPortGroup)
+	s.handle(mux, "GET "+sloPrefix+"/portgroup/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findPortGroup(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.portGroupResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "PUT "+sloPrefix+"/portgroup/{id}", s.editPortGroup)
+	s.handle(mux, "DELETE "+sloPrefix+"/portgroup/{id}", s.deletePortGroup)
+
+	s.handle(mux, "GET "+sloPrefix+"/maskingview", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.ListMaskingViewResult{MaskingViewId: sortedKeys(s.maskingViews)})
+	})
+	s.handle(mux, "POST "+sloPrefix+"/maskingview", s.createMaskingView)
+	s.handle(mux, "GET "+sloPrefix+"/maskingview/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findMaskingView(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.maskingViewResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "GET "+sloPrefix+"/maskingview/{id}/connections", func(w http.ResponseWriter, r *http.Request) {
+		if s.findMaskingView(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, powermax.GetMaskingViewConnectionsResult{MaskingViewConnection: []powermax.MaskingViewConnection{}})
+		}
+	})
+	s.handle(mux, "PUT "+sloPrefix+"/maskingview/{id}", s.editMaskingView)
+	s.handle(mux, "DELETE "+sloPrefix+"/maskingview/{id}", s.deleteMaskingView)
+}
+
+func (s *Server) findHost(w http.ResponseWriter, id string) *host {
+	h, ok := s.hosts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Host %s", id)
+	}
+	return h
+}
+
+func (s *Server) findHostGroup(w http.ResponseWriter, id string) *hostGroup {
+	hg, ok := s.hostGroups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Host Group %s", id)
+	}
+	return hg
+}
+
+func (s *Server) findPortGroup(w http.ResponseWriter, id string) *portGroup {
+	pg, ok := s.portGroups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Port Group %s", id)
+	}
+	return pg
+}
+
+func (s *Server) findMaskingView(w http.ResponseWriter, id string) *maskingView {
+	mv, ok := s.maskingViews[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Masking View %s", id)
+	}
+	return mv
+}
+
+// nameTaken answers 409 when taken reports that an object of the given kind is already named id.
+func nameTaken(w http.ResponseWriter, kind, id string, taken bool) bool {
+	if taken {
+		writeError(w, http.StatusConflict, "A %s with the name %s already exists", kind, id)
+	}
+	return taken
+}
+
+func (s *Server) createHost(w http.ResponseWriter, r *http.Request) {
+	var param powermax.CreateHostParam
+	if !decode(w, r, &param) {
+		return
+	}
+	if nameTaken(w, "Host", param.HostId, s.initiatorGroupExists(param.HostId)) || !s.initiatorsAvailable(w, "", param.InitiatorId) {
+		return
+	}
+	s.hosts[param.HostId] = &host{initiators: param.InitiatorId, flags: param.HostFlags}
+	s.respond(w, param.ExecutionOption, "Create Host "+param.HostId, s.hostResponse(param.HostId))
+}
+
+// initiatorGroupExists reports whether a host or host group is named id, they share a namespace.
+func (s *Server) initiatorGroupExists(id string) bool {
+	_, isHost := s.hosts[id]
+	_, isHostGroup := s.hostGroups[id]
+	return isHost || isHostGroup
+}
+
+// initiatorsAvailable answers 409 when one of the initiators already belongs to another host than hostID.
+func (s *Server) initiatorsAvailable(w http.ResponseWriter, hostID string, initiators []string) bool {
+	for id, h := range s.hosts {
+		for _, initiator := range initiators {
+			if id != hostID && contains(h.initiators, initiator) {
+				writeError(w, http.StatusConflict, "Initiator %s is already in use by Host %s", initiator, id)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) editHost(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	h := s.findHost(w, id)
+	var param powermax.EditHostParam
+	if h == nil || !decode(w, r, &param) {
+		return
+	}
+	action := param.EditHostActionParam
+	switch {
+	case action.AddInitiatorParam != nil:
+		if !s.initiatorsAvailable(w, id, action.AddInitiatorParam.Initiator) {
+			return
+		}
+		h.initiators = append(remove(h.initiators, action.AddInitiatorParam.Initiator...), action.AddInitiatorParam.Initiator...)
+	case action.RemoveInitiatorParam != nil:
+		h.initiators = remove(h.initiators, action.RemoveInitiatorParam.Initiator...)
+	case action.SetHostFlagsParam != nil:
+		flags := action.SetHostFlagsParam.HostFlags
+		h.flags = &flags
+	case action.RenameHostParam != nil && action.RenameHostParam.NewHostName != nil:
+		newID := *action.RenameHostParam.NewHostName
+		if nameTaken(w, "Host", newID, s.initiatorGroupExists(newID)) {
+			return
+		}
+		delete(s.hosts, id)
+		s.hosts[newID] = h
+		for _, hg := range s.hostGroups {
+			if contains(hg.hosts, id) {
+				hg.hosts = append(remove(hg.hosts, id), newID)
+			}
+		}
+		for _, mv := range s.maskingViews {
+			if mv.host == id {
+				mv.host = newID
+			}
+		}
+		id = newID
+	default:
+		writeError(w, http.StatusBadRequest, "No edit host action provided")
+		return
+	}
+	s.respond(w, param.ExecutionOption, "Modify Host "+id, s.hostResponse(id))
+}
+
+func (s *Server) deleteHost(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.findHost(w, id) == nil {
+		return
+	}
+	if views := s.maskingViewsOfHost(id); len(views) > 0 {
+		writeError(w, http.StatusConflict, "Host %s is part of Masking View %s", id, views[0])
+		return
+	}
+	if groups := s.hostGroupsOfHost(id); len(groups) > 0 {
+		writeError(w, http.StatusConflict, "Host %s is part of Host Group %s", id, groups[0])
+		return
+	}
+	delete(s.hosts, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) hostResponse(id string) *powermax.Host {
+	h := s.hosts[id]
+	groups := s.hostGroupsOfHost(id)
+	views := s.maskingViewsOfHost(id)
+	resp := powermax.NewHost(id)
+	resp.SetNumOfInitiators(int32(len(h.initiators)))
+	resp.SetNumOfHostGroups(int32(len(groups)))
+	resp.SetNumOfMaskingViews(int64(len(views)))
+	resp.SetType("Fibre")
+	resp.Initiator = h.initiators
+	resp.Hostgroup = groups
+	resp.Maskingview = views
+	enabled, disabled, consistentLun := flagNames(h.flags)
+	resp.SetPortFlagsOverride(enabled != "" || disabled != "")
+	resp.SetConsistentLun(consistentLun)
+	resp.SetEnabledFlags(enabled)
+	resp.SetDisabledFlags(disabled)
+	return resp
+}
+
+// hostGroupsOfHost returns the host groups containing the host.
+func (s *Server) hostGroupsOfHost(id string) []string {
+	var groups []string
+	for _, groupID := range sortedKeys(s.hostGroups) {
+		if contains(s.hostGroups[groupID].hosts, id) {
+			groups = append(groups, groupID)
+		}
+	}
+	return groups
+}
+
+// maskingViewsOfHost returns the masking views of the host, directly or through its host groups.
+func (s *Server) maskingViewsOfHost(id string) []string {
+	groups := s.hostGroupsOfHost(id)
+	return s.maskingViewsWhere(func(mv *maskingView) bool {
+		return mv.host == id || contains(groups, mv.hostGroup)
+	})
+}
+
+// flagNames formats the overridden host flags like the enabled_flags and disabled_flags of
+// Unisphere, it also returns whether consistent LUNs are enabled.
+func flagNames(flags *powermax.HostFlags) (string, string, bool) {
+	if flags == nil {
+		return "", "", false
+	}
+	var enabled, disabled []string
+	for _, flag := range []struct {
+		name              string
+		enabled, override bool
+	}{
+		{"Volume_Set_Addressing(V)", flags.VolumeSetAddressing.Enabled, flags.VolumeSetAddressing.Override},
+		{"Disable_Q_Reset_on_UA(D)", flags.DisableQResetOnUa.Enabled, flags.DisableQResetOnUa.Override},
+		{"Environ_Set(E)", flags.EnvironSet.Enabled, flags.EnvironSet.Override},
+		{"Avoid_Reset_Broadcast(ARB)", flags.AvoidResetBroadcast.Enabled, flags.AvoidResetBroadcast.Override},
+		{"OpenVMS(OVMS)", flags.Openvms.Enabled, flags.Openvms.Override},
+		{"SCSI_3(SC3)", flags.Scsi3.Enabled, flags.Scsi3.Override},
+		{"SPC2_Protocol_Version(SPC2)", flags.Spc2ProtocolVersion.Enabled, flags.Spc2ProtocolVersion.Override},
+		{"SCSI_Support1(OS2007)", flags.ScsiSupport1.Enabled, flags.ScsiSupport1.Override},
+	} {
+		switch {
+		case flag.override && flag.enabled:
+			enabled = append(enabled, flag.name)
+		case flag.override:
+			disabled = append(disabled, flag.name)
+		}
+	}
+	return strings.Join(enabled, ","), strings.Join(disabled, ","), flags.ConsistentLun
+}
+
+func (s *Server) createHostGroup(w http.ResponseWriter, r *http.Request) {
+	var param powermax.CreateHostGroupParam
+	if !decode(w, r, &param) {
+		return
+	}
+	if nameTaken(w, "Host Group", param.HostGroupId, s.initiatorGroupExists(param.HostGroupId)) || !s.hostsExist(w, param.HostId) {
+		return
+	}
+	s.hostGroups[param.HostGroupId] = &hostGroup{hosts: param.HostId, flags: param.HostFlags}
+	s.respond(w, param.ExecutionOption, "Create Host Group "+param.HostGroupId, s.hostGroupResponse(param.HostGroupId))
+}
+
+// hostsExist answers 404 when one of the hosts does not exist.
+func (s *Server) hostsExist(w http.ResponseWriter, hosts []string) bool {
+	for _, id := range hosts {
+		if s.findHost(w, id) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) editHostGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	hg := s.findHostGroup(w, id)
+	var param powermax.EditHostGroupParam
+	if hg == nil || !decode(w, r, &param) {
+		return
+	}
+	action := param.EditHostGroupActionParam
+	switch {
+	case action.AddHostParam != nil:
+		if !s.hostsExist(w, action.AddHostParam.Host) {
+			return
+		}
+		hg.hosts = append(remove(hg.hosts, action.AddHostParam.Host...), action.AddHostParam.Host...)
+	case action.RemoveHostParam != nil:
+		hg.hosts = remove(hg.hosts, action.RemoveHostParam.Host...)
+	case action.SetHostGroupFlagsParam != nil:
+		flags := action.SetHostGroupFlagsParam.HostFlags
+		hg.flags = &flags
+	case action.RenameHostGroupParam != nil && action.RenameHostGroupParam.NewHostGroupName != nil:
+		newID := *action.RenameHostGroupParam.NewHostGroupName
+		if nameTaken(w, "Host Group", newID, s.initiatorGroupExists(newID)) {
+			return
+		}
+		delete(s.hostGroups, id)
+		s.hostGroups[newID] = hg
+		for _, mv := range s.maskingViews {
+			if mv.hostGroup == id {
+				mv.hostGroup = newID
+			}
+		}
+		id = newID
+	default:
+		writeError(w, http.StatusBadRequest, "No edit host group action provided")
+		return
+	}
+	s.respond(w, param.ExecutionOption, "Modify Host Group "+id, s.hostGroupResponse(id))
+}
+
+func (s *Server) deleteHostGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.findHostGroup(w, id) == nil {
+		return
+	}
+	if views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.hostGroup == id }); len(views) > 0 {
+		writeError(w, http.StatusConflict, "Host Group %s is part of Masking View %s", id, views[0])
+		return
+	}
+	delete(s.hostGroups, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) hostGroupResponse(id string) *powermax.HostGroup {
+	hg := s.hostGroups[id]
+	resp := powermax.NewHostGroup(id)
+	initiators := 0
+	for _, hostID := range hg.hosts {
+		h := s.hosts[hostID]
+		initiators += len(h.initiators)
+		resp.Host = append(resp.Host, powermax.HostSummary{HostId: hostID, Initiator: h.initiators})
+	}
+	views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.hostGroup == id })
+	resp.SetNumOfHosts(int32(len(hg.hosts)))
+	resp.SetNumOfInitiators(int32(initiators))
+	resp.SetNumOfMaskingViews(int64(len(views)))
+	resp.SetType("Fibre")
+	resp.Maskingview = views
+	enabled, disabled, consistentLun := flagNames(hg.flags)
+	resp.SetPortFlagsOverride(enabled != "" || disabled != "")
+	resp.SetConsistentLun(consistentLun)
+	resp.SetEnabledFlags(enabled)
+	resp.SetDisabledFlags(disabled)
+	return resp
+}
+
+func (s *Server) createPortGroup(w http.ResponseWriter, r *http.Request) {
+	var param powermax.CreatePortGroupParam
+	if !decode(w, r, &param) {
+		return
+	}
+	_, exists := s.portGroups[param.PortGroupId]
+	if nameTaken(w, "Port Group", param.PortGroupId, exists) || !s.portsExist(w, param.SymmetrixPortKey) {
+		return
+	}
+	pg := &portGroup{ports: param.SymmetrixPortKey, protocol: "SCSI_FC"}
+	if param.PortGroupProtocol != nil {
+		pg.protocol = *param.PortGroupProtocol
+	}
+	s.portGroups[param.PortGroupId] = pg
+	s.respond(w, param.ExecutionOption, "Create Port Group "+param.PortGroupId, s.portGroupResponse(param.PortGroupId))
+}
+
+// portsExist answers 404 when one of the ports does not exist.
+func (s *Server) portsExist(w http.ResponseWriter, ports []powermax.SymmetrixPortKey) bool {
+	for _, port := range ports {
+		if !s.portExists(port) {
+			writeError(w, http.StatusNotFound, "Cannot find port %s:%s", port.DirectorId, port.PortId)
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) editPortGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	pg := s.findPortGroup(w, id)
+	var param powermax.EditPortGroupParam
+	if pg == nil || !decode(w, r, &param) {
+		return
+	}
+	action := param.EditPortGroupActionParam
+	switch {
+	case action.AddPortParam != nil:
+		if !s.portsExist(w, action.AddPortParam.Port) {
+			return
+		}
+		pg.ports = append(removePorts(pg.ports, action.AddPortParam.Port), action.AddPortParam.Port...)
+	case action.RemovePortParam != nil:
+		pg.ports = removePorts(pg.ports, action.RemovePortParam.Port)
+	case action.RenamePortGroupParam != nil:
+		newID := action.RenamePortGroupParam.NewPortGroupName
+		_, exists := s.portGroups[newID]
+		if nameTaken(w, "Port Group", newID, exists) {
+			return
+		}
+		delete(s.portGroups, id)
+		s.portGroups[newID] = pg
+		for _, mv := range s.maskingViews {
+			if mv.portGroup == id {
+				mv.portGroup = newID
+			}
+		}
+		id = newID
+	default:
+		writeError(w, http.StatusBadRequest, "No edit port group action provided")
+		return
+	}
+	s.respond(w, param.ExecutionOption, "Modify Port Group "+id, s.portGroupResponse(id))
+}
+
+// removePorts returns ports without the removed ones.
+func removePorts(ports, removed []powermax.SymmetrixPortKey) []powermax.SymmetrixPortKey {
+	kept := make([]powermax.SymmetrixPortKey, 0, len(ports))
+	for _, port := range ports {
+		found := false
+		for _, r := range removed {
+			found = found || port == r
+		}
+		if !found {
+			kept = append(kept, port)
+		}
+	}
+	return kept
+}
+
+func (s *Server) deletePortGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.findPortGroup(w, id) == nil {
+		return
+	}
+	if views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.portGroup == id }); len(views) > 0 {
+		writeError(w, http.StatusConflict, "Port Group %s is part of Masking View %s", id, views[0])
+		return
+	}
+	delete(s.portGroups, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) portGroupResponse(id string) *powermax.PortGroup {
+	pg := s.portGroups[id]
+	views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.portGroup == id })
+	resp := powermax.NewPortGroup(id)
+	resp.SymmetrixPortKey = pg.ports
+	resp.SetNumOfPorts(int32(len(pg.ports)))
+	resp.SetNumOfMaskingViews(int64(len(views)))
+	resp.SetType("Fibre")
+	resp.SetPortGroupProtocol(pg.protocol)
+	resp.Maskingview = views
+	return resp
+}
+
+// portGroupsOfPort returns the port groups containing the port.
+func (s *Server) portGroupsOfPort(port powermax.SymmetrixPortKey) []string {
+	var groups []string
+	for _, id := range sortedKeys(s.portGroups) {
+		if len(removePorts(s.portGroups[id].ports, []powermax.SymmetrixPortKey{port})) != len(s.portGroups[id].ports) {
+			groups = append(groups, id)
+		}
+	}
+	return groups
+}
+
+// maskingViewsOfPort returns the masking views exposing the port.
+func (s *Server) maskingViewsOfPort(port powermax.SymmetrixPortKey) []string {
+	groups := s.portGroupsOfPort(port)
+	return s.maskingViewsWhere(func(mv *maskingView) bool { return contains(groups, mv.portGroup) })
+}
+
+func (s *Server) createMaskingView(w http.ResponseWriter, r *http.Request) {
+	var param powermax.CreateMaskingViewParam
+	if !decode(w, r, &param) {
+		return
+	}
+	_, exists := s.maskingViews[param.MaskingViewId]
+	if nameTaken(w, "Masking View", param.MaskingViewId, exists) {
+		return
+	}
+	if param.HostOrHostGroupSelection == nil || param.PortGroupSelection == nil || param.PortGroupSelection.UseExistingPortGroupParam == nil ||
+		param.StorageGroupSelection == nil || param.StorageGroupSelection.UseExistingStorageGroupParam == nil {
+		writeError(w, http.StatusBadRequest, "A masking view requires an existing host or host group, port group and storage group")
+		return
+	}
+	mv := &maskingView{
+		portGroup:    param.PortGroupSelection.UseExistingPortGroupParam.PortGroupId,
+		storageGroup: param.StorageGroupSelection.UseExistingStorageGroupParam.StorageGroupId,
+	}
+	switch selection := param.HostOrHostGroupSelection; {
+	case selection.UseExistingHostParam != nil:
+		mv.host = selection.UseExistingHostParam.HostId
+		if s.findHost(w, mv.host) == nil {
+			return
+		}
+	case selection.UseExistingHostGroupParam != nil:
+		mv.hostGroup = selection.UseExistingHostGroupParam.HostGroupId
+		if s.findHostGroup(w, mv.hostGroup) == nil {
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "A masking view requires an existing host or host group")
+		return
+	}
+	if s.findPortGroup(w, mv.portGroup) == nil || s.findStorageGroup(w, mv.storageGroup) == nil {
+		return
+	}
+	s.maskingViews[param.MaskingViewId] = mv
+	s.respond(w, param.ExecutionOption, "Create Masking View "+param.MaskingViewId, s.maskingViewResponse(param.MaskingViewId))
+}
+
+func (s *Server) editMaskingView(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	mv := s.findMaskingView(w, id)
+	var param powermax.EditMaskingViewParam
+	if mv == nil || !decode(w, r, &param) {
+		return
+	}
+	rename := param.EditMaskingViewActionParam.RenameMaskingViewParam
+	if rename == nil {
+		writeError(w, http.StatusBadRequest, "No edit masking view action provided")
+		return
+	}
+	_, exists := s.maskingViews[rename.NewMaskingViewName]
+	if nameTaken(w, "Masking View", rename.NewMaskingViewName, exists) {
+		return
+	}
+	delete(s.maskingViews, id)
+	s.maskingViews[rename.NewMaskingViewName] = mv
+	s.respond(w, param.ExecutionOption, "Modify Masking View "+id, s.maskingViewResponse(rename.NewMaskingViewName))
+}
+
+func (s *Server) deleteMaskingView(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.findMaskingView(w, id) == nil {
+		return
+	}
+	delete(s.maskingViews, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) maskingViewResponse(id string) *powermax.MaskingView {
+	mv := s.maskingViews[id]
+	resp := powermax.NewMaskingView(id)
+	if mv.host != "" {
+		resp.SetHostId(mv.host)
+	}
+	if mv.hostGroup != "" {
+		resp.SetHostGroupId(mv.hostGroup)
+	}
+	resp.SetPortGroupId(mv.portGroup)
+	resp.SetStorageGroupId(mv.storageGroup)
+	return resp
+}
+
+// maskingViewsWhere returns the sorted names of the masking views matching the predicate.
+func (s *Server) maskingViewsWhere(match func(*maskingView) bool) []string {
+	var views []string
+	for _, id := range sortedKeys(s.maskingViews) {
+		if match(s.maskingViews[id]) {
+			views = append(views, id)
+		}
+	}
+	return views
+}
+
+// sortedKeys returns the sorted keys of m, so that lists are stable between requests.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
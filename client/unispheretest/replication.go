@@ -0,0 +1,496 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unispheretest
+
+import (
+	"dell/powermax-go-client"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	replicationPrefix = apiPrefix + "/replication/symmetrix/{symid}"
+	snapshotPrefix    = replicationPrefix + "/storagegroup/{sg}/snapshot"
+)
+
+// snapshot is a SnapVX snapshot of a storage group.
+type snapshot struct {
+	name        string
+	snapID      int64
+	created     time.Time
+	ttlExpiry   *time.Time
+	secureUntil *time.Time
+	restored    bool
+	linked      []string
+}
+
+// snapshotPolicy is a local snapshot policy of the array.
+type snapshotPolicy struct {
+	intervalMinutes         int64
+	offsetMinutes           int64
+	snapshotCount           int64
+	complianceCountWarning  int64
+	complianceCountCritical int64
+	secure                  bool
+	suspended               bool
+	storageGroups           []string
+}
+
+// routeReplication registers the snapshot and snapshot policy endpoints.
+func (s *Server) routeReplication(mux *http.ServeMux) {
+	s.handle(mux, "GET "+snapshotPrefix, func(w http.ResponseWriter, r *http.Request) {
+		if s.findStorageGroup(w, r.PathValue("sg")) != nil {
+			writeJSON(w, http.StatusOK, s.snapshotListResponse(r.PathValue("sg")))
+		}
+	})
+	s.handle(mux, "POST "+snapshotPrefix, s.createSnapshot)
+	s.handle(mux, "GET "+snapshotPrefix+"/{name}/snapid", func(w http.ResponseWriter, r *http.Request) {
+		if s.findStorageGroup(w, r.PathValue("sg")) == nil {
+			return
+		}
+		list := powermax.StorageGroupSnapshotSnapIDList{Snapids: []int64{}}
+		for _, snap := range s.snapshotsNamed(r.PathValue("sg"), r.PathValue("name")) {
+			list.Snapids = append(list.Snapids, snap.snapID)
+		}
+		if len(list.Snapids) == 0 {
+			writeError(w, http.StatusNotFound, "Cannot find Snapshot %s", r.PathValue("name"))
+			return
+		}
+		writeJSON(w, http.StatusOK, list)
+	})
+	s.handle(mux, "GET "+snapshotPrefix+"/{name}/snapid/{snapid}", func(w http.ResponseWriter, r *http.Request) {
+		if snap := s.findSnapshot(w, r); snap != nil {
+			writeJSON(w, http.StatusOK, s.snapshotResponse(r.PathValue("sg"), snap))
+		}
+	})
+	s.handle(mux, "PUT "+snapshotPrefix+"/{name}/snapid/{snapid}", s.editSnapshot)
+	s.handle(mux, "DELETE "+snapshotPrefix+"/{name}/snapid/{snapid}", s.deleteSnapshot)
+
+	s.handle(mux, "GET "+replicationPrefix+"/snapshot_policy", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.SnapshotPolicyList{Name: sortedKeys(s.snapshotPolicies)})
+	})
+	s.handle(mux, "POST "+replicationPrefix+"/snapshot_policy", s.createSnapshotPolicy)
+	s.handle(mux, "GET "+replicationPrefix+"/snapshot_policy/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findSnapshotPolicy(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.snapshotPolicyResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "GET "+replicationPrefix+"/snapshot_policy/{id}/storagegroup", func(w http.ResponseWriter, r *http.Request) {
+		if policy := s.findSnapshotPolicy(w, r.PathValue("id")); policy != nil {
+			writeJSON(w, http.StatusOK, powermax.StorageGroupList{Name: policy.storageGroups})
+		}
+	})
+	s.handle(mux, "PUT "+replicationPrefix+"/snapshot_policy/{id}", s.editSnapshotPolicy)
+	s.handle(mux, "DELETE "+replicationPrefix+"/snapshot_policy/{id}", s.deleteSnapshotPolicy)
+}
+
+// snapshotsNamed returns the generations of the named snapshot of the storage group, newest first.
+func (s *Server) snapshotsNamed(sgID, name string) []*snapshot {
+	var named []*snapshot
+	snapshots := s.snapshots[sgID]
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i].name == name {
+			named = append(named, snapshots[i])
+		}
+	}
+	return named
+}
+
+// findSnapshot returns the snapshot addressed by the sg, name and snapid path values, or answers 404.
+func (s *Server) findSnapshot(w http.ResponseWriter, r *http.Request) *snapshot {
+	if s.findStorageGroup(w, r.PathValue("sg")) == nil {
+		return nil
+	}
+	snapID, err := strconv.ParseInt(r.PathValue("snapid"), 10, 64)
+	if err == nil {
+		for _, snap := range s.snapshotsNamed(r.PathValue("sg"), r.PathValue("name")) {
+			if snap.snapID == snapID {
+				return snap
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "Cannot find Snapshot %s with snapid %s", r.PathValue("name"), r.PathValue("snapid"))
+	return nil
+}
+
+// isSnapshotSource reports whether the volume belongs to a storage group with snapshots.
+func (s *Server) isSnapshotSource(volumeID string) bool {
+	for _, sgID := range s.volumes[volumeID].storageGroups {
+		if len(s.snapshots[sgID]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	sgID := r.PathValue("sg")
+	var param powermax.StorageGroupSnapshotCreate
+	if s.findStorageGroup(w, sgID) == nil || !decode(w, r, &param) {
+		return
+	}
+	if len(s.volumesOfStorageGroup(sgID)) == 0 {
+		writeError(w, http.StatusBadRequest, "Storage Group %s has no volumes to snapshot", sgID)
+		return
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	snap := &snapshot{name: param.SnapshotName, snapID: int64(s.nextID), created: now}
+	s.nextID++
+	inHours := param.TimeInHours != nil && *param.TimeInHours
+	if param.TimeToLive != nil && *param.TimeToLive > 0 {
+		snap.ttlExpiry = expiry(now, *param.TimeToLive, inHours)
+	}
+	if param.Secure != nil && *param.Secure > 0 {
+		snap.secureUntil = expiry(now, *param.Secure, inHours)
+	}
+	s.snapshots[sgID] = append(s.snapshots[sgID], snap)
+	resp := powermax.NewSnapVXSnapshotGeneration(snap.name, snap.created.Format(time.ANSIC), snap.created.UnixMilli(), []string{"Established"},
+		s.snapshotSourceVolumes(sgID), int32(len(s.volumesOfStorageGroup(sgID))), false, false, false)
+	resp.SetGeneration(0)
+	resp.SetSnapId(snap.snapID)
+	s.respond(w, param.ExecutionOption, "Create Snapshot "+snap.name, resp)
+}
+
+// expiry returns the time which is value days, or hours, after from.
+func expiry(from time.Time, value int32, inHours bool) *time.Time {
+	unit := 24 * time.Hour
+	if inHours {
+		unit = time.Hour
+	}
+	t := from.Add(time.Duration(value) * unit)
+	return &t
+}
+
+func (s *Server) editSnapshot(w http.ResponseWriter, r *http.Request) {
+	sgID := r.PathValue("sg")
+	snap := s.findSnapshot(w, r)
+	var param powermax.StorageGroupSnapshotInstanceUpdate
+	if snap == nil || !decode(w, r, &param) {
+		return
+	}
+	switch {
+	case param.Action == "Rename" && param.Rename != nil:
+		snap.name = param.Rename.NewSnapshotName
+	case param.Action == "Restore":
+		snap.restored = true
+	case param.Action == "Link" && param.Link != nil:
+		target := param.Link.StorageGroupName
+		if s.findStorageGroup(w, target) == nil {
+			return
+		}
+		if target == sgID {
+			writeError(w, http.StatusBadRequest, "A snapshot cannot be linked to its source Storage Group")
+			return
+		}
+		snap.linked = append(remove(snap.linked, target), target)
+	case param.Action == "Unlink" && param.Unlink != nil:
+		if !contains(snap.linked, param.Unlink.StorageGroupName) {
+			writeError(w, http.StatusBadRequest, "Snapshot %s is not linked to Storage Group %s", snap.name, param.Unlink.StorageGroupName)
+			return
+		}
+		snap.linked = remove(snap.linked, param.Unlink.StorageGroupName)
+	case param.Action == "SetTimeToLive" && param.TimeToLive != nil:
+		snap.ttlExpiry = nil
+		if ttl := param.TimeToLive.TimeToLive; ttl != nil && *ttl > 0 {
+			snap.ttlExpiry = expiry(time.Now().UTC().Truncate(time.Second), *ttl, param.TimeToLive.TimeInHours != nil && *param.TimeToLive.TimeInHours)
+		}
+	case param.Action == "SetSecure" && param.Secure != nil:
+		if secure := param.Secure.Secure; secure != nil && *secure > 0 {
+			snap.secureUntil = expiry(time.Now().UTC().Truncate(time.Second), *secure, param.Secure.TimeInHours != nil && *param.Secure.TimeInHours)
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "The snapshot action %s is not supported", param.Action)
+		return
+	}
+	s.respond(w, param.ExecutionOption, param.Action+" Snapshot "+snap.name, s.snapshotResponse(sgID, snap))
+}
+
+func (s *Server) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	sgID := r.PathValue("sg")
+	snap := s.findSnapshot(w, r)
+	if snap == nil {
+		return
+	}
+	if len(snap.linked) > 0 {
+		writeError(w, http.StatusConflict, "Snapshot %s is linked to Storage Group %s", snap.name, snap.linked[0])
+		return
+	}
+	if snap.secureUntil != nil && snap.secureUntil.After(time.Now()) {
+		writeError(w, http.StatusConflict, "Snapshot %s is secure until %s", snap.name, snap.secureUntil.Format(time.ANSIC))
+		return
+	}
+	var kept []*snapshot
+	for _, other := range s.snapshots[sgID] {
+		if other != snap {
+			kept = append(kept, other)
+		}
+	}
+	s.snapshots[sgID] = kept
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) snapshotListResponse(sgID string) *powermax.StorageGroupSnapshotList {
+	list := &powermax.StorageGroupSnapshotList{Name: []string{}}
+	for _, snap := range s.snapshots[sgID] {
+		if contains(list.Name, snap.name) {
+			continue
+		}
+		named := s.snapshotsNamed(sgID, snap.name)
+		list.Name = append(list.Name, snap.name)
+		list.SnapshotNamesAndCounts = append(list.SnapshotNamesAndCounts, powermax.SnapshotNameGenerationCount{
+			Name:               powermax.PtrString(snap.name),
+			SnapshotCount:      powermax.PtrInt64(int64(len(named))),
+			NewestTimestampUtc: powermax.PtrInt64(named[0].created.UnixMilli()),
+		})
+	}
+	return list
+}
+
+// snapshotSourceVolumes returns the volumes of the storage group as snapshot source volumes.
+func (s *Server) snapshotSourceVolumes(sgID string) []powermax.SnapVXSnapshotGenerationSourceVolume {
+	volumes := []powermax.SnapVXSnapshotGenerationSourceVolume{}
+	for _, volumeID := range s.volumesOfStorageGroup(sgID) {
+		capacityMB := s.volumes[volumeID].capacityMB
+		volumes = append(volumes, powermax.SnapVXSnapshotGenerationSourceVolume{
+			Name:       volumeID,
+			Capacity:   int64(capacityMB / mbPerCylinder),
+			CapacityGb: float32(capacityMB / 1024),
+		})
+	}
+	return volumes
+}
+
+func (s *Server) snapshotResponse(sgID string, snap *snapshot) *powermax.SnapVXSnapshotInstance {
+	generation := int64(0)
+	for _, other := range s.snapshotsNamed(sgID, snap.name) {
+		if other == snap {
+			break
+		}
+		generation++
+	}
+	sources := s.snapshotSourceVolumes(sgID)
+	state := []string{"Established"}
+	if snap.restored {
+		state = []string{"Restored"}
+	}
+	resp := powermax.NewSnapVXSnapshotInstance(snap.name, snap.created.Format(time.ANSIC), snap.created.UnixMilli(), state,
+		sources, int32(len(sources)), false, len(snap.linked) > 0, snap.restored)
+	resp.SetGeneration(generation)
+	resp.SetSnapid(snap.snapID)
+	resp.SetNumSourceVolumes(int32(len(sources)))
+	resp.SetTracks(0)
+	resp.SetNonSharedTracks(0)
+	resp.SetPersistent(false)
+	if snap.ttlExpiry != nil {
+		resp.SetTimeToLiveExpiryDate(snap.ttlExpiry.Format(time.ANSIC))
+		resp.Expired = snap.ttlExpiry.Before(time.Now())
+	}
+	if snap.secureUntil != nil {
+		resp.SetSecureExpiryDate(snap.secureUntil.Format(time.ANSIC))
+	}
+	for _, target := range snap.linked {
+		resp.LinkedStorageGroupNames = append(resp.LinkedStorageGroupNames, target)
+		targetVolumes := s.volumesOfStorageGroup(target)
+		for i, source := range sources {
+			linked := powermax.LinkedSnapshots{
+				Name:                       target,
+				SourceVolumeName:           source.Name,
+				LinkedCreationTimestamp:    snap.created.Format(time.ANSIC),
+				PercentageCopied:           100,
+				TrackSize:                  128,
+				Defined:                    powermax.PtrBool(true),
+				BackgroundDefineInProgress: powermax.PtrBool(false),
+			}
+			if i < len(targetVolumes) {
+				linked.LinkedVolumeName = targetVolumes[i]
+			}
+			resp.LinkedStorageGroup = append(resp.LinkedStorageGroup, linked)
+		}
+	}
+	return resp
+}
+
+func (s *Server) findSnapshotPolicy(w http.ResponseWriter, id string) *snapshotPolicy {
+	policy, ok := s.snapshotPolicies[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Snapshot Policy %s", id)
+	}
+	return policy
+}
+
+// snapshotPoliciesOfStorageGroup returns the snapshot policies associated to the storage group.
+func (s *Server) snapshotPoliciesOfStorageGroup(sgID string) []string {
+	var policies []string
+	for _, id := range sortedKeys(s.snapshotPolicies) {
+		if contains(s.snapshotPolicies[id].storageGroups, sgID) {
+			policies = append(policies, id)
+		}
+	}
+	return policies
+}
+
+// intervalMinutes parses an interval like "10 Minutes", "1 Hour" or "7 Days".
+func intervalMinutes(interval string) (int64, bool) {
+	value, unit, found := strings.Cut(strings.TrimSpace(interval), " ")
+	count, err := strconv.ParseInt(value, 10, 64)
+	if !found || err != nil || count <= 0 {
+		return 0, false
+	}
+	switch strings.TrimSuffix(unit, "s") {
+	case "Minute":
+		return count, true
+	case "Hour":
+		return count * 60, true
+	case "Day":
+		return count * 1440, true
+	}
+	return 0, false
+}
+
+func (s *Server) createSnapshotPolicy(w http.ResponseWriter, r *http.Request) {
+	var param powermax.SnapshotPolicyCreate
+	if !decode(w, r, &param) {
+		return
+	}
+	if param.SnapshotPolicyName == nil || *param.SnapshotPolicyName == "" {
+		writeError(w, http.StatusBadRequest, "A snapshot policy name is required")
+		return
+	}
+	id := *param.SnapshotPolicyName
+	_, exists := s.snapshotPolicies[id]
+	if nameTaken(w, "Snapshot Policy", id, exists) {
+		return
+	}
+	if param.CloudSnapshotPolicyDetails != nil {
+		writeError(w, http.StatusBadRequest, "Cloud snapshot policies are not supported")
+		return
+	}
+	policy := &snapshotPolicy{intervalMinutes: 60, offsetMinutes: 0, snapshotCount: 48, complianceCountWarning: 47, complianceCountCritical: 46}
+	if param.Interval != nil {
+		minutes, ok := intervalMinutes(*param.Interval)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "Invalid interval %s", *param.Interval)
+			return
+		}
+		policy.intervalMinutes = minutes
+	}
+	if param.OffsetMins != nil {
+		policy.offsetMinutes = int64(*param.OffsetMins)
+	}
+	if details := param.LocalSnapshotPolicyDetails; details != nil {
+		if details.SnapshotCount != nil {
+			policy.snapshotCount = int64(*details.SnapshotCount)
+		}
+		policy.secure = details.Secure != nil && *details.Secure
+	}
+	if param.ComplianceCountWarning != nil {
+		policy.complianceCountWarning = *param.ComplianceCountWarning
+	}
+	if param.ComplianceCountCritical != nil {
+		policy.complianceCountCritical = *param.ComplianceCountCritical
+	}
+	s.snapshotPolicies[id] = policy
+	s.respond(w, param.ExecutionOption, "Create Snapshot Policy "+id, s.snapshotPolicyResponse(id))
+}
+
+func (s *Server) editSnapshotPolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	policy := s.findSnapshotPolicy(w, id)
+	var param powermax.SnapshotPolicyUpdate
+	if policy == nil || !decode(w, r, &param) {
+		return
+	}
+	switch {
+	case param.Action == "Modify" && param.Modify != nil:
+		modify := param.Modify
+		if modify.SnapshotPolicyName != nil && *modify.SnapshotPolicyName != id {
+			newID := *modify.SnapshotPolicyName
+			_, exists := s.snapshotPolicies[newID]
+			if nameTaken(w, "Snapshot Policy", newID, exists) {
+				return
+			}
+			delete(s.snapshotPolicies, id)
+			s.snapshotPolicies[newID] = policy
+			id = newID
+		}
+		if modify.IntervalMins != nil {
+			policy.intervalMinutes = *modify.IntervalMins
+		}
+		if modify.OffsetMins != nil {
+			policy.offsetMinutes = int64(*modify.OffsetMins)
+		}
+		if modify.SnapshotCount != nil {
+			policy.snapshotCount = int64(*modify.SnapshotCount)
+		}
+		if modify.ComplianceCountWarning != nil {
+			policy.complianceCountWarning = *modify.ComplianceCountWarning
+		}
+		if modify.ComplianceCountCritical != nil {
+			policy.complianceCountCritical = *modify.ComplianceCountCritical
+		}
+	case param.Action == "Suspend":
+		policy.suspended = true
+	case param.Action == "Resume":
+		policy.suspended = false
+	case param.Action == "AssociateToStorageGroups" && param.AssociateToStorageGroup != nil:
+		for _, sgID := range param.AssociateToStorageGroup.StorageGroupName {
+			if s.findStorageGroup(w, sgID) == nil {
+				return
+			}
+		}
+		added := param.AssociateToStorageGroup.StorageGroupName
+		policy.storageGroups = append(remove(policy.storageGroups, added...), added...)
+	case param.Action == "DisassociateFromStorageGroups" && param.DisassociateFromStorageGroup != nil:
+		policy.storageGroups = remove(policy.storageGroups, param.DisassociateFromStorageGroup.StorageGroupName...)
+	default:
+		writeError(w, http.StatusBadRequest, "The snapshot policy action %s is not supported", param.Action)
+		return
+	}
+	s.respond(w, param.ExecutionOption, param.Action+" Snapshot Policy "+id, s.snapshotPolicyResponse(id))
+}
+
+func (s *Server) deleteSnapshotPolicy(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	policy := s.findSnapshotPolicy(w, id)
+	if policy == nil {
+		return
+	}
+	if len(policy.storageGroups) > 0 {
+		writeError(w, http.StatusConflict, "Snapshot Policy %s is associated to Storage Group %s", id, policy.storageGroups[0])
+		return
+	}
+	delete(s.snapshotPolicies, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) snapshotPolicyResponse(id string) *powermax.SnapshotPolicy {
+	policy := s.snapshotPolicies[id]
+	resp := powermax.NewSnapshotPolicy(s.SerialNumber, id)
+	resp.SetSnapshotCount(policy.snapshotCount)
+	resp.SetIntervalMinutes(policy.intervalMinutes)
+	resp.SetOffsetMinutes(policy.offsetMinutes)
+	resp.SetSuspended(policy.suspended)
+	resp.SetSecure(policy.secure)
+	resp.SetStorageGroupCount(int32(len(policy.storageGroups)))
+	resp.SetComplianceCountWarning(policy.complianceCountWarning)
+	resp.SetComplianceCountCritical(policy.complianceCountCritical)
+	resp.SetType("local")
+	return resp
+}
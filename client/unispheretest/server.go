@@ -0,0 +1,290 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package unispheretest provides an in-memory stand-in for the Unisphere for PowerMax REST API.
+// It keeps the objects managed by the provider (hosts, host groups, port groups, masking views,
+// storage groups, volumes, snapshots and snapshot policies) so that the lifecycle of the
+// resources can be tested without an array.
+package unispheretest
+
+import (
+	"crypto/rand"
+	"dell/powermax-go-client"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSerialNumber is the serial number of the array served by NewServer.
+	DefaultSerialNumber = "000000000001"
+	// Username and Password are the credentials accepted by the server.
+	Username = "admin"
+	Password = "password"
+	// SRP is the storage resource pool of the array.
+	SRP = "SRP_1"
+	// Version is the Unisphere release reported by the server.
+	Version = "V10.1.0.0"
+
+	apiPrefix         = "/univmax/restapi/100"
+	sessionCookieName = "JSESSIONID"
+	asynchronous      = "ASYNCHRONOUS"
+)
+
+// ServiceLevels are the service levels of the array.
+var ServiceLevels = []string{"Diamond", "Platinum", "Gold", "Silver", "Bronze", "Optimized", "None"}
+
+// Server is a Unisphere REST server backed by memory. Requests must authenticate with Username
+// and Password, a session cookie is returned and accepted afterwards like Unisphere does.
+// Asynchronous requests are applied immediately and answered with a succeeded job.
+type Server struct {
+	*httptest.Server
+	// SerialNumber is the serial number of the array, requests for other arrays are answered 404.
+	SerialNumber string
+
+	mu               sync.Mutex
+	sessions         map[string]bool
+	jobs             map[string]*powermax.Job
+	ports            []powermax.SymmetrixPortKey
+	hosts            map[string]*host
+	hostGroups       map[string]*hostGroup
+	portGroups       map[string]*portGroup
+	maskingViews     map[string]*maskingView
+	storageGroups    map[string]*storageGroup
+	volumes          map[string]*volume
+	snapshots        map[string][]*snapshot
+	snapshotPolicies map[string]*snapshotPolicy
+	nextID           int
+}
+
+// NewServer starts a server for the array DefaultSerialNumber. It has the Srp SRP and the front
+// end ports OR-1C:0 to OR-1C:3 and OR-2C:0 to OR-2C:3. The caller must Close it.
+func NewServer() *Server {
+	return NewServerForArray(DefaultSerialNumber)
+}
+
+// NewServerForArray starts a server for the array serialNumber.
+func NewServerForArray(serialNumber string) *Server {
+	s := &Server{
+		SerialNumber:     serialNumber,
+		sessions:         map[string]bool{},
+		jobs:             map[string]*powermax.Job{},
+		hosts:            map[string]*host{},
+		hostGroups:       map[string]*hostGroup{},
+		portGroups:       map[string]*portGroup{},
+		maskingViews:     map[string]*maskingView{},
+		storageGroups:    map[string]*storageGroup{},
+		volumes:          map[string]*volume{},
+		snapshots:        map[string][]*snapshot{},
+		snapshotPolicies: map[string]*snapshotPolicy{},
+		nextID:           0x100,
+	}
+	for _, director := range []string{"OR-1C", "OR-2C"} {
+		for port := 0; port < 4; port++ {
+			s.ports = append(s.ports, powermax.SymmetrixPortKey{DirectorId: director, PortId: strconv.Itoa(port)})
+		}
+	}
+
+	mux := http.NewServeMux()
+	s.routeSystem(mux)
+	s.routeProvisioning(mux)
+	s.routeStorage(mux)
+	s.routeReplication(mux)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// handle registers a handler which runs authenticated, for the served array and with the lock held.
+func (s *Server) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.authenticate(w, r) {
+			writeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		if symid := r.PathValue("symid"); symid != "" && symid != s.SerialNumber {
+			writeError(w, http.StatusNotFound, "Cannot find Symmetrix %s", symid)
+			return
+		}
+		handler(w, r)
+	})
+}
+
+// authenticate accepts a known session cookie or the Basic credentials, which open a new session.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil && s.sessions[cookie.Value] {
+		return true
+	}
+	username, password, ok := r.BasicAuth()
+	if !ok || username != Username || password != Password {
+		return false
+	}
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	session := hex.EncodeToString(token)
+	s.sessions[session] = true
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Value: session, Path: "/univmax", HttpOnly: true})
+	return true
+}
+
+// newID returns a new hexadecimal ID of the given width, like the volume IDs of the array.
+func (s *Server) newID(width int) string {
+	s.nextID++
+	return fmt.Sprintf("%0*X", width, s.nextID)
+}
+
+// respond writes the result of a mutation. Asynchronous requests are answered with a job.
+func (s *Server) respond(w http.ResponseWriter, executionOption *string, name string, body interface{}) {
+	if executionOption == nil || *executionOption != asynchronous {
+		writeJSON(w, http.StatusOK, body)
+		return
+	}
+	now := time.Now()
+	job := powermax.NewJob(s.newID(8), "SUCCEEDED", Username, now.Format(time.RFC3339))
+	job.SetName(name)
+	job.SetSymmetrixId(s.SerialNumber)
+	job.SetResult("Succeeded")
+	job.SetCompletedDate(now.Format(time.RFC3339))
+	s.jobs[job.JobId] = job
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// decode reads the JSON body of the request into v and answers 400 when it is invalid.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body: %s", err.Error())
+		return false
+	}
+	return true
+}
+
+// writeJSON writes body as the JSON answer of the request.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if body != nil {
+		_ = json.NewEncoder(w).Encode(body)
+	}
+}
+
+// writeError writes an error formatted like the errors of Unisphere.
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"message": fmt.Sprintf(format, args...)})
+}
+
+// routeSystem registers the version, array, job and port endpoints.
+func (s *Server) routeSystem(mux *http.ServeMux) {
+	s.handle(mux, "POST /univmax/restapi/logout", func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(sessionCookieName); err == nil {
+			delete(s.sessions, cookie.Value)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.handle(mux, "GET /univmax/restapi/version", func(w http.ResponseWriter, r *http.Request) {
+		version := powermax.NewVersion()
+		version.SetVersion(Version)
+		version.SetApiVersion("101")
+		version.SupportedApiVersions = []string{"100", "101"}
+		writeJSON(w, http.StatusOK, version)
+	})
+	s.handle(mux, "GET "+apiPrefix+"/system/symmetrix/{symid}", func(w http.ResponseWriter, r *http.Request) {
+		symmetrix := powermax.NewSymmetrix(s.SerialNumber)
+		symmetrix.SetModel("PowerMax_8500")
+		symmetrix.SetMicrocode("6079.225.0")
+		symmetrix.SetLocal(true)
+		symmetrix.SetDefaultFbaSrp(SRP)
+		writeJSON(w, http.StatusOK, symmetrix)
+	})
+	s.handle(mux, "GET "+apiPrefix+"/system/job/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := s.jobs[r.PathValue("id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Cannot find job %s", r.PathValue("id"))
+			return
+		}
+		writeJSON(w, http.StatusOK, job)
+	})
+	s.handle(mux, "GET "+apiPrefix+"/system/tag", s.listTags)
+	s.handle(mux, "GET "+apiPrefix+"/system/tag/{tag}", s.getTag)
+	s.handle(mux, "GET "+apiPrefix+"/sloprovisioning/symmetrix/{symid}/port", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.DirectorPortList{SymmetrixPortKey: s.ports})
+	})
+	s.handle(mux, "GET "+apiPrefix+"/system/symmetrix/{symid}/director/{director}/port/{port}", func(w http.ResponseWriter, r *http.Request) {
+		key := powermax.SymmetrixPortKey{DirectorId: r.PathValue("director"), PortId: r.PathValue("port")}
+		if !s.portExists(key) {
+			writeError(w, http.StatusNotFound, "Cannot find port %s:%s", key.DirectorId, key.PortId)
+			return
+		}
+		portGroups := s.portGroupsOfPort(key)
+		maskingViews := s.maskingViewsOfPort(key)
+		port := powermax.NewSymmetrixPort(key)
+		port.SetPortStatus("ON")
+		port.SetDirectorStatus("Online")
+		port.SetType("FibreChannel (563)")
+		port.SetIdentifier(fmt.Sprintf("5000097%09X", s.portIndex(key)))
+		port.SetNegotiatedSpeed("32")
+		port.SetNumOfCores(4)
+		port.SetNumOfPortGroups(int32(len(portGroups)))
+		port.SetNumOfMaskingViews(int32(len(maskingViews)))
+		port.SetNumOfMappedVols(0)
+		port.SetAclx(true)
+		port.SetPortgroup(portGroups)
+		port.SetMaskingview(maskingViews)
+		writeJSON(w, http.StatusOK, powermax.DirectorPort{SymmetrixPort: port})
+	})
+}
+
+// portExists reports whether the front end port key exists on the array.
+func (s *Server) portExists(key powermax.SymmetrixPortKey) bool {
+	return s.portIndex(key) >= 0
+}
+
+// portIndex returns the index of the front end port key, or -1 if it does not exist.
+func (s *Server) portIndex(key powermax.SymmetrixPortKey) int {
+	for i, port := range s.ports {
+		if port == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// contains reports whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// remove returns values without the given ones.
+func remove(values []string, removed ...string) []string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if !contains(removed, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
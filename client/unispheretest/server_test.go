@@ -0,0 +1,494 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unispheretest
+
+import (
+	"context"
+	"dell/powermax-go-client"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, server *Server, password string) *client.Client {
+	c, err := client.NewClient(context.Background(), server.URL, Username, password, server.SerialNumber, "", true, client.ClientOptions{})
+	require.NoError(t, err)
+	return c
+}
+
+func TestServerValidatesConnection(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	assert.NoError(t, newTestClient(t, server, Password).ValidateConnection(context.Background(), ""))
+
+	var connErr *client.ConnectionError
+	assert.ErrorAs(t, newTestClient(t, server, "wrong").ValidateConnection(context.Background(), ""), &connErr)
+	assert.Equal(t, "username", connErr.Attribute)
+
+	err := newTestClient(t, server, Password).WithSerialNumber("000000000002").ValidateConnection(context.Background(), "")
+	assert.ErrorAs(t, err, &connErr)
+	assert.Equal(t, "serial_number", connErr.Attribute)
+}
+
+func TestServerStorageGroupLifecycle(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+
+	create := powermax.NewCreateStorageGroupParam("sg1")
+	create.SetSrpId(SRP)
+	create.SetSloBasedStorageGroupParam([]powermax.SloBasedStorageGroupParam{{SloId: powermax.PtrString("Gold")}})
+	sg, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*create).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "Gold", sg.GetSlo())
+	assert.True(t, sg.GetCompression())
+
+	_, _, err = api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*create).Execute()
+	assert.Equal(t, client.CategoryConflict, client.ParseAPIError(err).Category)
+
+	_, resp, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, "sg1").EditStorageGroupParam(powermax.EditStorageGroupParam{
+		ExecutionOption: powermax.PtrString(asynchronous),
+		EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+				AddVolumeParam: &powermax.AddVolumeParam{
+					CreateNewVolumes: powermax.PtrBool(true),
+					VolumeAttributes: []powermax.VolumeAttribute{{
+						CapacityUnit:     "GB",
+						VolumeSize:       "5",
+						NumOfVols:        powermax.PtrInt64(1),
+						VolumeIdentifier: &powermax.VolumeIdentifier{VolumeIdentifierChoice: "identifier_name", IdentifierName: powermax.PtrString("vol1")},
+					}},
+				},
+			},
+		},
+	}).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	volumes, _, err := api.ListVolumes(ctx, c.SymmetrixID).StorageGroupId("sg1").Execute()
+	require.NoError(t, err)
+	require.Len(t, volumes.ResultList.Result, 1)
+	volumeID := volumes.ResultList.Result[0]["volumeId"].(string)
+	vol, _, err := api.GetVolume(ctx, c.SymmetrixID, volumeID).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "vol1", vol.GetVolumeIdentifier())
+	assert.Equal(t, 5.0, vol.GetCapGb())
+	assert.Equal(t, []string{"sg1"}, vol.StorageGroupId)
+
+	_, err = api.DeleteVolume(ctx, c.SymmetrixID, volumeID).Execute()
+	assert.Equal(t, client.CategoryConflict, client.ParseAPIError(err).Category)
+
+	sg, _, err = api.GetStorageGroup2(ctx, c.SymmetrixID, "sg1").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), sg.GetNumOfVols())
+	assert.Equal(t, 5.0, sg.GetCapGb())
+
+	_, err = api.DeleteStorageGroup(ctx, c.SymmetrixID, "sg1").Execute()
+	require.NoError(t, err)
+	_, err = api.DeleteVolume(ctx, c.SymmetrixID, volumeID).Execute()
+	require.NoError(t, err)
+	_, _, err = api.GetStorageGroup2(ctx, c.SymmetrixID, "sg1").Execute()
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestServerCascadedStorageGroups(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+	edit := func(id string, action powermax.EditStorageGroupActionParam) error {
+		_, _, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, id).EditStorageGroupParam(powermax.EditStorageGroupParam{EditStorageGroupActionParam: action}).Execute()
+		return err
+	}
+	for _, id := range []string{"parent", "gold", "silver"} {
+		_, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*powermax.NewCreateStorageGroupParam(id)).Execute()
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, edit("parent", powermax.EditStorageGroupActionParam{
+		ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+			AddExistingStorageGroupParam: &powermax.AddExistingStorageGroupParam{StorageGroupId: []string{"gold", "silver"}},
+		},
+	}))
+	parent, _, err := api.GetStorageGroup2(ctx, c.SymmetrixID, "parent").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gold", "silver"}, parent.ChildStorageGroup)
+	assert.Equal(t, "Parent", parent.GetType())
+	child, _, err := api.GetStorageGroup2(ctx, c.SymmetrixID, "gold").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"parent"}, child.ParentStorageGroup)
+	assert.Equal(t, "Child", child.GetType())
+
+	// Cascades have two levels
+	err = edit("gold", powermax.EditStorageGroupActionParam{
+		ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+			AddExistingStorageGroupParam: &powermax.AddExistingStorageGroupParam{StorageGroupId: []string{"silver"}},
+		},
+	})
+	assert.Equal(t, client.CategoryValidation, client.ParseAPIError(err).Category)
+	_, err = api.DeleteStorageGroup(ctx, c.SymmetrixID, "gold").Execute()
+	assert.Equal(t, client.CategoryConflict, client.ParseAPIError(err).Category)
+
+	require.NoError(t, edit("parent", powermax.EditStorageGroupActionParam{
+		RemoveStorageGroupParam: &powermax.RemoveStorageGroupParam{StorageGroupId: []string{"gold"}},
+	}))
+	parent, _, err = api.GetStorageGroup2(ctx, c.SymmetrixID, "parent").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"silver"}, parent.ChildStorageGroup)
+	_, err = api.DeleteStorageGroup(ctx, c.SymmetrixID, "gold").Execute()
+	assert.NoError(t, err)
+}
+
+func TestServerVolumeAppendNumber(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+	_, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*powermax.NewCreateStorageGroupParam("sg1")).Execute()
+	require.NoError(t, err)
+
+	_, _, err = api.ModifyStorageGroup(ctx, c.SymmetrixID, "sg1").EditStorageGroupParam(powermax.EditStorageGroupParam{
+		EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+				AddVolumeParam: &powermax.AddVolumeParam{
+					CreateNewVolumes: powermax.PtrBool(true),
+					VolumeAttributes: []powermax.VolumeAttribute{{
+						CapacityUnit: "GB",
+						VolumeSize:   "1",
+						NumOfVols:    powermax.PtrInt64(2),
+						VolumeIdentifier: &powermax.VolumeIdentifier{
+							VolumeIdentifierChoice: "identifier_name_plus_append_number",
+							IdentifierName:         powermax.PtrString("data_"),
+							AppendNumber:           powermax.PtrString("3"),
+						},
+					}},
+				},
+			},
+		},
+	}).Execute()
+	require.NoError(t, err)
+
+	var identifiers []string
+	volumes, _, err := api.ListVolumes(ctx, c.SymmetrixID).StorageGroupId("sg1").Execute()
+	require.NoError(t, err)
+	for _, result := range volumes.ResultList.Result {
+		vol, _, err := api.GetVolume(ctx, c.SymmetrixID, result["volumeId"].(string)).Execute()
+		require.NoError(t, err)
+		identifiers = append(identifiers, vol.GetVolumeIdentifier())
+	}
+	assert.ElementsMatch(t, []string{"data_3", "data_4"}, identifiers)
+}
+
+func TestServerStorageGroupTags(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+	tag := func(id string, param powermax.TagManagementParam) error {
+		_, _, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, id).EditStorageGroupParam(powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{TagManagementParam: &param},
+		}).Execute()
+		return err
+	}
+	for _, id := range []string{"sg1", "sg2"} {
+		_, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*powermax.NewCreateStorageGroupParam(id)).Execute()
+		require.NoError(t, err)
+	}
+	require.NoError(t, tag("sg1", powermax.TagManagementParam{AddTagsParam: &powermax.AddTagsParam{TagName: []string{"app", "cc_100"}}}))
+	require.NoError(t, tag("sg2", powermax.TagManagementParam{AddTagsParam: &powermax.AddTagsParam{TagName: []string{"app"}}}))
+	require.NoError(t, tag("sg1", powermax.TagManagementParam{
+		RemoveTagsParam: &powermax.RemoveTagsParam{TagName: []string{"cc_100"}},
+		AddTagsParam:    &powermax.AddTagsParam{TagName: []string{"cc_200"}},
+	}))
+	err := tag("sg1", powermax.TagManagementParam{AddTagsParam: &powermax.AddTagsParam{TagName: []string{"a,b"}}})
+	assert.Equal(t, client.CategoryValidation, client.ParseAPIError(err).Category)
+
+	sg, _, err := api.GetStorageGroup2(ctx, c.SymmetrixID, "sg1").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "app,cc_200", sg.GetTags())
+
+	// The SDK requires every filter, the empty ones are not sent
+	tags, _, err := c.PmaxOpenapiClient.SystemApi.ListTags(ctx).TagName([]string{}).StorageGroupId([]string{}).
+		ArrayId([]string{}).NumOfStorageGroups([]string{}).NumOfArrays([]string{}).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app", "cc_200"}, tags.TagName)
+
+	app, _, err := c.PmaxOpenapiClient.SystemApi.GetTag(ctx, "app").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []powermax.SystemStorageGroupInfo{
+		{StorageGroupId: "sg1", ArrayId: c.SymmetrixID},
+		{StorageGroupId: "sg2", ArrayId: c.SymmetrixID},
+	}, app.GetStorageGroupInfos().StorageGroupInfo)
+	_, _, err = c.PmaxOpenapiClient.SystemApi.GetTag(ctx, "cc_100").Execute()
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestServerVolumeMove(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+	move := func(force bool, volumeIDs ...string) error {
+		_, _, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, "sg1").EditStorageGroupParam(powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				MoveVolumeToStorageGroupParam: &powermax.MoveVolumeToStorageGroupParam{VolumeId: volumeIDs, StorageGroupId: "sg2", Force: powermax.PtrBool(force)},
+			},
+		}).Execute()
+		return err
+	}
+	for _, id := range []string{"sg1", "sg2"} {
+		_, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*powermax.NewCreateStorageGroupParam(id)).Execute()
+		require.NoError(t, err)
+	}
+	_, _, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, "sg1").EditStorageGroupParam(powermax.EditStorageGroupParam{
+		EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+				AddVolumeParam: &powermax.AddVolumeParam{
+					CreateNewVolumes: powermax.PtrBool(true),
+					VolumeAttributes: []powermax.VolumeAttribute{{CapacityUnit: "GB", VolumeSize: "1", NumOfVols: powermax.PtrInt64(1)}},
+				},
+			},
+		},
+	}).Execute()
+	require.NoError(t, err)
+	volumes, _, err := api.ListVolumes(ctx, c.SymmetrixID).StorageGroupId("sg1").Execute()
+	require.NoError(t, err)
+	volumeID := volumes.ResultList.Result[0]["volumeId"].(string)
+
+	_, _, err = api.CreateHost(ctx, c.SymmetrixID).CreateHostParam(powermax.CreateHostParam{HostId: "host1", InitiatorId: []string{"10000000c9000001"}}).Execute()
+	require.NoError(t, err)
+	_, _, err = api.CreatePortGroup(ctx, c.SymmetrixID).CreatePortGroupParam(powermax.CreatePortGroupParam{
+		PortGroupId: "pg1", SymmetrixPortKey: []powermax.SymmetrixPortKey{{DirectorId: "OR-1C", PortId: "0"}},
+	}).Execute()
+	require.NoError(t, err)
+	_, _, err = api.CreateMaskingView(ctx, c.SymmetrixID).CreateMaskingViewParam(powermax.CreateMaskingViewParam{
+		MaskingViewId:            "mv1",
+		HostOrHostGroupSelection: &powermax.HostOrHostGroupSelection{UseExistingHostParam: &powermax.UseExistingHostParam{HostId: "host1"}},
+		PortGroupSelection:       &powermax.PortGroupSelection{UseExistingPortGroupParam: &powermax.UseExistingPortGroupParam{PortGroupId: "pg1"}},
+		StorageGroupSelection:    &powermax.StorageGroupSelection{UseExistingStorageGroupParam: &powermax.UseExistingStorageGroupParam{StorageGroupId: "sg2"}},
+	}).Execute()
+	require.NoError(t, err)
+
+	// A masked storage group requires force
+	err = move(false, volumeID)
+	assert.Equal(t, client.CategoryValidation, client.ParseAPIError(err).Category)
+	err = move(true, "09999")
+	assert.True(t, client.IsNotFound(err))
+	require.NoError(t, move(true, volumeID))
+	vol, _, err := api.GetVolume(ctx, c.SymmetrixID, volumeID).Execute()
+	require.NoError(t, err)
+	require.Len(t, vol.StorageGroups, 1)
+	assert.Equal(t, "sg2", vol.StorageGroups[0].GetStorageGroupName())
+	err = move(true, volumeID)
+	assert.Equal(t, client.CategoryValidation, client.ParseAPIError(err).Category)
+
+	// A volume of a storage group in use by FAST is moved to another one, not added
+	for _, id := range []string{"fast1", "fast2"} {
+		param := powermax.NewCreateStorageGroupParam(id)
+		param.SetSrpId(SRP)
+		_, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*param).Execute()
+		require.NoError(t, err)
+	}
+	add := func(sgID string) error {
+		_, _, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, sgID).EditStorageGroupParam(powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+					AddSpecificVolumeParam: &powermax.AddSpecificVolumeParam{VolumeId: []string{volumeID}},
+				},
+			},
+		}).Execute()
+		return err
+	}
+	require.NoError(t, add("fast1"))
+	err = add("fast2")
+	assert.Equal(t, client.CategoryValidation, client.ParseAPIError(err).Category)
+}
+
+func TestServerStorageGroupSplitMerge(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+	edit := func(id string, action powermax.EditStorageGroupActionParam) error {
+		_, _, err := api.ModifyStorageGroup(ctx, c.SymmetrixID, id).EditStorageGroupParam(powermax.EditStorageGroupParam{EditStorageGroupActionParam: action}).Execute()
+		return err
+	}
+	volumesOf := func(id string) []string {
+		volumes, _, err := api.ListVolumes(ctx, c.SymmetrixID).StorageGroupId(id).Execute()
+		require.NoError(t, err)
+		var volumeIDs []string
+		for _, result := range volumes.ResultList.Result {
+			volumeIDs = append(volumeIDs, result["volumeId"].(string))
+		}
+		return volumeIDs
+	}
+	_, _, err := api.CreateHost(ctx, c.SymmetrixID).CreateHostParam(powermax.CreateHostParam{HostId: "host1", InitiatorId: []string{"10000000c9000001"}}).Execute()
+	require.NoError(t, err)
+	_, _, err = api.CreatePortGroup(ctx, c.SymmetrixID).CreatePortGroupParam(powermax.CreatePortGroupParam{
+		PortGroupId: "pg1", SymmetrixPortKey: []powermax.SymmetrixPortKey{{DirectorId: "OR-1C", PortId: "0"}},
+	}).Execute()
+	require.NoError(t, err)
+	for _, id := range []string{"big", "parent", "child", "unmasked"} {
+		_, _, err := api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*powermax.NewCreateStorageGroupParam(id)).Execute()
+		require.NoError(t, err)
+	}
+	require.NoError(t, edit("big", powermax.EditStorageGroupActionParam{
+		ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+			AddVolumeParam: &powermax.AddVolumeParam{
+				CreateNewVolumes: powermax.PtrBool(true),
+				VolumeAttributes: []powermax.VolumeAttribute{{CapacityUnit: "GB", VolumeSize: "1", NumOfVols: powermax.PtrInt64(3)}},
+			},
+		},
+	}))
+	require.NoError(t, edit("parent", powermax.EditStorageGroupActionParam{
+		ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+			AddExistingStorageGroupParam: &powermax.AddExistingStorageGroupParam{StorageGroupId: []string{"child"}},
+		},
+	}))
+	for _, id := range []string{"big", "parent"} {
+		_, _, err = api.CreateMaskingView(ctx, c.SymmetrixID).CreateMaskingViewParam(powermax.CreateMaskingViewParam{
+			MaskingViewId:            id + "_mv",
+			HostOrHostGroupSelection: &powermax.HostOrHostGroupSelection{UseExistingHostParam: &powermax.UseExistingHostParam{HostId: "host1"}},
+			PortGroupSelection:       &powermax.PortGroupSelection{UseExistingPortGroupParam: &powermax.UseExistingPortGroupParam{PortGroupId: "pg1"}},
+			StorageGroupSelection:    &powermax.StorageGroupSelection{UseExistingStorageGroupParam: &powermax.UseExistingStorageGroupParam{StorageGroupId: id}},
+		}).Execute()
+		require.NoError(t, err)
+	}
+	volumeIDs := volumesOf("big")
+	require.Len(t, volumeIDs, 3)
+
+	// The split storage groups are masked like the storage group they are split from
+	require.NoError(t, edit("big", powermax.EditStorageGroupActionParam{
+		SplitStorageGroupVolumesParam: &powermax.SplitStorageGroupVolumesParam{VolumeId: volumeIDs[1:], StorageGroupId: "tier2", MaskingViewId: "tier2_mv"},
+	}))
+	assert.Equal(t, volumeIDs[:1], volumesOf("big"))
+	assert.ElementsMatch(t, volumeIDs[1:], volumesOf("tier2"))
+	mv, _, err := api.GetMaskingView(ctx, c.SymmetrixID, "tier2_mv").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "host1", mv.GetHostId())
+	assert.Equal(t, "tier2", mv.GetStorageGroupId())
+
+	require.NoError(t, edit("parent", powermax.EditStorageGroupActionParam{
+		SplitChildStorageGroupParam: &powermax.SplitChildStorageGroupParam{StorageGroupId: "child", MaskingViewId: "child_mv"},
+	}))
+	child, _, err := api.GetStorageGroup2(ctx, c.SymmetrixID, "child").Execute()
+	require.NoError(t, err)
+	assert.Empty(t, child.ParentStorageGroup)
+	assert.Equal(t, []string{"child_mv"}, child.Maskingview)
+
+	err = edit("big", powermax.EditStorageGroupActionParam{MergeStorageGroupParam: &powermax.MergeStorageGroupParam{StorageGroupId: "unmasked"}})
+	assert.Equal(t, client.CategoryValidation, client.ParseAPIError(err).Category)
+	require.NoError(t, edit("big", powermax.EditStorageGroupActionParam{MergeStorageGroupParam: &powermax.MergeStorageGroupParam{StorageGroupId: "tier2"}}))
+	assert.ElementsMatch(t, volumeIDs, volumesOf("big"))
+	_, _, err = api.GetStorageGroup2(ctx, c.SymmetrixID, "tier2").Execute()
+	assert.True(t, client.IsNotFound(err))
+	_, _, err = api.GetMaskingView(ctx, c.SymmetrixID, "tier2_mv").Execute()
+	assert.True(t, client.IsNotFound(err))
+}
+
+func TestServerMaskingViewReferences(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.SLOProvisioningApi
+
+	_, _, err := api.CreateHost(ctx, c.SymmetrixID).CreateHostParam(powermax.CreateHostParam{HostId: "host1", InitiatorId: []string{"10000000c9000001"}}).Execute()
+	require.NoError(t, err)
+	port := powermax.SymmetrixPortKey{DirectorId: "OR-1C", PortId: "0"}
+	_, _, err = api.CreatePortGroup(ctx, c.SymmetrixID).CreatePortGroupParam(powermax.CreatePortGroupParam{PortGroupId: "pg1", SymmetrixPortKey: []powermax.SymmetrixPortKey{port}}).Execute()
+	require.NoError(t, err)
+	_, _, err = api.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*powermax.NewCreateStorageGroupParam("sg1")).Execute()
+	require.NoError(t, err)
+
+	mv, _, err := api.CreateMaskingView(ctx, c.SymmetrixID).CreateMaskingViewParam(powermax.CreateMaskingViewParam{
+		MaskingViewId:            "mv1",
+		HostOrHostGroupSelection: &powermax.HostOrHostGroupSelection{UseExistingHostParam: &powermax.UseExistingHostParam{HostId: "host1"}},
+		PortGroupSelection:       &powermax.PortGroupSelection{UseExistingPortGroupParam: &powermax.UseExistingPortGroupParam{PortGroupId: "pg1"}},
+		StorageGroupSelection:    &powermax.StorageGroupSelection{UseExistingStorageGroupParam: &powermax.UseExistingStorageGroupParam{StorageGroupId: "sg1"}},
+	}).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "host1", mv.GetHostId())
+
+	host, _, err := api.GetHost(ctx, c.SymmetrixID, "host1").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mv1"}, host.Maskingview)
+	directorPort, _, err := c.PmaxOpenapiClient.SystemApi.GetDirectorPorts1(ctx, c.SymmetrixID, port.DirectorId, port.PortId).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), directorPort.SymmetrixPort.GetNumOfMaskingViews())
+
+	_, err = api.DeleteHost(ctx, c.SymmetrixID, "host1").Execute()
+	assert.Equal(t, client.CategoryConflict, client.ParseAPIError(err).Category)
+	_, err = api.DeleteMaskingView(ctx, c.SymmetrixID, "mv1").Execute()
+	require.NoError(t, err)
+	_, err = api.DeleteHost(ctx, c.SymmetrixID, "host1").Execute()
+	assert.NoError(t, err)
+}
+
+func TestServerSnapshots(t *testing.T) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	c := newTestClient(t, server, Password)
+	ctx := context.Background()
+	api := c.PmaxOpenapiClient.ReplicationApi
+
+	create := powermax.NewCreateStorageGroupParam("sg1")
+	create.SetSloBasedStorageGroupParam([]powermax.SloBasedStorageGroupParam{{
+		VolumeAttributes: []powermax.VolumeAttribute{{CapacityUnit: "CYL", VolumeSize: "547", NumOfVols: powermax.PtrInt64(2)}},
+	}})
+	_, _, err := c.PmaxOpenapiClient.SLOProvisioningApi.CreateStorageGroup(ctx, c.SymmetrixID).CreateStorageGroupParam(*create).Execute()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, _, err = api.CreateSnapshot1(ctx, c.SymmetrixID, "sg1").StorageGroupSnapshotCreate(powermax.StorageGroupSnapshotCreate{SnapshotName: "snap1"}).Execute()
+		require.NoError(t, err)
+	}
+	snapIDs, _, err := api.GetStorageGroupSnapshotSnapIDs(ctx, c.SymmetrixID, "sg1", "snap1").Execute()
+	require.NoError(t, err)
+	require.Len(t, snapIDs.Snapids, 2)
+
+	oldest, _, err := api.GetSnapshotSnapIDSG(ctx, c.SymmetrixID, "sg1", "snap1", snapIDs.Snapids[1]).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), oldest.GetGeneration())
+	assert.Equal(t, int32(2), oldest.GetNumSourceVolumes())
+
+	_, _, err = api.UpdateSnapshotSnapID(ctx, c.SymmetrixID, "sg1", "snap1", snapIDs.Snapids[0]).StorageGroupSnapshotInstanceUpdate(powermax.StorageGroupSnapshotInstanceUpdate{
+		Action: "Rename",
+		Rename: &powermax.SnapVXRenameOptions{NewSnapshotName: "snap2"},
+	}).Execute()
+	require.NoError(t, err)
+	list, _, err := api.GetStorageGroupSnapshots(ctx, c.SymmetrixID, "sg1").Execute()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"snap1", "snap2"}, list.Name)
+
+	_, err = api.DeleteSnapshotSnapID(ctx, c.SymmetrixID, "sg1", "snap1", snapIDs.Snapids[0]).Execute()
+	assert.True(t, client.IsNotFound(err))
+	_, err = api.DeleteSnapshotSnapID(ctx, c.SymmetrixID, "sg1", "snap2", snapIDs.Snapids[0]).Execute()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,858 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unispheretest
+
+import (
+	"dell/powermax-go-client"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mbPerCylinder is the capacity of a cylinder of an FBA volume.
+const mbPerCylinder = 1.875
+
+// storageGroup is a storage group of the array. Its volumes are the ones referencing it, and the
+// volumes of its children when it is the parent of a cascade.
+type storageGroup struct {
+	srp         string
+	slo         string
+	workload    string
+	compression bool
+	hostIOLimit *powermax.HostIOLimit
+	uuid        string
+	children    []string
+	tags        []string
+}
+
+// volume is a volume of the array, its capacity is stored in MB.
+type volume struct {
+	identifier    string
+	capacityMB    float64
+	mobilityID    bool
+	storageGroups []string
+}
+
+// routeStorage registers the storage group and volume endpoints.
+func (s *Server) routeStorage(mux *http.ServeMux) {
+	s.handle(mux, "GET "+sloPrefix+"/storagegroup", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, powermax.ListStorageGroupResult{StorageGroupId: sortedKeys(s.storageGroups)})
+	})
+	s.handle(mux, "POST "+sloPrefix+"/storagegroup", s.createStorageGroup)
+	s.handle(mux, "GET "+sloPrefix+"/storagegroup/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findStorageGroup(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.storageGroupResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "PUT "+sloPrefix+"/storagegroup/{id}", s.editStorageGroup)
+	s.handle(mux, "DELETE "+sloPrefix+"/storagegroup/{id}", s.deleteStorageGroup)
+
+	s.handle(mux, "GET "+sloPrefix+"/volume", s.listVolumes)
+	s.handle(mux, "GET "+sloPrefix+"/volume/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if s.findVolume(w, r.PathValue("id")) != nil {
+			writeJSON(w, http.StatusOK, s.volumeResponse(r.PathValue("id")))
+		}
+	})
+	s.handle(mux, "PUT "+sloPrefix+"/volume/{id}", s.editVolume)
+	s.handle(mux, "DELETE "+sloPrefix+"/volume/{id}", s.deleteVolume)
+}
+
+func (s *Server) findStorageGroup(w http.ResponseWriter, id string) *storageGroup {
+	sg, ok := s.storageGroups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Storage Group %s", id)
+	}
+	return sg
+}
+
+func (s *Server) findVolume(w http.ResponseWriter, id string) *volume {
+	vol, ok := s.volumes[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Cannot find Volume %s", id)
+	}
+	return vol
+}
+
+func (s *Server) createStorageGroup(w http.ResponseWriter, r *http.Request) {
+	var param powermax.CreateStorageGroupParam
+	if !decode(w, r, &param) {
+		return
+	}
+	_, exists := s.storageGroups[param.StorageGroupId]
+	if nameTaken(w, "Storage Group", param.StorageGroupId, exists) {
+		return
+	}
+	sg := &storageGroup{srp: "None", slo: "None", workload: "None", uuid: s.newID(32)}
+	if param.SrpId != nil {
+		sg.srp = *param.SrpId
+	}
+	if sg.srp != SRP && sg.srp != "None" {
+		writeError(w, http.StatusBadRequest, "Cannot find Srp %s", sg.srp)
+		return
+	}
+	sg.compression = sg.srp != "None"
+	var volumes []powermax.VolumeAttribute
+	for _, slo := range param.SloBasedStorageGroupParam {
+		if slo.SloId != nil {
+			sg.slo = *slo.SloId
+		}
+		if slo.WorkloadSelection != nil {
+			sg.workload = *slo.WorkloadSelection
+		}
+		if slo.NoCompression != nil && *slo.NoCompression {
+			sg.compression = false
+		}
+		if slo.SetHostIOLimitsParam != nil && !setHostIOLimit(w, sg, slo.SetHostIOLimitsParam) {
+			return
+		}
+		volumes = append(volumes, slo.VolumeAttributes...)
+	}
+	if !contains(ServiceLevels, sg.slo) {
+		writeError(w, http.StatusBadRequest, "Cannot find Service Level %s", sg.slo)
+		return
+	}
+	s.storageGroups[param.StorageGroupId] = sg
+	if !s.createVolumes(w, param.StorageGroupId, volumes, nil, false) {
+		delete(s.storageGroups, param.StorageGroupId)
+		return
+	}
+	s.respond(w, param.ExecutionOption, "Create Storage Group "+param.StorageGroupId, s.storageGroupResponse(param.StorageGroupId))
+}
+
+// setHostIOLimit sets the host IO limit of the storage group, a limit requires a dynamic distribution.
+func setHostIOLimit(w http.ResponseWriter, sg *storageGroup, param *powermax.SetHostIOLimitsParam) bool {
+	if param.DynamicDistribution == nil || (param.HostIoLimitMbSec == nil && param.HostIoLimitIoSec == nil) {
+		writeError(w, http.StatusBadRequest, "A host IO limit requires a limit and a dynamic distribution")
+		return false
+	}
+	limit := powermax.NewHostIOLimit()
+	limit.DynamicDistribution = param.DynamicDistribution
+	limit.SetHostIoLimitMbSec("NOLIMIT")
+	limit.SetHostIoLimitIoSec("NOLIMIT")
+	if param.HostIoLimitMbSec != nil {
+		limit.HostIoLimitMbSec = param.HostIoLimitMbSec
+	}
+	if param.HostIoLimitIoSec != nil {
+		limit.HostIoLimitIoSec = param.HostIoLimitIoSec
+	}
+	sg.hostIOLimit = limit
+	return true
+}
+
+// createVolumes creates the volumes described by the attributes in the storage group. The volume
+// identifier of the attributes takes precedence over the identifier of the request, an append number
+// is added to the identifier of each volume and incremented.
+func (s *Server) createVolumes(w http.ResponseWriter, sgID string, attributes []powermax.VolumeAttribute, identifier *powermax.VolumeIdentifier, mobilityID bool) bool {
+	type request struct {
+		identifier string
+		capacityMB float64
+	}
+	var requests []request
+	for _, attribute := range attributes {
+		count := int64(1)
+		if attribute.NumOfVols != nil {
+			count = *attribute.NumOfVols
+		}
+		if count == 0 {
+			continue
+		}
+		capacityMB, err := parseCapacity(attribute.VolumeSize, attribute.CapacityUnit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%s", err.Error())
+			return false
+		}
+		volumeIdentifier := identifier
+		if attribute.VolumeIdentifier != nil {
+			volumeIdentifier = attribute.VolumeIdentifier
+		}
+		name := identifierName(volumeIdentifier)
+		appendNumber := int64(-1)
+		if volumeIdentifier != nil && volumeIdentifier.VolumeIdentifierChoice == "identifier_name_plus_append_number" {
+			appendNumber, err = strconv.ParseInt(volumeIdentifier.GetAppendNumber(), 10, 64)
+			if err != nil || appendNumber < 0 {
+				writeError(w, http.StatusBadRequest, "Invalid append number %s", volumeIdentifier.GetAppendNumber())
+				return false
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			if appendNumber >= 0 {
+				requests = append(requests, request{identifier: name + strconv.FormatInt(appendNumber+i, 10), capacityMB: capacityMB})
+				continue
+			}
+			requests = append(requests, request{identifier: name, capacityMB: capacityMB})
+		}
+	}
+	for _, req := range requests {
+		s.volumes[s.newID(5)] = &volume{
+			identifier:    req.identifier,
+			capacityMB:    req.capacityMB,
+			mobilityID:    mobilityID,
+			storageGroups: []string{sgID},
+		}
+	}
+	return true
+}
+
+// identifierName returns the name set by a volume identifier, or "" if none.
+func identifierName(identifier *powermax.VolumeIdentifier) string {
+	if identifier == nil || identifier.IdentifierName == nil {
+		return ""
+	}
+	return *identifier.IdentifierName
+}
+
+// parseCapacity converts a volume size in the given unit to MB.
+func parseCapacity(size, unit string) (float64, error) {
+	value, err := strconv.ParseFloat(size, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid volume size %s", size)
+	}
+	switch strings.ToUpper(unit) {
+	case "CYL":
+		if value != math.Trunc(value) {
+			return 0, fmt.Errorf("the size of a volume in CYL must be an integer")
+		}
+		return value * mbPerCylinder, nil
+	case "MB":
+		return value, nil
+	case "GB":
+		return value * 1024, nil
+	case "TB":
+		return value * 1024 * 1024, nil
+	}
+	return 0, fmt.Errorf("invalid capacity unit %s", unit)
+}
+
+func (s *Server) editStorageGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	sg := s.findStorageGroup(w, id)
+	var param powermax.EditStorageGroupParam
+	if sg == nil || !decode(w, r, &param) {
+		return
+	}
+	action := param.EditStorageGroupActionParam
+	switch {
+	case action.RenameStorageGroupParam != nil:
+		newID := action.RenameStorageGroupParam.NewStorageGroupName
+		_, exists := s.storageGroups[newID]
+		if nameTaken(w, "Storage Group", newID, exists) {
+			return
+		}
+		s.renameStorageGroup(id, newID)
+		id = newID
+	case action.EditCompressionParam != nil:
+		sg.compression = action.EditCompressionParam.Compression != nil && *action.EditCompressionParam.Compression
+	case action.SetHostIOLimitsParam != nil:
+		if !setHostIOLimit(w, sg, action.SetHostIOLimitsParam) {
+			return
+		}
+	case action.EditStorageGroupWorkloadParam != nil:
+		sg.workload = action.EditStorageGroupWorkloadParam.WorkloadSelection
+	case action.EditStorageGroupSLOParam != nil:
+		if !contains(ServiceLevels, action.EditStorageGroupSLOParam.SloId) {
+			writeError(w, http.StatusBadRequest, "Cannot find Service Level %s", action.EditStorageGroupSLOParam.SloId)
+			return
+		}
+		sg.slo = action.EditStorageGroupSLOParam.SloId
+	case action.EditStorageGroupSRPParam != nil:
+		if srp := action.EditStorageGroupSRPParam.SrpId; srp != SRP && srp != "None" {
+			writeError(w, http.StatusBadRequest, "Cannot find Srp %s", srp)
+			return
+		}
+		sg.srp = action.EditStorageGroupSRPParam.SrpId
+	case action.ExpandStorageGroupParam != nil && action.ExpandStorageGroupParam.AddExistingStorageGroupParam != nil:
+		if !s.addChildStorageGroups(w, id, action.ExpandStorageGroupParam.AddExistingStorageGroupParam.StorageGroupId) {
+			return
+		}
+	case action.RemoveStorageGroupParam != nil:
+		for _, childID := range action.RemoveStorageGroupParam.StorageGroupId {
+			if !contains(sg.children, childID) {
+				writeError(w, http.StatusBadRequest, "Storage Group %s is not a child of Storage Group %s", childID, id)
+				return
+			}
+		}
+		sg.children = remove(sg.children, action.RemoveStorageGroupParam.StorageGroupId...)
+	case action.TagManagementParam != nil:
+		if removed := action.TagManagementParam.RemoveTagsParam; removed != nil {
+			sg.tags = remove(sg.tags, removed.TagName...)
+		}
+		if add := action.TagManagementParam.AddTagsParam; add != nil {
+			for _, tag := range add.TagName {
+				if tag == "" || strings.Contains(tag, ",") {
+					writeError(w, http.StatusBadRequest, "Invalid tag name %q", tag)
+					return
+				}
+			}
+			sg.tags = append(remove(sg.tags, add.TagName...), add.TagName...)
+		}
+	case len(sg.children) > 0 && action.ExpandStorageGroupParam != nil:
+		writeError(w, http.StatusBadRequest, "Storage Group %s is a parent storage group, volumes are added to its children", id)
+		return
+	case action.ExpandStorageGroupParam != nil && action.ExpandStorageGroupParam.AddSpecificVolumeParam != nil:
+		for _, volumeID := range action.ExpandStorageGroupParam.AddSpecificVolumeParam.VolumeId {
+			vol := s.findVolume(w, volumeID)
+			if vol == nil {
+				return
+			}
+			// Like Unisphere, a volume belongs to one storage group in use by FAST at most
+			for _, other := range vol.storageGroups {
+				if other != id && sg.srp != "None" && s.storageGroups[other].srp != "None" {
+					writeError(w, http.StatusBadRequest, "Volume %s is already in Storage Group %s, a volume cannot belong to more than one storage group in use by FAST", volumeID, other)
+					return
+				}
+			}
+		}
+		for _, volumeID := range action.ExpandStorageGroupParam.AddSpecificVolumeParam.VolumeId {
+			vol := s.volumes[volumeID]
+			vol.storageGroups = append(remove(vol.storageGroups, id), id)
+		}
+	case action.ExpandStorageGroupParam != nil && action.ExpandStorageGroupParam.AddVolumeParam != nil:
+		add := action.ExpandStorageGroupParam.AddVolumeParam
+		if add.CreateNewVolumes != nil && !*add.CreateNewVolumes {
+			writeError(w, http.StatusBadRequest, "Reusing existing volumes is not supported")
+			return
+		}
+		if !s.createVolumes(w, id, add.VolumeAttributes, add.VolumeIdentifier, add.EnableMobilityId != nil && *add.EnableMobilityId) {
+			return
+		}
+	case action.RemoveVolumeParam != nil:
+		for _, volumeID := range action.RemoveVolumeParam.VolumeId {
+			if vol := s.findVolume(w, volumeID); vol == nil {
+				return
+			} else if !contains(vol.storageGroups, id) {
+				writeError(w, http.StatusBadRequest, "Volume %s is not in Storage Group %s", volumeID, id)
+				return
+			}
+		}
+		for _, volumeID := range action.RemoveVolumeParam.VolumeId {
+			vol := s.volumes[volumeID]
+			vol.storageGroups = remove(vol.storageGroups, id)
+		}
+	case action.MoveVolumeToStorageGroupParam != nil:
+		if !s.moveVolumes(w, id, action.MoveVolumeToStorageGroupParam) {
+			return
+		}
+	case action.SplitStorageGroupVolumesParam != nil:
+		if !s.splitVolumes(w, id, action.SplitStorageGroupVolumesParam) {
+			return
+		}
+	case action.SplitChildStorageGroupParam != nil:
+		if !s.splitChild(w, id, action.SplitChildStorageGroupParam) {
+			return
+		}
+	case action.MergeStorageGroupParam != nil:
+		if !s.mergeStorageGroup(w, id, action.MergeStorageGroupParam.StorageGroupId) {
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, "The edit storage group action is not supported")
+		return
+	}
+	s.respond(w, param.ExecutionOption, "Modify Storage Group "+id, s.storageGroupResponse(id))
+}
+
+// addChildStorageGroups cascades the storage groups under the parent. Like Unisphere, cascades
+// have two levels, a child has one parent and a parent has no volumes of its own.
+func (s *Server) addChildStorageGroups(w http.ResponseWriter, parentID string, childIDs []string) bool {
+	parent := s.storageGroups[parentID]
+	if parents := s.parentsOf(parentID); len(parents) > 0 {
+		writeError(w, http.StatusBadRequest, "Storage Group %s is a child of Storage Group %s", parentID, parents[0])
+		return false
+	}
+	if len(parent.children) == 0 && len(s.volumesOfStorageGroup(parentID)) > 0 {
+		writeError(w, http.StatusBadRequest, "Storage Group %s has volumes and cannot be a parent storage group", parentID)
+		return false
+	}
+	for _, childID := range childIDs {
+		child := s.findStorageGroup(w, childID)
+		if child == nil {
+			return false
+		}
+		if childID == parentID || len(child.children) > 0 {
+			writeError(w, http.StatusBadRequest, "Storage Group %s is a parent storage group", childID)
+			return false
+		}
+		if parents := s.parentsOf(childID); len(parents) > 0 && parents[0] != parentID {
+			writeError(w, http.StatusBadRequest, "Storage Group %s is already a child of Storage Group %s", childID, parents[0])
+			return false
+		}
+	}
+	for _, childID := range childIDs {
+		parent.children = append(remove(parent.children, childID), childID)
+	}
+	return true
+}
+
+// moveVolumes moves the volumes of the storage group to the target storage group in one step.
+// Like Unisphere, the move requires force when either storage group is masked to a host.
+func (s *Server) moveVolumes(w http.ResponseWriter, id string, param *powermax.MoveVolumeToStorageGroupParam) bool {
+	target := s.findStorageGroup(w, param.StorageGroupId)
+	if target == nil {
+		return false
+	}
+	if param.StorageGroupId == id || len(target.children) > 0 {
+		writeError(w, http.StatusBadRequest, "Volumes cannot be moved to Storage Group %s", param.StorageGroupId)
+		return false
+	}
+	for _, volumeID := range param.VolumeId {
+		if vol := s.findVolume(w, volumeID); vol == nil {
+			return false
+		} else if !contains(vol.storageGroups, id) {
+			writeError(w, http.StatusBadRequest, "Volume %s is not in Storage Group %s", volumeID, id)
+			return false
+		}
+	}
+	if (s.masked(id) || s.masked(param.StorageGroupId)) && (param.Force == nil || !*param.Force) {
+		writeError(w, http.StatusBadRequest, "Storage Group %s or %s is in a masking view, force is required to move volumes", id, param.StorageGroupId)
+		return false
+	}
+	for _, volumeID := range param.VolumeId {
+		vol := s.volumes[volumeID]
+		vol.storageGroups = append(remove(vol.storageGroups, id, param.StorageGroupId), param.StorageGroupId)
+	}
+	return true
+}
+
+// splitVolumes moves volumes of the masked storage group to a new storage group with the same
+// service level, masked to the host and port group of the storage group by a new masking view.
+func (s *Server) splitVolumes(w http.ResponseWriter, id string, param *powermax.SplitStorageGroupVolumesParam) bool {
+	sg := s.storageGroups[id]
+	view := s.splitMaskingView(w, id, param.MaskingViewId)
+	if view == nil {
+		return false
+	}
+	_, exists := s.storageGroups[param.StorageGroupId]
+	if nameTaken(w, "Storage Group", param.StorageGroupId, exists) {
+		return false
+	}
+	if len(param.VolumeId) == 0 {
+		writeError(w, http.StatusBadRequest, "No volumes to split from Storage Group %s", id)
+		return false
+	}
+	for _, volumeID := range param.VolumeId {
+		if vol := s.findVolume(w, volumeID); vol == nil {
+			return false
+		} else if !contains(vol.storageGroups, id) {
+			writeError(w, http.StatusBadRequest, "Volume %s is not in Storage Group %s", volumeID, id)
+			return false
+		}
+	}
+	s.storageGroups[param.StorageGroupId] = &storageGroup{
+		srp:         sg.srp,
+		slo:         sg.slo,
+		workload:    sg.workload,
+		compression: sg.compression,
+		uuid:        s.newID(32),
+	}
+	for _, volumeID := range param.VolumeId {
+		vol := s.volumes[volumeID]
+		vol.storageGroups = append(remove(vol.storageGroups, id), param.StorageGroupId)
+	}
+	s.maskingViews[param.MaskingViewId] = &maskingView{host: view.host, hostGroup: view.hostGroup, portGroup: view.portGroup, storageGroup: param.StorageGroupId}
+	return true
+}
+
+// splitChild uncascades a child of the masked parent storage group, and masks it to the host and
+// port group of the parent by a new masking view.
+func (s *Server) splitChild(w http.ResponseWriter, id string, param *powermax.SplitChildStorageGroupParam) bool {
+	sg := s.storageGroups[id]
+	view := s.splitMaskingView(w, id, param.MaskingViewId)
+	if view == nil {
+		return false
+	}
+	if !contains(sg.children, param.StorageGroupId) {
+		writeError(w, http.StatusBadRequest, "Storage Group %s is not a child of Storage Group %s", param.StorageGroupId, id)
+		return false
+	}
+	sg.children = remove(sg.children, param.StorageGroupId)
+	s.maskingViews[param.MaskingViewId] = &maskingView{host: view.host, hostGroup: view.hostGroup, portGroup: view.portGroup, storageGroup: param.StorageGroupId}
+	return true
+}
+
+// splitMaskingView returns the masking view of the storage group split, after checking the name
+// of the masking view of the new storage group is free.
+func (s *Server) splitMaskingView(w http.ResponseWriter, id, maskingViewID string) *maskingView {
+	views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.storageGroup == id })
+	if len(views) == 0 {
+		writeError(w, http.StatusBadRequest, "Storage Group %s is not in a masking view", id)
+		return nil
+	}
+	_, exists := s.maskingViews[maskingViewID]
+	if nameTaken(w, "Masking View", maskingViewID, exists) {
+		return nil
+	}
+	return s.maskingViews[views[0]]
+}
+
+// mergeStorageGroup moves the volumes of the standalone storage group into the storage group, then
+// deletes the standalone storage group and its masking views. Both storage groups must be masked to
+// the same host and port group.
+func (s *Server) mergeStorageGroup(w http.ResponseWriter, id, standaloneID string) bool {
+	standalone := s.findStorageGroup(w, standaloneID)
+	if standalone == nil {
+		return false
+	}
+	if standaloneID == id || len(standalone.children) > 0 || len(s.parentsOf(standaloneID)) > 0 || len(s.storageGroups[id].children) > 0 {
+		writeError(w, http.StatusBadRequest, "Storage Group %s cannot be merged into Storage Group %s", standaloneID, id)
+		return false
+	}
+	views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.storageGroup == id })
+	standaloneViews := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.storageGroup == standaloneID })
+	if len(views) == 0 || len(standaloneViews) == 0 {
+		writeError(w, http.StatusBadRequest, "Storage Groups %s and %s must be in masking views to be merged", id, standaloneID)
+		return false
+	}
+	view, standaloneView := s.maskingViews[views[0]], s.maskingViews[standaloneViews[0]]
+	if view.host != standaloneView.host || view.hostGroup != standaloneView.hostGroup || view.portGroup != standaloneView.portGroup {
+		writeError(w, http.StatusBadRequest, "Storage Groups %s and %s are not masked to the same host and port group", id, standaloneID)
+		return false
+	}
+	if len(s.snapshots[standaloneID]) > 0 {
+		writeError(w, http.StatusConflict, "Storage Group %s has snapshots", standaloneID)
+		return false
+	}
+	for _, vol := range s.volumes {
+		if contains(vol.storageGroups, standaloneID) {
+			vol.storageGroups = append(remove(vol.storageGroups, standaloneID, id), id)
+		}
+	}
+	for _, viewID := range standaloneViews {
+		delete(s.maskingViews, viewID)
+	}
+	for _, policy := range s.snapshotPolicies {
+		policy.storageGroups = remove(policy.storageGroups, standaloneID)
+	}
+	delete(s.storageGroups, standaloneID)
+	return true
+}
+
+// masked reports whether the storage group or its parent is in a masking view.
+func (s *Server) masked(id string) bool {
+	groups := append(s.parentsOf(id), id)
+	return len(s.maskingViewsWhere(func(mv *maskingView) bool { return contains(groups, mv.storageGroup) })) > 0
+}
+
+// parentsOf returns the parent storage groups of the storage group.
+func (s *Server) parentsOf(id string) []string {
+	var parents []string
+	for _, parentID := range sortedKeys(s.storageGroups) {
+		if contains(s.storageGroups[parentID].children, id) {
+			parents = append(parents, parentID)
+		}
+	}
+	return parents
+}
+
+// renameStorageGroup renames the storage group and updates the objects referencing it.
+func (s *Server) renameStorageGroup(id, newID string) {
+	s.storageGroups[newID] = s.storageGroups[id]
+	delete(s.storageGroups, id)
+	for _, sg := range s.storageGroups {
+		if contains(sg.children, id) {
+			sg.children = append(remove(sg.children, id), newID)
+		}
+	}
+	for _, vol := range s.volumes {
+		if contains(vol.storageGroups, id) {
+			vol.storageGroups = append(remove(vol.storageGroups, id), newID)
+		}
+	}
+	for _, mv := range s.maskingViews {
+		if mv.storageGroup == id {
+			mv.storageGroup = newID
+		}
+	}
+	if snapshots, ok := s.snapshots[id]; ok {
+		s.snapshots[newID] = snapshots
+		delete(s.snapshots, id)
+	}
+	for _, policy := range s.snapshotPolicies {
+		if contains(policy.storageGroups, id) {
+			policy.storageGroups = append(remove(policy.storageGroups, id), newID)
+		}
+	}
+}
+
+func (s *Server) deleteStorageGroup(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if s.findStorageGroup(w, id) == nil {
+		return
+	}
+	if views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.storageGroup == id }); len(views) > 0 {
+		writeError(w, http.StatusConflict, "Storage Group %s is part of Masking View %s", id, views[0])
+		return
+	}
+	if len(s.snapshots[id]) > 0 {
+		writeError(w, http.StatusConflict, "Storage Group %s has snapshots", id)
+		return
+	}
+	if parents := s.parentsOf(id); len(parents) > 0 {
+		writeError(w, http.StatusConflict, "Storage Group %s is a child of Storage Group %s", id, parents[0])
+		return
+	}
+	for _, vol := range s.volumes {
+		vol.storageGroups = remove(vol.storageGroups, id)
+	}
+	for _, policy := range s.snapshotPolicies {
+		policy.storageGroups = remove(policy.storageGroups, id)
+	}
+	delete(s.storageGroups, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) storageGroupResponse(id string) *powermax.StorageGroup {
+	sg := s.storageGroups[id]
+	volumes := s.volumesOfStorageGroup(id)
+	capacityGB := 0.0
+	for _, volumeID := range volumes {
+		capacityGB += s.volumes[volumeID].capacityMB / 1024
+	}
+	views := s.maskingViewsWhere(func(mv *maskingView) bool { return mv.storageGroup == id })
+	policies := s.snapshotPoliciesOfStorageGroup(id)
+
+	resp := powermax.NewStorageGroup(id)
+	resp.SetSrp(sg.srp)
+	if sg.slo != "None" {
+		resp.SetSlo(sg.slo)
+		resp.SetServiceLevel(sg.slo)
+		resp.SetBaseSloName(sg.slo)
+		resp.SetSloCompliance("STABLE")
+	} else {
+		resp.SetSloCompliance("NONE")
+	}
+	if sg.workload != "None" {
+		resp.SetWorkload(sg.workload)
+	}
+	resp.SetNumOfVols(int32(len(volumes)))
+	parents := s.parentsOf(id)
+	resp.SetNumOfChildSgs(int64(len(sg.children)))
+	resp.SetNumOfParentSgs(int64(len(parents)))
+	resp.ChildStorageGroup = sg.children
+	resp.ParentStorageGroup = parents
+	resp.SetNumOfMaskingViews(int64(len(views)))
+	resp.SetNumOfSnapshots(int64(len(s.snapshots[id])))
+	resp.SetNumOfSnapshotPolicies(int64(len(policies)))
+	resp.SetCapGb(math.Round(capacityGB*100) / 100)
+	resp.SetDeviceEmulation("FBA")
+	switch {
+	case len(sg.children) > 0:
+		resp.SetType("Parent")
+	case len(parents) > 0:
+		resp.SetType("Child")
+	default:
+		resp.SetType("Standalone")
+	}
+	resp.SetUnprotected(len(s.snapshots[id]) == 0 && len(policies) == 0)
+	resp.Maskingview = views
+	resp.SnapshotPolicies = policies
+	resp.HostIOLimit = sg.hostIOLimit
+	resp.SetCompression(sg.compression)
+	if sg.compression {
+		resp.SetCompressionRatio("1.0:1")
+		resp.SetCompressionRatioToOne(1)
+	}
+	resp.SetVpSavedPercent(0)
+	resp.SetUnreducibleDataGb(0)
+	resp.SetUuid(sg.uuid)
+	if len(sg.tags) > 0 {
+		resp.SetTags(strings.Join(sg.tags, ","))
+	}
+	return resp
+}
+
+// listTags lists the tags carried by storage groups. The filters are ignored, the SDK cannot send them.
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	tags := []string{}
+	for _, id := range sortedKeys(s.storageGroups) {
+		for _, tag := range s.storageGroups[id].tags {
+			if !contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	writeJSON(w, http.StatusOK, powermax.TagListResult{TagName: tags})
+}
+
+// getTag answers the storage groups carrying the tag.
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("tag")
+	var infos []powermax.SystemStorageGroupInfo
+	for _, id := range sortedKeys(s.storageGroups) {
+		if contains(s.storageGroups[id].tags, name) {
+			infos = append(infos, *powermax.NewSystemStorageGroupInfo(id, s.SerialNumber))
+		}
+	}
+	if len(infos) == 0 {
+		writeError(w, http.StatusNotFound, "Cannot find Tag %s", name)
+		return
+	}
+	tag := powermax.NewTagResult()
+	tag.SetTagName(name)
+	tag.SetStorageGroupInfos(powermax.StorageGroupInfoList{StorageGroupInfo: infos})
+	tag.ArrayIds = []string{s.SerialNumber}
+	writeJSON(w, http.StatusOK, tag)
+}
+
+// volumesOfStorageGroup returns the sorted IDs of the volumes of the storage group, or of its children.
+func (s *Server) volumesOfStorageGroup(id string) []string {
+	var volumes []string
+	for _, volumeID := range sortedKeys(s.volumes) {
+		if s.volumeInStorageGroup(s.volumes[volumeID], id) {
+			volumes = append(volumes, volumeID)
+		}
+	}
+	return volumes
+}
+
+// volumeInStorageGroup reports whether the volume is in the storage group or in one of its children.
+func (s *Server) volumeInStorageGroup(vol *volume, id string) bool {
+	if contains(vol.storageGroups, id) {
+		return true
+	}
+	if sg, ok := s.storageGroups[id]; ok {
+		for _, childID := range sg.children {
+			if contains(vol.storageGroups, childID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listVolumes answers the volumes matching the storageGroupId and volume_identifier filters.
+// Like Unisphere, a volume_identifier starting with <like> matches the identifiers containing the rest.
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var result []map[string]interface{}
+	for _, volumeID := range sortedKeys(s.volumes) {
+		vol := s.volumes[volumeID]
+		if sgID := query.Get("storageGroupId"); sgID != "" && !s.volumeInStorageGroup(vol, sgID) {
+			continue
+		}
+		if identifier := query.Get("volume_identifier"); identifier != "" && !matchesFilter(vol.identifier, identifier) {
+			continue
+		}
+		result = append(result, map[string]interface{}{"volumeId": volumeID})
+	}
+	iterator := powermax.Iterator{ResultList: powermax.ResultList{Result: result}}
+	iterator.SetId(s.newID(32))
+	iterator.SetCount(int32(len(result)))
+	iterator.SetMaxPageSize(1000)
+	iterator.ResultList.SetFrom(1)
+	iterator.ResultList.SetTo(int32(len(result)))
+	writeJSON(w, http.StatusOK, iterator)
+}
+
+// matchesFilter reports whether value matches the Unisphere filter, an exact value or <like>substring.
+func matchesFilter(value, filter string) bool {
+	if substring, found := strings.CutPrefix(filter, "<like>"); found {
+		return strings.Contains(value, substring)
+	}
+	return value == filter
+}
+
+func (s *Server) editVolume(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	vol := s.findVolume(w, id)
+	var param powermax.EditVolumeParam
+	if vol == nil || !decode(w, r, &param) {
+		return
+	}
+	if param.EditVolumeActionParam == nil {
+		writeError(w, http.StatusBadRequest, "No edit volume action provided")
+		return
+	}
+	action := param.EditVolumeActionParam
+	switch {
+	case action.ModifyVolumeIdentifierParam != nil:
+		vol.identifier = identifierName(action.ModifyVolumeIdentifierParam.VolumeIdentifier)
+	case action.EnableMobilityIdParam != nil:
+		vol.mobilityID = action.EnableMobilityIdParam.EnableMobilityId
+	case action.ExpandVolumeParam != nil:
+		attribute := action.ExpandVolumeParam.VolumeAttribute
+		capacityMB, err := parseCapacity(attribute.VolumeSize, attribute.CapacityUnit)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%s", err.Error())
+			return
+		}
+		if capacityMB < vol.capacityMB {
+			writeError(w, http.StatusBadRequest, "Volume %s cannot be shrunk", id)
+			return
+		}
+		vol.capacityMB = capacityMB
+	case action.FreeVolumeParam != nil:
+	default:
+		writeError(w, http.StatusBadRequest, "The edit volume action is not supported")
+		return
+	}
+	s.respond(w, param.ExecutionOption, "Modify Volume "+id, s.volumeResponse(id))
+}
+
+func (s *Server) deleteVolume(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	vol := s.findVolume(w, id)
+	if vol == nil {
+		return
+	}
+	if len(vol.storageGroups) > 0 {
+		writeError(w, http.StatusConflict, "Volume %s is part of Storage Group %s", id, vol.storageGroups[0])
+		return
+	}
+	delete(s.volumes, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) volumeResponse(id string) *powermax.Volume {
+	vol := s.volumes[id]
+	resp := powermax.NewVolume(id)
+	resp.SetType("TDEV")
+	resp.SetEmulation("FBA")
+	resp.SetSsid("FFFFFFFF")
+	resp.SetAllocatedPercent(0)
+	// Unisphere reports one more MB than the volume size
+	resp.SetCapMb(vol.capacityMB + 1)
+	resp.SetCapGb(vol.capacityMB / 1024)
+	resp.SetCapCyl(int64(math.Ceil(vol.capacityMB / mbPerCylinder)))
+	resp.SetStatus("Ready")
+	resp.SetReserved(false)
+	resp.SetPinned(false)
+	resp.SetPhysicalName("")
+	resp.SetVolumeIdentifier(vol.identifier)
+	resp.SetWwn("60000970000" + s.SerialNumber + "5330" + id)
+	resp.SetEffectiveWwn(resp.GetWwn())
+	resp.SetHasEffectiveWwn(false)
+	resp.SetEncapsulated(false)
+	resp.SetEncapsulatedWwn("")
+	resp.SetNumOfStorageGroups(int32(len(vol.storageGroups)))
+	resp.SetNumOfFrontEndPaths(0)
+	resp.StorageGroupId = vol.storageGroups
+	for _, sgID := range vol.storageGroups {
+		resp.StorageGroups = append(resp.StorageGroups, powermax.StorageGroupConfiguration{StorageGroupName: powermax.PtrString(sgID)})
+	}
+	resp.SetSnapvxSource(s.isSnapshotSource(id))
+	resp.SetSnapvxTarget(false)
+	resp.SetOracleInstanceName("")
+	resp.SetMobilityIdEnabled(vol.mobilityID)
+	resp.SetUnreducibleDataGb(0)
+	resp.SetNguid("")
+	return resp
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ConnectionError describes why the client could not connect to Unisphere.
+type ConnectionError struct {
+	// Attribute is the provider attribute most likely responsible for the failure, if any.
+	Attribute string
+	// Summary and Detail are meant to be reported as a diagnostic.
+	Summary string
+	Detail  string
+	Err     error
+}
+
+// Error returns the summary and detail of the connection error.
+func (e *ConnectionError) Error() string {
+	return e.Summary + ": " + e.Detail
+}
+
+// Unwrap returns the underlying error.
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateConnection checks that Unisphere is reachable with the configured credentials, that it
+// serves the REST API version of the provider and that it manages the configured array.
+// It only reads the version and the array, so it stays fast on arrays with many objects.
+// Failures are returned as a *ConnectionError describing the cause.
+func (c *Client) ValidateConnection(ctx context.Context, requestedVersion string) error {
+	resp, err := c.negotiateVersion(ctx, requestedVersion)
+	if errors.Is(err, ErrUnsupportedVersion) {
+		return &ConnectionError{
+			Attribute: "pmax_version",
+			Summary:   "Unsupported Unisphere version",
+			Detail:    err.Error(),
+			Err:       err,
+		}
+	}
+	if err != nil {
+		return classifyConnectionError(err, resp, "")
+	}
+
+	if c.SymmetrixID == "" {
+		return &ConnectionError{
+			Attribute: "serial_number",
+			Summary:   "Missing serial_number",
+			Detail:    "The serial_number of the PowerMax array must be set in the provider configuration or using the environment variable POWERMAX_SERIAL_NUMBER.",
+		}
+	}
+
+	_, resp, err = c.PmaxOpenapiClient.SystemApi.GetSymm(ctx, c.SymmetrixID).Execute()
+	if err != nil {
+		return classifyConnectionError(err, resp, c.SymmetrixID)
+	}
+	tflog.Info(ctx, "Validated connection to the PowerMax array", map[string]interface{}{
+		"serial_number": c.SymmetrixID,
+	})
+	return nil
+}
+
+// classifyConnectionError maps the error and response of a validation call to a ConnectionError.
+// serialNumber is set when the failing call looked up the array.
+func classifyConnectionError(err error, resp *http.Response, serialNumber string) *ConnectionError {
+	connErr := &ConnectionError{
+		Attribute: "endpoint",
+		Summary:   "Unable to connect to the PowerMax host",
+		Detail:    err.Error(),
+		Err:       err,
+	}
+
+	if resp != nil && resp.StatusCode >= 300 {
+		status := resp.StatusCode
+		switch {
+		case status == http.StatusUnauthorized:
+			connErr.Attribute = "username"
+			connErr.Summary = "Authentication failed"
+			connErr.Detail = "Unisphere rejected the credentials, please validate that the username and password are correct."
+		case status == http.StatusForbidden:
+			connErr.Attribute = "username"
+			connErr.Summary = "Permission denied"
+			connErr.Detail = "The user is not authorized to use the Unisphere REST API, please validate the roles of the user."
+		case status == http.StatusNotFound && serialNumber != "":
+			connErr.Attribute = "serial_number"
+			connErr.Summary = "PowerMax array not found"
+			connErr.Detail = fmt.Sprintf("The array %s is not managed by this Unisphere, please validate that the serial_number is correct.", serialNumber)
+		case status == http.StatusNotFound:
+			connErr.Detail = "The Unisphere REST API was not found, please validate that the endpoint is the Unisphere for PowerMax URL, IE: (https://x.x.x.x:8443)."
+		default:
+			connErr.Summary = "Unexpected response from the PowerMax host"
+			connErr.Detail = fmt.Sprintf("Unisphere answered with HTTP status %d: %s", status, err.Error())
+		}
+		return connErr
+	}
+
+	var dnsErr *net.DNSError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidCert x509.CertificateInvalidError
+	var verificationErr *tls.CertificateVerificationError
+	var recordHeaderErr tls.RecordHeaderError
+	var opErr *net.OpError
+	var netErr net.Error
+	var urlErr *url.Error
+
+	switch {
+	case errors.As(err, &dnsErr):
+		connErr.Summary = "Unable to resolve the PowerMax host"
+		connErr.Detail = fmt.Sprintf("The host %s could not be resolved, please validate the endpoint: %s", dnsErr.Name, err.Error())
+	case errors.Is(err, errFingerprintMismatch):
+		connErr.Attribute = "certificate_fingerprint"
+		connErr.Summary = "TLS certificate verification failed"
+		connErr.Detail = err.Error()
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &invalidCert), errors.As(err, &verificationErr):
+		connErr.Attribute = "ca_certificate"
+		connErr.Summary = "TLS certificate verification failed"
+		connErr.Detail = "The certificate of the PowerMax host could not be verified, please configure ca_certificate or certificate_fingerprint, or set insecure to true for lab environments: " + err.Error()
+	case errors.As(err, &recordHeaderErr):
+		connErr.Summary = "TLS handshake failed"
+		connErr.Detail = "The PowerMax host did not answer with TLS, please validate that the endpoint uses https and the Unisphere port: " + err.Error()
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		connErr.Summary = "Timeout connecting to the PowerMax host"
+		connErr.Detail = "The PowerMax host did not answer in time, please validate the endpoint and the timeout: " + err.Error()
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		connErr.Summary = "Unable to reach the PowerMax host"
+		connErr.Detail = "The connection to the PowerMax host failed, please validate the endpoint and the proxy settings: " + err.Error()
+	case errors.As(err, &urlErr) && strings.Contains(urlErr.Err.Error(), "unsupported protocol scheme"):
+		connErr.Summary = "Invalid PowerMax endpoint"
+		connErr.Detail = "Please validate that the endpoint is formatted as https://x.x.x.x:8443: " + err.Error()
+	}
+	return connErr
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validateAgainst(t *testing.T, endpoint, serialNumber string, opts ClientOptions) *ConnectionError {
+	opts.Retry.MaxRetries = 0
+	client, err := NewClient(context.Background(), endpoint, "admin", "secret", serialNumber, "", false, opts)
+	assert.NoError(t, err)
+	err = client.ValidateConnection(context.Background(), "")
+	if err == nil {
+		return nil
+	}
+	var connErr *ConnectionError
+	assert.True(t, errors.As(err, &connErr))
+	return connErr
+}
+
+func newValidationServer(t *testing.T, status int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/univmax/restapi/version":
+			_, _ = w.Write([]byte(`{"version":"V10.0.0.1","api_version":"100","supported_api_versions":["100"]}`))
+		case "/univmax/restapi/100/system/symmetrix/000000000001":
+			_, _ = w.Write([]byte(`{"symmetrixId":"000000000001"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidateConnection(t *testing.T) {
+	server := newValidationServer(t, http.StatusOK)
+	assert.Nil(t, validateAgainst(t, server.URL, "000000000001", ClientOptions{}))
+
+	connErr := validateAgainst(t, server.URL, "000000000002", ClientOptions{})
+	assert.Equal(t, "serial_number", connErr.Attribute)
+	assert.Equal(t, "PowerMax array not found", connErr.Summary)
+
+	connErr = validateAgainst(t, server.URL, "", ClientOptions{})
+	assert.Equal(t, "serial_number", connErr.Attribute)
+}
+
+func TestValidateConnectionUnauthorized(t *testing.T) {
+	server := newValidationServer(t, http.StatusUnauthorized)
+	connErr := validateAgainst(t, server.URL, "000000000001", ClientOptions{})
+	assert.Equal(t, "username", connErr.Attribute)
+	assert.Equal(t, "Authentication failed", connErr.Summary)
+}
+
+func TestValidateConnectionUntrustedCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	connErr := validateAgainst(t, server.URL, "000000000001", ClientOptions{})
+	assert.Equal(t, "ca_certificate", connErr.Attribute)
+	assert.Equal(t, "TLS certificate verification failed", connErr.Summary)
+}
+
+func TestValidateConnectionUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := server.URL
+	server.Close()
+
+	connErr := validateAgainst(t, endpoint, "000000000001", ClientOptions{})
+	assert.Equal(t, "endpoint", connErr.Attribute)
+	assert.Equal(t, "Unable to reach the PowerMax host", connErr.Summary)
+
+	connErr = validateAgainst(t, "https://unisphere.invalid:8443", "000000000001", ClientOptions{})
+	assert.Equal(t, "endpoint", connErr.Attribute)
+	assert.Equal(t, "Unable to resolve the PowerMax host", connErr.Summary)
+}
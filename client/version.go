@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// SupportedAPIVersion is the Unisphere REST API version used by the powermax-go-client SDK.
+const SupportedAPIVersion = "100"
+
+// MinimumUnisphereMajorVersion is the oldest Unisphere for PowerMax release supported by the provider.
+const MinimumUnisphereMajorVersion = 10
+
+// ErrUnsupportedVersion is returned when the Unisphere version does not support the provider.
+var ErrUnsupportedVersion = errors.New("unsupported Unisphere version")
+
+// UnisphereVersion describes the Unisphere installation detected during the version negotiation.
+type UnisphereVersion struct {
+	// Version is the Unisphere release, IE: V10.0.0.1
+	Version string
+	// APIVersion is the REST API version used by the provider, IE: 100
+	APIVersion string
+	// SupportedAPIVersions lists the REST API versions served by Unisphere.
+	SupportedAPIVersions []string
+}
+
+// NegotiateVersion reads the Unisphere version and checks that it serves the REST API version
+// used by the provider. requestedVersion is the pmax_version configured by the user, if any.
+// The detected version is stored on the client.
+func (c *Client) NegotiateVersion(ctx context.Context, requestedVersion string) error {
+	_, err := c.negotiateVersion(ctx, requestedVersion)
+	return err
+}
+
+// negotiateVersion implements NegotiateVersion and also returns the response of the version call.
+func (c *Client) negotiateVersion(ctx context.Context, requestedVersion string) (*http.Response, error) {
+	version, resp, err := c.PmaxOpenapiClient.VersionApi.GetVersion(ctx).Execute()
+	if err != nil {
+		return resp, err
+	}
+
+	detected := UnisphereVersion{
+		Version:              version.GetVersion(),
+		APIVersion:           SupportedAPIVersion,
+		SupportedAPIVersions: version.GetSupportedApiVersions(),
+	}
+	tflog.Info(ctx, "Detected Unisphere version", map[string]interface{}{
+		"version":                detected.Version,
+		"api_version":            version.GetApiVersion(),
+		"supported_api_versions": detected.SupportedAPIVersions,
+	})
+
+	if requestedVersion != "" && !IsLegacyPmaxVersion(requestedVersion) && strings.TrimPrefix(requestedVersion, "V") != SupportedAPIVersion {
+		return resp, fmt.Errorf("%w: pmax_version %s is not supported by the provider, the supported REST API version is %s", ErrUnsupportedVersion, requestedVersion, SupportedAPIVersion)
+	}
+
+	if major, _, ok := parseUnisphereVersion(detected.Version); ok && major < MinimumUnisphereMajorVersion {
+		return resp, fmt.Errorf("%w: Unisphere %s is not supported, Unisphere for PowerMax %d.0 or later is required", ErrUnsupportedVersion, detected.Version, MinimumUnisphereMajorVersion)
+	}
+
+	if len(detected.SupportedAPIVersions) > 0 && !containsString(detected.SupportedAPIVersions, SupportedAPIVersion) {
+		return resp, fmt.Errorf("%w: Unisphere %s does not serve the REST API version %s used by the provider, supported versions are %s", ErrUnsupportedVersion, detected.Version, SupportedAPIVersion, strings.Join(detected.SupportedAPIVersions, ", "))
+	}
+
+	c.Version = &detected
+	return resp, nil
+}
+
+// IsLegacyPmaxVersion reports whether pmax_version holds a PowerMax host version, like 10 or 10.1, as
+// documented before it became the Unisphere REST API version. Such values are not checked.
+func IsLegacyPmaxVersion(version string) bool {
+	if _, _, ok := parseUnisphereVersion(version); ok {
+		return true
+	}
+	major, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(version), "V"))
+	// The REST API versions are 90 and later
+	return err == nil && major > 0 && major < 90
+}
+
+// AtLeastUnisphereVersion reports whether the detected Unisphere version is at least major.minor.
+// It returns false when the version has not been negotiated.
+func (c *Client) AtLeastUnisphereVersion(major, minor int) bool {
+	if c.Version == nil {
+		return false
+	}
+	detectedMajor, detectedMinor, ok := parseUnisphereVersion(c.Version.Version)
+	if !ok {
+		return false
+	}
+	return detectedMajor > major || (detectedMajor == major && detectedMinor >= minor)
+}
+
+// parseUnisphereVersion returns the major and minor numbers of a Unisphere version like V10.1.0.2.
+func parseUnisphereVersion(version string) (int, int, bool) {
+	parts := strings.Split(strings.TrimPrefix(strings.ToUpper(version), "V"), ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// containsString reports whether the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
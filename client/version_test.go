@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newVersionClient(t *testing.T, body string) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/univmax/restapi/version", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", true, ClientOptions{})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	client := newVersionClient(t, `{"version":"V10.1.0.2","api_version":"101","supported_api_versions":["101","100","92"]}`)
+
+	assert.NoError(t, client.NegotiateVersion(context.Background(), "100"))
+	assert.Equal(t, "V10.1.0.2", client.Version.Version)
+	assert.Equal(t, SupportedAPIVersion, client.Version.APIVersion)
+	assert.True(t, client.AtLeastUnisphereVersion(10, 1))
+	assert.False(t, client.AtLeastUnisphereVersion(10, 2))
+}
+
+func TestNegotiateVersionMismatch(t *testing.T) {
+	client := newVersionClient(t, `{"version":"V10.1.0.2","api_version":"101","supported_api_versions":["101","100"]}`)
+	assert.ErrorIs(t, client.NegotiateVersion(context.Background(), "92"), ErrUnsupportedVersion)
+	assert.ErrorIs(t, client.NegotiateVersion(context.Background(), "V101"), ErrUnsupportedVersion)
+
+	client = newVersionClient(t, `{"version":"V9.2.1.4","api_version":"92","supported_api_versions":["92","91"]}`)
+	assert.ErrorIs(t, client.NegotiateVersion(context.Background(), ""), ErrUnsupportedVersion)
+	assert.Nil(t, client.Version)
+	assert.False(t, client.AtLeastUnisphereVersion(9, 0))
+}
+
+func TestNegotiateVersionLegacy(t *testing.T) {
+	client := newVersionClient(t, `{"version":"V10.1.0.2","api_version":"101","supported_api_versions":["101","100"]}`)
+	for _, version := range []string{"10.1", "V10.1.0.2", "10"} {
+		assert.True(t, IsLegacyPmaxVersion(version), version)
+		assert.NoError(t, client.NegotiateVersion(context.Background(), version), version)
+	}
+	for _, version := range []string{"100", "V100", "92", "latest"} {
+		assert.False(t, IsLegacyPmaxVersion(version), version)
+	}
+}
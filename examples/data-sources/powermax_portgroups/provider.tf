@@ -38,6 +38,6 @@ provider "powermax" {
   # POWERMAX_PASSWORD="password"
   # POWERMAX_ENDPOINT="https://yourhost.host.com:8443"
   # POWERMAX_SERIAL_NUMBER="xxxxxxxxxxxx"
-  # POWERMAX_POWERMAX_VERSION="100"
+  # POWERMAX_VERSION="100"
   # POWERMAX_INSECURE="false"
 }
\ No newline at end of file
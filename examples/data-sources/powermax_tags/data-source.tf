@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# This terraform DataSource is used to query the existing tags from PowerMax array.
+# The information fetched from this data source can be used for getting the details / for further processing in resource block.
+
+# Returns all of the tags carried by the storage groups of the PowerMax array and the storage groups carrying them
+data "powermax_tags" "all" {
+  # Optional Update the read timeout with (XXm) for minutes or (XXs) for timeout in seconds
+  # If unset defaults to 2 minute timeout
+  # timeouts = {
+  #   read = "3m"
+  # }
+}
+
+output "tags_all" {
+  value = data.powermax_tags.all
+}
+
+# Returns the tags of the `names` filter block and the storage groups carrying them
+data "powermax_tags" "application" {
+  # Optional list of names to filter upon
+  filter {
+    names = ["app_erp"]
+  }
+}
+
+output "application_storage_groups" {
+  value = data.powermax_tags.application.tags[0].storage_groups
+}
+
+# After the successful execution of above said block, We can see the output value by executing 'terraform output' command.
+# Also, we can use the fetched information by the variable data.powermax_tags.example
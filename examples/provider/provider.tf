@@ -20,7 +20,10 @@ variable "username" {
 }
 
 variable "password" {
-  type = string
+  type      = string
+  sensitive = true
+  # With Terraform 1.10 and later, an ephemeral variable is never written to the plan or the state
+  # ephemeral = true
 }
 
 variable "endpoint" {
@@ -43,13 +46,35 @@ provider "powermax" {
   pmax_version  = var.pmax_version
   insecure      = true
 
+  ## Instead of password, the password can be read from a file or got from a command
+  ## when the provider is configured
+  # password_file      = "/run/secrets/powermax_password"
+  # credential_process = "vault kv get -field=password secret/powermax"
+
   ## Provider can also be set using environment variables
   ## If environment variables are set it will override this configuration
   ## Example environment variables
   # POWERMAX_USERNAME="username"
   # POWERMAX_PASSWORD="password"
+  # POWERMAX_PASSWORD_FILE="/run/secrets/powermax_password"
+  # POWERMAX_CREDENTIAL_PROCESS="vault kv get -field=password secret/powermax"
   # POWERMAX_ENDPOINT="https://yourhost.host.com:8443"
   # POWERMAX_SERIAL_NUMBER="xxxxxxxxxxxx"
-  # POWERMAX_POWERMAX_VERSION="100"
+  # POWERMAX_VERSION="100"
   # POWERMAX_INSECURE="false"
+  # POWERMAX_CA_CERTIFICATE="/path/to/ca.pem"
+  # POWERMAX_CERTIFICATE_FINGERPRINT="AB:CD:..."
+  # POWERMAX_CLIENT_CERTIFICATE="/path/to/client.pem"
+  # POWERMAX_CLIENT_KEY="/path/to/client.key"
+  # POWERMAX_PROXY_URL="http://proxy.example.com:3128"
+  # POWERMAX_NO_PROXY="localhost,127.0.0.1"
+  # POWERMAX_MAX_IDLE_CONNS="10"
+  # POWERMAX_KEEPALIVE="30"
+  # POWERMAX_MAX_CONCURRENT_REQUESTS="8"
+  # POWERMAX_TIMEOUT="60"
+  # POWERMAX_MAX_RETRIES="3"
+  # POWERMAX_RETRY_MIN_WAIT="1"
+  # POWERMAX_RETRY_MAX_WAIT="30"
+  # POWERMAX_TRACE_REQUESTS="false"
+  # POWERMAX_CACHE_TTL="0"
 }
\ No newline at end of file
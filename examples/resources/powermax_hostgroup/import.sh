@@ -17,4 +17,6 @@
 # terraform import powermax_hostgroup.test_host_group <id>
 # Example:
 terraform import powermax_hostgroup.test_host_group host_group
+# To import it from another array than the one of the provider, prefix the id with the serial number of the array:
+terraform import powermax_hostgroup.test_host_group 000000000001:host_group
 # after running this command, populate the name field in the config file to start managing this resource
@@ -17,4 +17,6 @@
 # terraform import powermax_portgroup.portgroup_1 <id>
 # Example:
 terraform import powermax_portgroup.portgroup_1 tfacc_pg_test_1
+# To import it from another array than the one of the provider, prefix the id with the serial number of the array:
+terraform import powermax_portgroup.portgroup_1 000000000001:tfacc_pg_test_1
 # after running this command, populate the name field in the config file to start managing this resource
@@ -17,4 +17,6 @@
 # terraform import powermax_snapshot.snapshot_test storage_group.snapshot_name
 # Example: must be storage_group.snapshot_name
 terraform import powermax_snapshot.snapshot_test storage_group.snapshot_name
+# To import it from another array than the one of the provider, prefix the id with the serial number of the array:
+terraform import powermax_snapshot.snapshot_test 000000000001:storage_group.snapshot_name
 # after running this command, populate the name field in the config file to start managing this resource
\ No newline at end of file
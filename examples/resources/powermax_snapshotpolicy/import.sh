@@ -13,4 +13,6 @@
 # terraform import powermax_storagegroup.test <id>
 # Example:
 terraform import powermax_snapshotpolicy.terraform_sp terraform_sp
+# To import it from another array than the one of the provider, prefix the id with the serial number of the array:
+terraform import powermax_snapshotpolicy.terraform_sp 000000000001:terraform_sp
 # after running this command, populate the name field in the config file to start managing this resource
@@ -17,4 +17,6 @@
 # terraform import powermax_storagegroup.test <id>
 # Example:
 terraform import powermax_storagegroup.test terraform_sg
+# To import it from another array than the one of the provider, prefix the id with the serial number of the array:
+terraform import powermax_storagegroup.test 000000000001:terraform_sg
 # after running this command, populate the name field in the config file to start managing this resource
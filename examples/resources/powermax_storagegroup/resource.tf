@@ -15,14 +15,14 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-# Available actions: Create, Update (name, compression, host_io_limit, workload, slo, srp_id, volume_ids), Delete and Import an existing storage group from the PowerMax Array.
+# Available actions: Create, Update (name, compression, host_io_limit, workload, slo, srp_id, volume_ids, child_storage_groups, volume_sets, tags), Delete and Import an existing storage group from the PowerMax Array.
 # After `terraform apply` of this example file it will create a new storage group with the name set in `name` attribute on the PowerMax
 
 # PowerMax storage groups are a collection of devices that are stored on the array.
 # An application, a server, or a collection of servers use them.
 resource "powermax_storagegroup" "test" {
 
-  # Attributes which are able to be modified after create (name, compression, host_io_limit, workload, slo, srp_id, volume_ids)
+  # Attributes which are able to be modified after create (name, compression, host_io_limit, workload, slo, srp_id, volume_ids, child_storage_groups, volume_sets, tags)
 
   # Required the name of the new storage group
   name = "terraform_sg"
@@ -51,6 +51,42 @@ resource "powermax_storagegroup" "test" {
 
   # Optional a list of volume ids to be added to the storage groups
   volume_ids = ["0008F"]
+
+  # Optional the tags of the storage group, e.g. its application and cost center
+  tags = ["app_erp", "cc_100"]
+}
+
+# A parent storage group cascading existing storage groups, it holds the volumes of its children and has none of its own
+resource "powermax_storagegroup" "parent" {
+  name   = "terraform_parent_sg"
+  srp_id = "SRP_1"
+  slo    = "Gold"
+
+  # Optional the names of the existing storage groups to cascade under the storage group
+  child_storage_groups = [powermax_storagegroup.test.name]
+}
+
+# A storage group creating its own volumes, increasing the count of a set creates the missing volumes
+resource "powermax_storagegroup" "volume_sets" {
+  name   = "terraform_volume_sets_sg"
+  srp_id = "SRP_1"
+  slo    = "Gold"
+
+  # Optional sets of new volumes, their IDs are tracked in volume_sets.*.volume_ids
+  volume_sets = [
+    {
+      # Unisphere appends the number of the volume in the set to the prefix
+      identifier_prefix = "terraform_data_"
+      count             = 4
+      size              = 10
+      # Optional the capacity unit of the size (Default to GB)
+      cap_unit = "GB"
+      # Optional the emulation of the volumes (Default to FBA)
+      emulation = "FBA"
+      # Optional keep the volumes removed from the set or with the storage group instead of deleting them (Default to false)
+      keep_volumes = false
+    }
+  ]
 }
 
 # After the execution of above resource block, a PowerMax storage group has been created at PowerMax array.
@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create of a merge of two storage groups on the PowerMax Array.
+# After `terraform apply` of this example file the volumes of the storage group `merged_storage_group_id` are moved
+# to the storage group `storage_group_id`, then `merged_storage_group_id` and its masking view are deleted.
+# NOTE: both storage groups must be masked to the same host and port group, and the merge is not undone when the resource is destroyed.
+
+# Merging storage groups consolidates the volumes masked to a host, for example after a split is no longer needed.
+resource "powermax_storagegroup_merge" "tier2" {
+
+  # Required, the name of the masked storage group receiving the volumes
+  storage_group_id = "terraform_sg"
+
+  # Required, the name of the masked standalone storage group merged into storage_group_id
+  merged_storage_group_id = "terraform_sg_tier2"
+}
+
+# When storage_group_id is managed by a powermax_storagegroup resource, list the merged volumes in its volume_ids,
+# for example volume_ids = sort(concat(["0008E"], tolist(powermax_storagegroup_merge.tier2.volume_ids)))
+
+# When the merged storage group and its masking view were managed by Terraform, stop managing them without destroying them
+removed {
+  from = powermax_storagegroup.tier2
+  lifecycle {
+    destroy = false
+  }
+}
+
+removed {
+  from = powermax_maskingview.tier2
+  lifecycle {
+    destroy = false
+  }
+}
+
+# After the execution of above resource block, the storage groups have been merged at PowerMax array.
+# For more information about the newly created resource use the `terraform show` command to review the current state
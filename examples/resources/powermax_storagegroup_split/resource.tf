@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2023 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+# Available actions: Create, Update (merge_on_destroy) and Delete of a split of a storage group on the PowerMax Array.
+# After `terraform apply` of this example file the volumes in `volume_ids` are moved from the storage group `storage_group_id`
+# to the new storage group `new_storage_group_id`, which is masked to the same host and port group by the new masking view `masking_view_id`.
+
+# Splitting a storage group lets a subset of its volumes be managed, protected or tiered on its own without unmasking them from the host.
+resource "powermax_storagegroup_split" "tier2" {
+
+  # Required, the name of the masked storage group to split
+  storage_group_id = "terraform_sg"
+
+  # Required, the name of the storage group resulting from the split
+  # When volume_ids is not set, it must be a child storage group of storage_group_id, which is uncascaded from it
+  new_storage_group_id = "terraform_sg_tier2"
+
+  # Required, the name of the masking view of the new storage group
+  masking_view_id = "terraform_sg_tier2_mv"
+
+  # Optional, the IDs of the volumes moved to the new storage group
+  volume_ids = ["0008F", "00090"]
+
+  # Optional, merge the new storage group back into storage_group_id when this resource is destroyed
+  # This deletes the new storage group and its masking view. Requires volume_ids
+  merge_on_destroy = false
+}
+
+# Once the split is applied, the new storage group and masking view can be managed by Terraform by importing them
+# import {
+#   to = powermax_storagegroup.tier2
+#   id = "terraform_sg_tier2"
+# }
+#
+# import {
+#   to = powermax_maskingview.tier2
+#   id = "terraform_sg_tier2_mv"
+# }
+
+# After the execution of above resource block, the storage group has been split at PowerMax array.
+# For more information about the newly created resource use the `terraform show` command to review the current state
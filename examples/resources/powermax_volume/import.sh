@@ -17,4 +17,6 @@
 # terraform import powermax_volume.test <id>
 # Example:
 terraform import powermax_volume.test terraform_volume
+# To import it from another array than the one of the provider, prefix the id with the serial number of the array:
+terraform import powermax_volume.test 000000000001:terraform_volume
 # after running this command, populate the name field in the config file to start managing this resource
@@ -21,7 +21,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
+	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -39,6 +41,9 @@ var (
 	// https://goreleaser.com/cookbooks/using-main.version/
 )
 
+// closeSessionsTimeout bounds the logout of the Unisphere sessions when the provider exits.
+const closeSessionsTimeout = 2 * time.Second
+
 func main() {
 	var debug bool
 
@@ -52,6 +57,11 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Terraform has stopped the provider, end the Unisphere sessions without holding its exit for long
+	ctx, cancel := context.WithTimeout(context.Background(), closeSessionsTimeout)
+	client.CloseSessions(ctx)
+	cancel()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}
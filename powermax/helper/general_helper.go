@@ -23,9 +23,103 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Default timeouts of the resource operations, used when the timeouts attribute does not set them.
+const (
+	DefaultCreateTimeout = 30 * time.Minute
+	DefaultReadTimeout   = 10 * time.Minute
+	DefaultUpdateTimeout = 30 * time.Minute
+	DefaultDeleteTimeout = 30 * time.Minute
+)
+
+// serialNumberDescription documents the serial_number attribute of resources and data sources.
+const serialNumberDescription = "The serial number of the PowerMax array managed through the Unisphere of the provider. Defaults to the serial_number of the provider."
+
+// SerialNumberResourceAttribute returns the schema of the serial_number attribute of resources.
+// Changing the array of a resource replaces it.
+func SerialNumberResourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         serialNumberDescription,
+		MarkdownDescription: serialNumberDescription,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// SerialNumberDataSourceAttribute returns the schema of the serial_number attribute of data sources.
+func SerialNumberDataSourceAttribute() datasourceschema.StringAttribute {
+	return datasourceschema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Description:         serialNumberDescription,
+		MarkdownDescription: serialNumberDescription,
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// unknownIfStringChanged is a list plan modifier marking the planned value unknown when a string
+// attribute of the resource changes.
+type unknownIfStringChanged struct {
+	attribute path.Path
+}
+
+// UnknownIfStringChanged returns a plan modifier marking a computed list unknown when the string
+// attribute changes, for the lists whose state is otherwise kept by UseStateForUnknown.
+func UnknownIfStringChanged(attribute path.Path) planmodifier.List {
+	return unknownIfStringChanged{attribute: attribute}
+}
+
+func (m unknownIfStringChanged) Description(_ context.Context) string {
+	return "The value is unknown when " + m.attribute.String() + " changes."
+}
+
+func (m unknownIfStringChanged) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m unknownIfStringChanged) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	var planValue, stateValue types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.attribute, &planValue)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.attribute, &stateValue)...)
+	if !resp.Diagnostics.HasError() && !planValue.Equal(stateValue) {
+		resp.PlanValue = types.ListUnknown(req.PlanValue.ElementType(ctx))
+	}
+}
+
+// SplitImportID splits an import ID of the form "<serial_number>:<id>". The returned serial number
+// is empty when the ID has no prefix, in which case the serial_number of the provider is used.
+func SplitImportID(importID string) (string, string) {
+	serialNumber, id, found := strings.Cut(importID, ":")
+	if !found {
+		return "", importID
+	}
+	return serialNumber, id
+}
+
 // IsParamUpdated General Reusable Functions.
 func IsParamUpdated(updatedParams []string, paramName string) bool {
 	isParamUpdate := false
@@ -81,3 +175,33 @@ func SetupTimeoutReadDatasource(ctx context.Context, resp *datasource.ReadRespon
 
 	return context.WithTimeout(ctx, readTimeout)
 }
+
+// TimeoutsResourceAttribute returns the schema of the timeouts attribute of resources.
+func TimeoutsResourceAttribute(ctx context.Context) schema.Attribute {
+	return resourcetimeouts.Attributes(ctx, resourcetimeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "Timeout of the creation, 30m by default. A string that can be parsed as a duration, e.g. \"30s\" or \"2h45m\".",
+		ReadDescription:   "Timeout of the refresh, 10m by default. A string that can be parsed as a duration, e.g. \"30s\" or \"2h45m\".",
+		UpdateDescription: "Timeout of the update, 30m by default. A string that can be parsed as a duration, e.g. \"30s\" or \"2h45m\".",
+		DeleteDescription: "Timeout of the deletion, 30m by default. A string that can be parsed as a duration, e.g. \"30s\" or \"2h45m\".",
+	})
+}
+
+// NullTimeouts returns an unset timeouts attribute, for the states which are not built from a
+// plan like the imported ones.
+func NullTimeouts(ctx context.Context) resourcetimeouts.Value {
+	timeoutsType := TimeoutsResourceAttribute(ctx).GetType().(resourcetimeouts.Type)
+	return resourcetimeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)}
+}
+
+// SetupTimeoutResource returns a context bounded by the timeout of a resource operation. timeout is
+// the Create, Read, Update or Delete method of the timeouts attribute, called with defaultTimeout.
+func SetupTimeoutResource(ctx context.Context, diags *diag.Diagnostics, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics),
+	defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+	operationTimeout, errs := timeout(ctx, defaultTimeout)
+	diags.Append(errs...)
+	return context.WithTimeout(ctx, operationTimeout)
+}
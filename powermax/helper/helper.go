@@ -19,12 +19,11 @@ package helper
 
 import (
 	"context"
-	pmax "dell/powermax-go-client"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"reflect"
 	"strings"
+	"terraform-provider-powermax/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -167,38 +166,17 @@ func copySliceToTargetField(ctx context.Context, fields interface{}) attr.Value
 	return nil
 }
 
-// ParseBody parses json body to extract error message.
-func ParseBody(body []byte) (string, error) {
-	var parsedData map[string]string
-	err := json.Unmarshal(body, &parsedData)
-	if err != nil {
-		return "", err
-	}
-	message, ok := parsedData["message"]
-	if !ok {
-		return "", fmt.Errorf("no message field found in body")
-	}
-	return message, nil
-}
-
-// GetErrorString extracts error message and returns combined message with errStr.
+// GetErrorString returns errStr followed by the decoded Unisphere error message.
+// Prefer client.ErrorDiagnostic when the message is reported as a diagnostic.
 func GetErrorString(err error, errStr string) string {
-	err1, ok := err.(*pmax.GenericOpenAPIError)
-	message := ""
-	msgStr := ""
-	if ok {
-		message, err := ParseBody(err1.Body())
-
-		// Handle case where we can pasrse the openapi error from the response
-		if err != nil {
-			message = ""
-		}
-		errStr = errStr + message
+	if err == nil {
+		return errStr
 	}
-	if message == "" {
-		msgStr = errStr + " " + err.Error()
+	message := client.ParseAPIError(err).Error()
+	if errStr = strings.TrimSpace(errStr); errStr == "" {
+		return message
 	}
-	return msgStr
+	return errStr + " " + message
 }
 
 // StringInSlice checks if string is present in the list.
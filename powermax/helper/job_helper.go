@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"bytes"
+	"context"
+	"dell/powermax-go-client"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-powermax/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// AsynchronousExecution is the executionOption asking Unisphere to run a request as a job.
+const AsynchronousExecution = "ASYNCHRONOUS"
+
+// Job statuses reported by Unisphere.
+const (
+	JobStatusSucceeded      = "SUCCEEDED"
+	JobStatusFailed         = "FAILED"
+	JobStatusAborted        = "ABORTED"
+	JobStatusValidateFailed = "VALIDATE_FAILED"
+	JobStatusInvalid        = "INVALID"
+)
+
+// DefaultJobTimeout is how long a job is waited for when the context has no deadline.
+const DefaultJobTimeout = 30 * time.Minute
+
+// JobPollInterval is the delay between two polls of a running job.
+var JobPollInterval = 5 * time.Second
+
+// ErrJobFailed is returned when a Unisphere job does not succeed.
+var ErrJobFailed = errors.New("unisphere job failed")
+
+// ExecuteJob submits an asynchronous request mutating the storage groups storageGroupIDs and waits for the
+// job it started, holding the locks of the storage groups until the job completes. The storage groups are
+// locked in name order so that two jobs sharing storage groups cannot deadlock. execute must send the
+// request with the given context and return its http response. Requests which Unisphere completed
+// synchronously are returned as they are.
+func ExecuteJob(ctx context.Context, pmaxClient client.Client, storageGroupIDs []string, name string, execute func(ctx context.Context) (*http.Response, error)) error {
+	ids := append([]string(nil), storageGroupIDs...)
+	sort.Strings(ids)
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		var unlock func()
+		var err error
+		ctx, unlock, err = pmaxClient.LockObject(ctx, client.StorageGroupObject, id)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	resp, err := execute(ctx)
+	jobID := JobIDFromResponse(resp)
+	if jobID == "" {
+		return err
+	}
+	// The SDK fails to decode the job into the model of the synchronous answer, the job is what matters.
+	tflog.Info(ctx, "Submitted Unisphere job", map[string]interface{}{
+		"job_id": jobID,
+		"name":   name,
+	})
+	_, err = WaitForJob(ctx, pmaxClient, jobID)
+	return err
+}
+
+// JobIDFromResponse returns the ID of the job started by an asynchronous request, or "" when the
+// request did not start a job.
+func JobIDFromResponse(resp *http.Response) string {
+	if resp == nil || resp.Body == nil || resp.StatusCode >= http.StatusMultipleChoices {
+		return ""
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	var job struct {
+		JobID string `json:"jobId"`
+	}
+	if json.Unmarshal(body, &job) != nil {
+		return ""
+	}
+	return job.JobID
+}
+
+// WaitForJob polls a Unisphere job until it completes, fails or the context is done.
+// When the context has no deadline, the job is waited for DefaultJobTimeout at most.
+// The reads of the array are not cached while the job runs, and the cached ones are dropped when it ends.
+func WaitForJob(ctx context.Context, pmaxClient client.Client, jobID string) (*powermax.Job, error) {
+	defer pmaxClient.TrackJob()()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultJobTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(JobPollInterval)
+	defer ticker.Stop()
+	lastStatus := ""
+	for {
+		job, _, err := pmaxClient.PmaxOpenapiClient.SystemApi.GetJob(ctx, jobID).Execute()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("timeout waiting for job %s, the job keeps running on the PowerMax array: %w", jobID, ctx.Err())
+			}
+			return nil, err
+		}
+
+		switch job.Status {
+		case JobStatusSucceeded:
+			tflog.Info(ctx, "Unisphere job succeeded", map[string]interface{}{
+				"job_id": jobID,
+				"name":   job.GetName(),
+			})
+			return job, nil
+		case JobStatusFailed, JobStatusAborted, JobStatusValidateFailed, JobStatusInvalid:
+			return job, &client.APIError{
+				Message:  fmt.Sprintf("job %s: %s", strings.ToLower(job.Status), job.GetResult()),
+				JobID:    jobID,
+				Category: client.CategoryUnknown,
+				Err:      ErrJobFailed,
+			}
+		}
+
+		if job.Status != lastStatus {
+			lastStatus = job.Status
+			tflog.Info(ctx, "Waiting for Unisphere job", map[string]interface{}{
+				"job_id": jobID,
+				"name":   job.GetName(),
+				"status": job.Status,
+				"tasks":  len(job.Task),
+			})
+		} else {
+			tflog.Debug(ctx, "Unisphere job still running", map[string]interface{}{
+				"job_id": jobID,
+				"status": job.Status,
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, fmt.Errorf("timeout waiting for job %s, the job keeps running on the PowerMax array: %w", jobID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
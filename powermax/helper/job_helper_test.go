@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"terraform-provider-powermax/client"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newJobServer starts a Unisphere answering the polls of job 1234 with statuses, the last one repeating.
+func newJobServer(t *testing.T, statuses ...string) (*client.Client, *atomic.Int32) {
+	polls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/system/job/1234") {
+			http.NotFound(w, r)
+			return
+		}
+		status := statuses[min(int(polls.Add(1)), len(statuses))-1]
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jobId":"1234","name":"Modify Storage Group","status":"%s","username":"admin","last_modified_date":"now","result":"Storage group sg1 is busy"}`, status)
+	}))
+	t.Cleanup(server.Close)
+
+	pmaxClient, err := client.NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", false, client.ClientOptions{})
+	assert.NoError(t, err)
+
+	interval := JobPollInterval
+	JobPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { JobPollInterval = interval })
+	return pmaxClient, polls
+}
+
+func jobResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestExecuteJobSynchronous(t *testing.T) {
+	pmaxClient, polls := newJobServer(t, JobStatusSucceeded)
+
+	err := ExecuteJob(context.Background(), *pmaxClient, []string{"sg1"}, "sync", func(context.Context) (*http.Response, error) {
+		return jobResponse(http.StatusOK, `{"storageGroupId":"sg1"}`), nil
+	})
+	assert.NoError(t, err)
+
+	submitErr := errors.New("rejected")
+	err = ExecuteJob(context.Background(), *pmaxClient, []string{"sg1"}, "sync", func(context.Context) (*http.Response, error) {
+		return jobResponse(http.StatusBadRequest, `{"message":"rejected","jobId":"1234"}`), submitErr
+	})
+	assert.Same(t, submitErr, err)
+	assert.Equal(t, int32(0), polls.Load())
+}
+
+func TestExecuteJobSucceeds(t *testing.T) {
+	pmaxClient, polls := newJobServer(t, "SCHEDULED", "RUNNING", "RUNNING", JobStatusSucceeded)
+
+	err := ExecuteJob(context.Background(), *pmaxClient, []string{"sg1"}, "async", func(context.Context) (*http.Response, error) {
+		// The SDK fails to decode the job as the synchronous answer.
+		return jobResponse(http.StatusAccepted, `{"jobId":"1234","status":"SCHEDULED"}`), errors.New("undefined response type")
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), polls.Load())
+}
+
+func TestExecuteJobLocksStorageGroups(t *testing.T) {
+	pmaxClient, _ := newJobServer(t, JobStatusSucceeded)
+
+	err := ExecuteJob(context.Background(), *pmaxClient, []string{"sg2", "sg1", "sg2"}, "link", func(ctx context.Context) (*http.Response, error) {
+		for _, sg := range []string{"sg1", "sg2"} {
+			// The job context holds the locks, other callers wait for them.
+			_, unlock, err := pmaxClient.LockObject(ctx, client.StorageGroupObject, sg)
+			assert.NoError(t, err)
+			unlock()
+			waitCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			_, _, err = pmaxClient.LockObject(waitCtx, client.StorageGroupObject, sg)
+			cancel()
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+		return jobResponse(http.StatusOK, `{"storageGroupId":"sg1"}`), nil
+	})
+	assert.NoError(t, err)
+
+	for _, sg := range []string{"sg1", "sg2"} {
+		_, unlock, err := pmaxClient.LockObject(context.Background(), client.StorageGroupObject, sg)
+		assert.NoError(t, err)
+		unlock()
+	}
+}
+
+func TestWaitForJobFails(t *testing.T) {
+	for _, status := range []string{JobStatusFailed, JobStatusValidateFailed} {
+		t.Run(status, func(t *testing.T) {
+			pmaxClient, _ := newJobServer(t, "RUNNING", status)
+
+			job, err := WaitForJob(context.Background(), *pmaxClient, "1234")
+			assert.Equal(t, status, job.Status)
+			var apiErr *client.APIError
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, "1234", apiErr.JobID)
+				assert.Equal(t, fmt.Sprintf("job %s: Storage group sg1 is busy", strings.ToLower(status)), apiErr.Message)
+			}
+			assert.ErrorIs(t, err, ErrJobFailed)
+		})
+	}
+}
+
+func TestWaitForJobTimeout(t *testing.T) {
+	pmaxClient, _ := newJobServer(t, "RUNNING")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := WaitForJob(ctx, *pmaxClient, "1234")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "timeout waiting for job 1234, the job keeps running on the PowerMax array")
+}
+
+func TestWaitForJobDropsCachedReads(t *testing.T) {
+	var done atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/system/job/1234") {
+			done.Store(true)
+			fmt.Fprint(w, `{"jobId":"1234","name":"Create volumes","status":"SUCCEEDED","username":"admin","last_modified_date":"now"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"storageGroupId":"sg1","num_of_vols":%d}`, map[bool]int{false: 0, true: 2}[done.Load()])
+	}))
+	defer server.Close()
+	pmaxClient, err := client.NewClient(context.Background(), server.URL, "admin", "secret", "000000000001", "", false, client.ClientOptions{
+		CacheTTL: time.Minute,
+	})
+	assert.NoError(t, err)
+	sgURL := server.URL + "/univmax/restapi/100/sloprovisioning/symmetrix/000000000001/storagegroup/sg1"
+	read := func() string {
+		resp, err := pmaxClient.PmaxOpenapiClient.GetConfig().HTTPClient.Get(sgURL)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	// The storage group read before the job is cached, the job changes it
+	assert.Equal(t, `{"storageGroupId":"sg1","num_of_vols":0}`, read())
+	_, err = WaitForJob(context.Background(), *pmaxClient, "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"storageGroupId":"sg1","num_of_vols":2}`, read())
+}
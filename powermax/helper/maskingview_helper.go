@@ -55,3 +55,27 @@ func CreateMaskingView(ctx context.Context, client client.Client, plan models.Ma
 func GetMaskingView(ctx context.Context, client client.Client, name string) (*pmax.MaskingView, *http.Response, error) {
 	return client.PmaxOpenapiClient.SLOProvisioningApi.GetMaskingView(ctx, client.SymmetrixID, name).Execute()
 }
+
+// IsMergedMaskingView reports whether a masking view no longer on the array was deleted by a merge of its
+// storage group sgID: the storage group is gone too, and another masking view still masks the host or host
+// group hostOrHostGroupID to the port group portGroupID.
+func IsMergedMaskingView(ctx context.Context, pmaxClient client.Client, sgID, hostOrHostGroupID, portGroupID string) (bool, error) {
+	_, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, pmaxClient.SymmetrixID, sgID).Execute()
+	if err == nil || !client.IsNotFound(err) {
+		return false, err
+	}
+	views, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListMaskingViews(ctx, pmaxClient.SymmetrixID).Execute()
+	if err != nil {
+		return false, err
+	}
+	for _, id := range views.MaskingViewId {
+		view, _, err := GetMaskingView(ctx, pmaxClient, id)
+		if err != nil {
+			return false, err
+		}
+		if view.GetHostId()+view.GetHostGroupId() == hostOrHostGroupID && view.GetPortGroupId() == portGroupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -165,27 +165,40 @@ func ModifySnapshot(ctx context.Context, client client.Client, plan *models.Snap
 		case ActionSnapshotLink:
 			if plan.Snapshot.Link != nil && (state.Snapshot.Link == nil || plan.Snapshot.Link.Enable.ValueBool() != state.Snapshot.Link.Enable.ValueBool()) {
 				if plan.Snapshot.Link.Enable.ValueBool() {
-					modifyParam := modifyParam.StorageGroupSnapshotInstanceUpdate(powermax.StorageGroupSnapshotInstanceUpdate{
-						Action: ActionSnapshotLink,
+					// Links with copy can take long, they run as a Unisphere job holding the source and the target storage groups
+					update := powermax.StorageGroupSnapshotInstanceUpdate{
+						ExecutionOption: powermax.PtrString(AsynchronousExecution),
+						Action:          ActionSnapshotLink,
 						Link: &powermax.SnapVxLinkOptions{
 							StorageGroupName: plan.Snapshot.Link.TargetStorageGroup.ValueString(),
 							NoCompression:    plan.Snapshot.Link.NoCompression.ValueBoolPointer(),
 							Copy:             plan.Snapshot.Link.Copy.ValueBoolPointer(),
 							Remote:           plan.Snapshot.Link.Remote.ValueBoolPointer(),
 						},
+					}
+					storageGroupIDs := []string{state.StorageGroup.Name.ValueString(), plan.Snapshot.Link.TargetStorageGroup.ValueString()}
+					err := ExecuteJob(ctx, client, storageGroupIDs, "Link snapshot "+plan.Snapshot.Name.ValueString(), func(ctx context.Context) (*http.Response, error) {
+						_, resp, err := client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotSnapID(ctx, client.SymmetrixID, state.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), state.Snapid.ValueInt64()).
+							StorageGroupSnapshotInstanceUpdate(update).Execute()
+						return resp, err
 					})
-					_, _, err := modifyParam.Execute()
 					if err != nil {
 						return err
 					}
 				} else {
-					modifyParam := modifyParam.StorageGroupSnapshotInstanceUpdate(powermax.StorageGroupSnapshotInstanceUpdate{
+					// The unlink also mutates the target storage group, it is locked with the source one
+					update := powermax.StorageGroupSnapshotInstanceUpdate{
 						Action: ActionSnapshotUnlink,
 						Unlink: &powermax.SnapVxUnlinkOptions{
 							StorageGroupName: plan.Snapshot.Link.TargetStorageGroup.ValueString(),
 						},
+					}
+					storageGroupIDs := []string{state.StorageGroup.Name.ValueString(), plan.Snapshot.Link.TargetStorageGroup.ValueString()}
+					err := ExecuteJob(ctx, client, storageGroupIDs, "Unlink snapshot "+plan.Snapshot.Name.ValueString(), func(ctx context.Context) (*http.Response, error) {
+						_, resp, err := client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotSnapID(ctx, client.SymmetrixID, state.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), state.Snapid.ValueInt64()).
+							StorageGroupSnapshotInstanceUpdate(update).Execute()
+						return resp, err
 					})
-					_, _, err := modifyParam.Execute()
 					if err != nil {
 						return err
 					}
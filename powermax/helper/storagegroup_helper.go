@@ -21,13 +21,18 @@ import (
 	"context"
 	"dell/powermax-go-client"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/models"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // constants to annotate if a volume should be added or removed.
@@ -37,6 +42,19 @@ const (
 	NoOperation  = 0
 )
 
+// VolumeSetType is the type of a volume set of the storage group resource.
+var VolumeSetType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"identifier_prefix": types.StringType,
+		"count":             types.Int64Type,
+		"size":              types.NumberType,
+		"cap_unit":          types.StringType,
+		"emulation":         types.StringType,
+		"keep_volumes":      types.BoolType,
+		"volume_ids":        types.ListType{ElemType: types.StringType},
+	},
+}
+
 // AddRemoveVolume add or remove a volume based on the config of plan and current state.
 func AddRemoveVolume(ctx context.Context, plan *models.StorageGroupResourceModel, state *models.StorageGroupResourceModel, client *client.Client, sgID string) error {
 
@@ -69,7 +87,6 @@ func AddRemoveVolume(ctx context.Context, plan *models.StorageGroupResourceModel
 		return nil
 	}
 
-	payload := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID)
 	// Add or remove existing volumes to the storage group based on the attribute "volume_ids"
 	volumeIDMap := make(map[string]int)
 	for _, elem := range planVolumeIDs {
@@ -91,38 +108,678 @@ func AddRemoveVolume(ctx context.Context, plan *models.StorageGroupResourceModel
 			removeVolumeArr = append(removeVolumeArr, val)
 		}
 	}
+	// Volumes already moved by a split or a merge of the storage group are not added or removed again
+	if len(addVolumeArr) > 0 || len(removeVolumeArr) > 0 {
+		current, err := StorageGroupVolumeIDs(ctx, client, sgID)
+		if err != nil {
+			return err
+		}
+		addVolumeArr = slices.DeleteFunc(addVolumeArr, func(id string) bool { return slices.Contains(current, id) })
+		removeVolumeArr = slices.DeleteFunc(removeVolumeArr, func(id string) bool { return !slices.Contains(current, id) })
+	}
+	// Volumes split from the storage group belong to the new storage group, they are not added back
+	split, err := SplitVolumeIDs(ctx, client, sgID, addVolumeArr)
+	if err != nil {
+		return err
+	}
+	if len(split) > 0 {
+		tflog.Info(ctx, "Keeping the volumes split from the storage group in their new storage group", map[string]interface{}{
+			"storageGroupID": sgID,
+			"volumeIDs":      split,
+		})
+		addVolumeArr = slices.DeleteFunc(addVolumeArr, func(id string) bool { return slices.Contains(split, id) })
+	}
+	// Volumes taken over from another storage group are moved from it in one step, so that they are not
+	// unmasked from the hosts when that storage group gives them up before they are added here
+	moves, addVolumeArr, err := volumesToMove(ctx, client, sgID, addVolumeArr)
+	if err != nil {
+		return err
+	}
+	for _, source := range slices.Sorted(maps.Keys(moves)) {
+		tflog.Info(ctx, "Moving volumes between storage groups", map[string]interface{}{
+			"source":    source,
+			"target":    sgID,
+			"volumeIDs": moves[source],
+		})
+		if err := MoveVolumes(ctx, client, source, sgID, moves[source]); err != nil {
+			return err
+		}
+	}
+	// Large expansions run as Unisphere jobs to not be bound by the HTTP timeout
 	if len(addVolumeArr) > 0 {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
-					AddSpecificVolumeParam: &powermax.AddSpecificVolumeParam{
-						VolumeId: addVolumeArr,
-					},
+		err := EditStorageGroupJob(ctx, client, sgID, "Add volumes to storage group "+sgID, powermax.EditStorageGroupActionParam{
+			ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+				AddSpecificVolumeParam: &powermax.AddSpecificVolumeParam{
+					VolumeId: addVolumeArr,
 				},
 			},
 		})
-		_, _, err := payload.Execute()
 		if err != nil {
 			return err
 		}
 	}
 	if len(removeVolumeArr) > 0 {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
+		err := EditStorageGroupJob(ctx, client, sgID, "Remove volumes from storage group "+sgID, powermax.EditStorageGroupActionParam{
+			RemoveVolumeParam: &powermax.RemoveVolumeParam{
+				VolumeId: removeVolumeArr,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	state.VolumeIDs = plan.VolumeIDs
+	return nil
+}
+
+// MoveVolumes moves the volumes from the storage group source to the storage group target in one step,
+// without unmasking them from the hosts in between. Force is set as the move is refused otherwise when
+// either storage group is in a masking view.
+func MoveVolumes(ctx context.Context, client *client.Client, source, target string, volumeIDs []string) error {
+	return EditStorageGroupJob(ctx, client, source, fmt.Sprintf("Move volumes from storage group %s to %s", source, target), powermax.EditStorageGroupActionParam{
+		MoveVolumeToStorageGroupParam: &powermax.MoveVolumeToStorageGroupParam{
+			VolumeId:       volumeIDs,
+			StorageGroupId: target,
+			Force:          powermax.PtrBool(true),
+		},
+	})
+}
+
+// volumesToMove returns the volumes to add to the storage group sgID which are in another storage group
+// in use by FAST, grouped by that storage group, and the volumes left to add. A volume belongs to one
+// storage group with a Srp at most, so it can only join sgID by moving out of the other one.
+func volumesToMove(ctx context.Context, client *client.Client, sgID string, volumeIDs []string) (map[string][]string, []string, error) {
+	if len(volumeIDs) == 0 {
+		return nil, volumeIDs, nil
+	}
+	fast := make(map[string]bool)
+	usesFAST := func(id string) (bool, error) {
+		if used, ok := fast[id]; ok {
+			return used, nil
+		}
+		sg, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, client.SymmetrixID, id).Execute()
+		if err != nil {
+			return false, err
+		}
+		// A parent storage group has no volumes of its own, they are moved from its children
+		fast[id] = len(sg.ChildStorageGroup) == 0 && sg.GetSrp() != "" && !strings.EqualFold(sg.GetSrp(), "None")
+		return fast[id], nil
+	}
+	if used, err := usesFAST(sgID); err != nil || !used {
+		return nil, volumeIDs, err
+	}
+
+	moves := make(map[string][]string)
+	var added []string
+	for _, volumeID := range volumeIDs {
+		volume, _, err := GetVolume(ctx, *client, volumeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		source := ""
+		for _, id := range volume.StorageGroupId {
+			if id == sgID {
+				continue
+			}
+			used, err := usesFAST(id)
+			if err != nil {
+				return nil, nil, err
+			}
+			if used {
+				source = id
+				break
+			}
+		}
+		if source == "" {
+			added = append(added, volumeID)
+		} else {
+			moves[source] = append(moves[source], volumeID)
+		}
+	}
+	return moves, added, nil
+}
+
+// SplitStorageGroupVolumes moves the volumes of the masked storage group sgID to the new storage group
+// newSgID, which is masked to the same host and port group by the new masking view maskingViewID.
+func SplitStorageGroupVolumes(ctx context.Context, client *client.Client, sgID, newSgID, maskingViewID string, volumeIDs []string) error {
+	return EditStorageGroupJob(ctx, client, sgID, fmt.Sprintf("Split volumes of storage group %s to %s", sgID, newSgID), powermax.EditStorageGroupActionParam{
+		SplitStorageGroupVolumesParam: &powermax.SplitStorageGroupVolumesParam{
+			VolumeId:       volumeIDs,
+			StorageGroupId: newSgID,
+			MaskingViewId:  maskingViewID,
+		},
+	})
+}
+
+// SplitChildStorageGroup uncascades the child storage group childID of the masked parent storage group
+// sgID, the child being masked to the same host and port group by the new masking view maskingViewID.
+func SplitChildStorageGroup(ctx context.Context, client *client.Client, sgID, childID, maskingViewID string) error {
+	return EditStorageGroupJob(ctx, client, sgID, fmt.Sprintf("Split child storage group %s of %s", childID, sgID), powermax.EditStorageGroupActionParam{
+		SplitChildStorageGroupParam: &powermax.SplitChildStorageGroupParam{
+			StorageGroupId: childID,
+			MaskingViewId:  maskingViewID,
+		},
+	})
+}
+
+// MergeStorageGroup moves the volumes of the standalone storage group mergedID to the storage group sgID,
+// both being masked to the same host and port group. The merged storage group and its masking view
+// are deleted.
+func MergeStorageGroup(ctx context.Context, client *client.Client, sgID, mergedID string) error {
+	return EditStorageGroupJob(ctx, client, sgID, fmt.Sprintf("Merge storage group %s into %s", mergedID, sgID), powermax.EditStorageGroupActionParam{
+		MergeStorageGroupParam: &powermax.MergeStorageGroupParam{
+			StorageGroupId: mergedID,
+		},
+	})
+}
+
+// SplitVolumeIDs returns the volumes among volumeIDs which a split moved out of the storage group sgID: they
+// are in another storage group masked to the same host or host group and port group as sgID.
+func SplitVolumeIDs(ctx context.Context, pmaxClient *client.Client, sgID string, volumeIDs []string) ([]string, error) {
+	if len(volumeIDs) == 0 {
+		return nil, nil
+	}
+	maskings := make(map[string]map[[2]string]bool)
+	maskingsOf := func(id string) (map[[2]string]bool, error) {
+		if m, ok := maskings[id]; ok {
+			return m, nil
+		}
+		m, err := storageGroupMaskings(ctx, pmaxClient, id)
+		maskings[id] = m
+		return m, err
+	}
+	own, err := maskingsOf(sgID)
+	if err != nil || len(own) == 0 {
+		return nil, err
+	}
+
+	var split []string
+	for _, volumeID := range volumeIDs {
+		volume, _, err := GetVolume(ctx, *pmaxClient, volumeID)
+		if err != nil {
+			if client.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, id := range volume.StorageGroupId {
+			if id == sgID {
+				continue
+			}
+			other, err := maskingsOf(id)
+			if err != nil {
+				return nil, err
+			}
+			if sharesMasking(own, other) {
+				split = append(split, volumeID)
+				break
+			}
+		}
+	}
+	return split, nil
+}
+
+// keepSplitVolumeIDs keeps in the volume IDs of the storage group the previous ones which a split moved out of it,
+// so that the split does not show as a change of volume_ids.
+func keepSplitVolumeIDs(ctx context.Context, client *client.Client, sgID string, previous types.List, volumeIDs []string) ([]string, error) {
+	var previousIDs []string
+	if !previous.IsNull() && !previous.IsUnknown() {
+		if diags := previous.ElementsAs(ctx, &previousIDs, true); diags.HasError() {
+			return nil, fmt.Errorf("unable to parse volume ids from state")
+		}
+	}
+	missing := slices.DeleteFunc(slices.Clone(previousIDs), func(id string) bool { return slices.Contains(volumeIDs, id) })
+	split, err := SplitVolumeIDs(ctx, client, sgID, missing)
+	if err != nil || len(split) == 0 {
+		return volumeIDs, err
+	}
+	kept := slices.DeleteFunc(previousIDs, func(id string) bool { return !slices.Contains(volumeIDs, id) && !slices.Contains(split, id) })
+	for _, id := range volumeIDs {
+		if !slices.Contains(kept, id) {
+			kept = append(kept, id)
+		}
+	}
+	return kept, nil
+}
+
+// MergedInto returns the masked storage group holding all the volumeIDs of a storage group no longer on the array,
+// where a merge moved them, or "" when there is none.
+func MergedInto(ctx context.Context, pmaxClient *client.Client, volumeIDs []string) (string, error) {
+	if len(volumeIDs) == 0 {
+		return "", nil
+	}
+	volume, _, err := GetVolume(ctx, *pmaxClient, volumeIDs[0])
+	if err != nil {
+		if client.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	for _, id := range volume.StorageGroupId {
+		sg, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, pmaxClient.SymmetrixID, id).Execute()
+		if err != nil {
+			return "", err
+		}
+		if len(sg.Maskingview) == 0 {
+			continue
+		}
+		current, err := StorageGroupVolumeIDs(ctx, pmaxClient, id)
+		if err != nil {
+			return "", err
+		}
+		if !slices.ContainsFunc(volumeIDs, func(volumeID string) bool { return !slices.Contains(current, volumeID) }) {
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// storageGroupMaskings returns the host or host group and port group pairs the storage group sgID is masked to.
+func storageGroupMaskings(ctx context.Context, client *client.Client, sgID string) (map[[2]string]bool, error) {
+	sg, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, client.SymmetrixID, sgID).Execute()
+	if err != nil {
+		return nil, err
+	}
+	maskings := make(map[[2]string]bool)
+	for _, maskingViewID := range sg.Maskingview {
+		view, _, err := GetMaskingView(ctx, *client, maskingViewID)
+		if err != nil {
+			return nil, err
+		}
+		maskings[[2]string{view.GetHostId() + view.GetHostGroupId(), view.GetPortGroupId()}] = true
+	}
+	return maskings, nil
+}
+
+// sharesMasking reports whether two storage groups are masked to a same host or host group and port group.
+func sharesMasking(maskings, others map[[2]string]bool) bool {
+	for masking := range others {
+		if maskings[masking] {
+			return true
+		}
+	}
+	return false
+}
+
+// EditStorageGroupJob runs the edit of the storage group as a Unisphere job.
+func EditStorageGroupJob(ctx context.Context, client *client.Client, sgID, description string, action powermax.EditStorageGroupActionParam) error {
+	return ExecuteJob(ctx, *client, []string{sgID}, description, func(ctx context.Context) (*http.Response, error) {
+		_, resp, err := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(powermax.EditStorageGroupParam{
+			ExecutionOption:             powermax.PtrString(AsynchronousExecution),
+			EditStorageGroupActionParam: action,
+		}).Execute()
+		return resp, err
+	})
+}
+
+// AddRemoveChildStorageGroups cascades or uncascades existing storage groups under the storage group based on the attribute "child_storage_groups".
+func AddRemoveChildStorageGroups(ctx context.Context, plan *models.StorageGroupResourceModel, state *models.StorageGroupResourceModel, client *client.Client, sgID string) error {
+	var planChildren []string
+	var stateChildren []string
+
+	if state.ChildStorageGroups.IsNull() || state.ChildStorageGroups.IsUnknown() {
+		state.ChildStorageGroups, _ = types.SetValueFrom(ctx, types.StringType, []string{})
+	}
+
+	if plan.ChildStorageGroups.IsNull() || plan.ChildStorageGroups.IsUnknown() {
+		return nil
+	}
+
+	if diags := plan.ChildStorageGroups.ElementsAs(ctx, &planChildren, true); diags.HasError() {
+		return fmt.Errorf("unable to parse child storage groups from plan")
+	}
+	if diags := state.ChildStorageGroups.ElementsAs(ctx, &stateChildren, true); diags.HasError() {
+		return fmt.Errorf("unable to parse child storage groups from state")
+	}
+
+	var addChildren []string
+	var removeChildren []string
+	for _, child := range planChildren {
+		if !slices.Contains(stateChildren, child) {
+			addChildren = append(addChildren, child)
+		}
+	}
+	for _, child := range stateChildren {
+		if !slices.Contains(planChildren, child) {
+			removeChildren = append(removeChildren, child)
+		}
+	}
+
+	// Uncascade first so that a child moved between two parents is free to join the new one
+	if len(removeChildren) > 0 {
+		_, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(powermax.EditStorageGroupParam{
 			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				RemoveVolumeParam: &powermax.RemoveVolumeParam{
-					VolumeId: removeVolumeArr,
+				RemoveStorageGroupParam: &powermax.RemoveStorageGroupParam{
+					StorageGroupId: removeChildren,
 				},
 			},
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("unable to remove child storage groups %s from storage group %s: %w", strings.Join(removeChildren, ", "), sgID, err)
+		}
+	}
+	if len(addChildren) > 0 {
+		_, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(powermax.EditStorageGroupParam{
+			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+				ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+					AddExistingStorageGroupParam: &powermax.AddExistingStorageGroupParam{
+						StorageGroupId: addChildren,
+					},
+				},
+			},
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("unable to add child storage groups %s to storage group %s: %w", strings.Join(addChildren, ", "), sgID, err)
+		}
+	}
+	state.ChildStorageGroups = plan.ChildStorageGroups
+	return nil
+}
+
+// AddRemoveTags adds or removes the tags of the storage group based on the attribute "tags".
+func AddRemoveTags(ctx context.Context, plan *models.StorageGroupResource, state *models.StorageGroupResource, client *client.Client, sgID string) error {
+	var planTags []string
+	var stateTags []string
+
+	if state.Tags.IsNull() || state.Tags.IsUnknown() {
+		state.Tags, _ = types.SetValueFrom(ctx, types.StringType, []string{})
+	}
+
+	if plan.Tags.IsNull() || plan.Tags.IsUnknown() {
+		return nil
+	}
+
+	if diags := plan.Tags.ElementsAs(ctx, &planTags, true); diags.HasError() {
+		return fmt.Errorf("unable to parse tags from plan")
+	}
+	if diags := state.Tags.ElementsAs(ctx, &stateTags, true); diags.HasError() {
+		return fmt.Errorf("unable to parse tags from state")
+	}
+
+	param := powermax.TagManagementParam{}
+	for _, tag := range planTags {
+		if !slices.Contains(stateTags, tag) {
+			if param.AddTagsParam == nil {
+				param.AddTagsParam = &powermax.AddTagsParam{}
+			}
+			param.AddTagsParam.TagName = append(param.AddTagsParam.TagName, tag)
+		}
+	}
+	for _, tag := range stateTags {
+		if !slices.Contains(planTags, tag) {
+			if param.RemoveTagsParam == nil {
+				param.RemoveTagsParam = &powermax.RemoveTagsParam{}
+			}
+			param.RemoveTagsParam.TagName = append(param.RemoveTagsParam.TagName, tag)
+		}
+	}
+	if param.AddTagsParam == nil && param.RemoveTagsParam == nil {
+		return nil
+	}
+
+	_, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, client.SymmetrixID, sgID).EditStorageGroupParam(powermax.EditStorageGroupParam{
+		EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
+			TagManagementParam: &param,
+		},
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to update the tags of storage group %s: %w", sgID, err)
+	}
+	state.Tags = plan.Tags
+	return nil
+}
+
+// StorageGroupTags returns the tags of the storage group as a set.
+func StorageGroupTags(ctx context.Context, storageGroup *powermax.StorageGroup) types.Set {
+	tags, _ := types.SetValueFrom(ctx, types.StringType, SplitTags(storageGroup.GetTags()))
+	return tags
+}
+
+// SplitTags returns the tags of the comma separated list of tags of a storage group.
+func SplitTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// AddRemoveVolumeSets creates, expands or shrinks the volume sets of the storage group based on the attribute "volume_sets".
+// The sets are matched by position, only their count can change; the volumes dropped from a set are removed from the
+// storage group, then deleted unless the set keeps them.
+func AddRemoveVolumeSets(ctx context.Context, plan *models.StorageGroupResource, state *models.StorageGroupResource, client *client.Client, sgID string) error {
+	var planSets []models.StorageGroupVolumeSet
+	var stateSets []models.StorageGroupVolumeSet
+
+	if plan.VolumeSets.IsUnknown() {
+		return nil
+	}
+	if !plan.VolumeSets.IsNull() {
+		if diags := plan.VolumeSets.ElementsAs(ctx, &planSets, true); diags.HasError() {
+			return fmt.Errorf("unable to parse volume sets from plan")
+		}
+	}
+	if !state.VolumeSets.IsNull() && !state.VolumeSets.IsUnknown() {
+		if diags := state.VolumeSets.ElementsAs(ctx, &stateSets, true); diags.HasError() {
+			return fmt.Errorf("unable to parse volume sets from state")
+		}
+	}
+
+	var removeVolumeArr []string
+	var deleteVolumeArr []string
+	for i := range planSets {
+		set := &planSets[i]
+		var volumeIDs []string
+		if i < len(stateSets) {
+			previous := stateSets[i]
+			if !set.IdentifierPrefix.Equal(previous.IdentifierPrefix) || !set.Size.Equal(previous.Size) ||
+				!set.CapUnit.Equal(previous.CapUnit) || !set.Emulation.Equal(previous.Emulation) {
+				return fmt.Errorf("only the count of the volume set %d can be updated, a volume set with other volumes must be appended to volume_sets", i)
+			}
+			if diags := previous.VolumeIDs.ElementsAs(ctx, &volumeIDs, true); diags.HasError() {
+				return fmt.Errorf("unable to parse the volume ids of the volume set %d from state", i)
+			}
+		}
+		count := int(set.Count.ValueInt64())
+		if count < len(volumeIDs) {
+			removeVolumeArr = append(removeVolumeArr, volumeIDs[count:]...)
+			if !set.KeepVolumes.ValueBool() {
+				deleteVolumeArr = append(deleteVolumeArr, volumeIDs[count:]...)
+			}
+			volumeIDs = volumeIDs[:count]
+		} else if count > len(volumeIDs) {
+			created, err := createVolumeSetVolumes(ctx, client, sgID, *set, len(volumeIDs), count-len(volumeIDs))
+			if err != nil {
+				return err
+			}
+			volumeIDs = append(volumeIDs, created...)
+		}
+		set.VolumeIDs, _ = types.ListValueFrom(ctx, types.StringType, volumeIDs)
+	}
+	for i := len(planSets); i < len(stateSets); i++ {
+		var volumeIDs []string
+		if diags := stateSets[i].VolumeIDs.ElementsAs(ctx, &volumeIDs, true); diags.HasError() {
+			return fmt.Errorf("unable to parse the volume ids of the volume set %d from state", i)
+		}
+		removeVolumeArr = append(removeVolumeArr, volumeIDs...)
+		if !stateSets[i].KeepVolumes.ValueBool() {
+			deleteVolumeArr = append(deleteVolumeArr, volumeIDs...)
+		}
+	}
+
+	if len(removeVolumeArr) > 0 {
+		err := EditStorageGroupJob(ctx, client, sgID, "Remove volumes of volume sets from storage group "+sgID, powermax.EditStorageGroupActionParam{
+			RemoveVolumeParam: &powermax.RemoveVolumeParam{
+				VolumeId: removeVolumeArr,
+			},
 		})
-		_, _, err := payload.Execute()
 		if err != nil {
 			return err
 		}
 	}
-	state.VolumeIDs = plan.VolumeIDs
+	if err := DeleteVolumeSetVolumes(ctx, client, deleteVolumeArr); err != nil {
+		return err
+	}
+
+	if plan.VolumeSets.IsNull() {
+		state.VolumeSets = types.ListNull(VolumeSetType)
+		return nil
+	}
+	volumeSets, diags := types.ListValueFrom(ctx, VolumeSetType, planSets)
+	if diags.HasError() {
+		return fmt.Errorf("unable to set the volume sets of storage group %s", sgID)
+	}
+	state.VolumeSets = volumeSets
 	return nil
 }
 
+// VolumeSetVolumeIDs returns the IDs of the volumes of the volume sets in state which are not kept on the array.
+func VolumeSetVolumeIDs(ctx context.Context, state *models.StorageGroupResource) ([]string, error) {
+	if state.VolumeSets.IsNull() || state.VolumeSets.IsUnknown() {
+		return nil, nil
+	}
+	var sets []models.StorageGroupVolumeSet
+	if diags := state.VolumeSets.ElementsAs(ctx, &sets, true); diags.HasError() {
+		return nil, fmt.Errorf("unable to parse volume sets from state")
+	}
+	var volumeIDs []string
+	for i, set := range sets {
+		if set.KeepVolumes.ValueBool() {
+			continue
+		}
+		var setVolumeIDs []string
+		if diags := set.VolumeIDs.ElementsAs(ctx, &setVolumeIDs, true); diags.HasError() {
+			return nil, fmt.Errorf("unable to parse the volume ids of the volume set %d from state", i)
+		}
+		volumeIDs = append(volumeIDs, setVolumeIDs...)
+	}
+	return volumeIDs, nil
+}
+
+// DeleteVolumeSetVolumes deletes the volumes of volume sets once removed from the storage group. The volumes no
+// longer on the array are skipped, and so are the ones still in another storage group, for example moved there by a split.
+func DeleteVolumeSetVolumes(ctx context.Context, pmaxClient *client.Client, volumeIDs []string) error {
+	for _, volumeID := range volumeIDs {
+		volume, _, err := GetVolume(ctx, *pmaxClient, volumeID)
+		if err != nil {
+			if client.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if len(volume.StorageGroupId) > 0 {
+			tflog.Warn(ctx, "Keeping the volume of a volume set which is in another storage group", map[string]interface{}{
+				"volumeID":        volumeID,
+				"storageGroupIDs": volume.StorageGroupId,
+			})
+			continue
+		}
+		_, err = pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteVolume(ctx, pmaxClient.SymmetrixID, volumeID).Execute()
+		if err != nil && !client.IsNotFound(err) {
+			return fmt.Errorf("could not delete the volume %s of a volume set: %w", volumeID, err)
+		}
+	}
+	return nil
+}
+
+// createVolumeSetVolumes creates count new volumes of the volume set in the storage group and returns their IDs.
+// The volumes are numbered after the ones the set already has.
+func createVolumeSetVolumes(ctx context.Context, client *client.Client, sgID string, set models.StorageGroupVolumeSet, existing int, count int) ([]string, error) {
+	before, err := StorageGroupVolumeIDs(ctx, client, sgID)
+	if err != nil {
+		return nil, err
+	}
+	createNewVol := true
+	num := int64(count)
+	err = EditStorageGroupJob(ctx, client, sgID, fmt.Sprintf("Create %d volumes %s in storage group %s", count, set.IdentifierPrefix.ValueString(), sgID), powermax.EditStorageGroupActionParam{
+		ExpandStorageGroupParam: &powermax.ExpandStorageGroupParam{
+			AddVolumeParam: &powermax.AddVolumeParam{
+				CreateNewVolumes: &createNewVol,
+				Emulation:        set.Emulation.ValueStringPointer(),
+				VolumeAttributes: []powermax.VolumeAttribute{
+					{
+						CapacityUnit: set.CapUnit.ValueString(),
+						VolumeSize:   set.Size.ValueBigFloat().String(),
+						NumOfVols:    &num,
+						VolumeIdentifier: &powermax.VolumeIdentifier{
+							VolumeIdentifierChoice: "identifier_name_plus_append_number",
+							IdentifierName:         set.IdentifierPrefix.ValueStringPointer(),
+							AppendNumber:           powermax.PtrString(strconv.Itoa(existing + 1)),
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	after, err := StorageGroupVolumeIDs(ctx, client, sgID)
+	if err != nil {
+		return nil, err
+	}
+	var created []string
+	for _, volumeID := range after {
+		if !slices.Contains(before, volumeID) {
+			created = append(created, volumeID)
+		}
+	}
+	if len(created) != count {
+		return created, fmt.Errorf("expected %d new volumes %s in storage group %s, found %d", count, set.IdentifierPrefix.ValueString(), sgID, len(created))
+	}
+	return created, nil
+}
+
+// UpdateVolumeSetsState drops from the volume sets the volumes which are no longer in the storage group,
+// the count of the sets follows so that they are expanded again on the next apply.
+func UpdateVolumeSetsState(ctx context.Context, state *models.StorageGroupResource) error {
+	if state.VolumeSets.IsNull() || state.VolumeSets.IsUnknown() {
+		return nil
+	}
+	var sets []models.StorageGroupVolumeSet
+	var volumeIDs []string
+	if diags := state.VolumeSets.ElementsAs(ctx, &sets, true); diags.HasError() {
+		return fmt.Errorf("unable to parse volume sets from state")
+	}
+	if diags := state.VolumeIDs.ElementsAs(ctx, &volumeIDs, true); diags.HasError() {
+		return fmt.Errorf("unable to parse volume ids from state")
+	}
+	for i := range sets {
+		var setVolumeIDs []string
+		if diags := sets[i].VolumeIDs.ElementsAs(ctx, &setVolumeIDs, true); diags.HasError() {
+			return fmt.Errorf("unable to parse the volume ids of the volume set %d from state", i)
+		}
+		setVolumeIDs = slices.DeleteFunc(setVolumeIDs, func(volumeID string) bool {
+			return !slices.Contains(volumeIDs, volumeID)
+		})
+		sets[i].Count = types.Int64Value(int64(len(setVolumeIDs)))
+		sets[i].VolumeIDs, _ = types.ListValueFrom(ctx, types.StringType, setVolumeIDs)
+	}
+	volumeSets, diags := types.ListValueFrom(ctx, VolumeSetType, sets)
+	if diags.HasError() {
+		return fmt.Errorf("unable to set the volume sets from state")
+	}
+	state.VolumeSets = volumeSets
+	return nil
+}
+
+// StorageGroupVolumeIDs returns the IDs of the volumes in the storage group.
+func StorageGroupVolumeIDs(ctx context.Context, client *client.Client, sgID string) ([]string, error) {
+	volumeIDList, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, client.SymmetrixID).StorageGroupId(sgID).Execute()
+	if err != nil {
+		return nil, err
+	}
+	volumeIDs := make([]string, 0, len(volumeIDList.GetResultList().Result))
+	for _, v := range volumeIDList.ResultList.Result {
+		for _, v2 := range v {
+			volumeIDs = append(volumeIDs, fmt.Sprint(v2))
+		}
+	}
+	return volumeIDs, nil
+}
+
 // CreateSloParam Create SLO param.
 func CreateSloParam(plan models.StorageGroupResourceModel) []powermax.SloBasedStorageGroupParam {
 
@@ -175,22 +832,23 @@ func CreateSloParam(plan models.StorageGroupResourceModel) []powermax.SloBasedSt
 }
 
 // UpdateSgState update the state of storage group based on the current state of the storage group.
-func UpdateSgState(ctx context.Context, client *client.Client, sgID string, state *models.StorageGroupResourceModel) error {
+// The storage group read is returned for the fields which differ between the resource and the data source.
+func UpdateSgState(ctx context.Context, client *client.Client, sgID string, state *models.StorageGroupResourceModel) (*powermax.StorageGroup, error) {
 	// Update all fields of state
 	storageGroup, _, err := client.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, client.SymmetrixID, sgID).Execute()
 
 	if err != nil {
-		mes := fmt.Sprintf("StorageGroup %s is not on the powermax: ", sgID)
-		return fmt.Errorf(mes, err.Error())
+		return nil, fmt.Errorf("StorageGroup %s is not on the powermax: %w", sgID, err)
 	}
 
 	err = CopyFields(ctx, storageGroup, state)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if id, ok := storageGroup.GetStorageGroupIdOk(); ok {
 		state.StorageGroupID = types.StringValue(*id)
 	}
+	state.SerialNumber = types.StringValue(client.SymmetrixID)
 
 	if uuid, ok := storageGroup.GetUuidOk(); ok {
 		state.UUID = types.StringValue(*uuid)
@@ -224,24 +882,24 @@ func UpdateSgState(ctx context.Context, client *client.Client, sgID string, stat
 	}
 
 	// Read volume list in storage group
-	volIDModel := client.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, client.SymmetrixID)
-	// Set the storage group id
-	volIDModel = volIDModel.StorageGroupId(storageGroup.StorageGroupId)
-	volumeIDListInStorageGroup, _, err := volIDModel.Execute()
-	vol := make([]string, 0, len(volumeIDListInStorageGroup.GetResultList().Result))
-	for _, v := range volumeIDListInStorageGroup.ResultList.Result {
-		for _, v2 := range v {
-			vol = append(vol, fmt.Sprint(v2))
-		}
+	vol, err := StorageGroupVolumeIDs(ctx, client, storageGroup.StorageGroupId)
+	if err != nil {
+		return nil, err
 	}
+	vol, err = keepSplitVolumeIDs(ctx, client, sgID, state.VolumeIDs, vol)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	state.VolumeIDs, _ = types.ListValueFrom(ctx, types.StringType, vol)
+	children := storageGroup.ChildStorageGroup
+	if children == nil {
+		children = []string{}
+	}
+	state.ChildStorageGroups, _ = types.SetValueFrom(ctx, types.StringType, children)
 	// set ID
 	state.ID = types.StringValue(storageGroup.StorageGroupId)
 
-	return nil
+	return storageGroup, nil
 }
 
 // ConstructHostIOLimit constructs the host io limit param based on the plan.
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+	"dell/powermax-go-client"
+	"net/http"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// GetTagList lists the names of the tags of the Unisphere.
+func GetTagList(ctx context.Context, client client.Client) (*powermax.TagListResult, *http.Response, error) {
+	// The SDK requires every filter of the request but does not encode their values, they are left
+	// empty so that they are not sent
+	return client.PmaxOpenapiClient.SystemApi.ListTags(ctx).
+		TagName([]string{}).
+		StorageGroupId([]string{}).
+		ArrayId([]string{}).
+		NumOfStorageGroups([]string{}).
+		NumOfArrays([]string{}).
+		Execute()
+}
+
+// ReadTagByName reads the tag and the objects carrying it.
+func ReadTagByName(ctx context.Context, client client.Client, tagName string) (*powermax.TagResult, *http.Response, error) {
+	return client.PmaxOpenapiClient.SystemApi.GetTag(ctx, tagName).Execute()
+}
+
+// UpdateTagState sets the state of the tag from the response, only the storage groups of the array are kept.
+func UpdateTagState(ctx context.Context, client client.Client, tag *models.Tag, tagResponse *powermax.TagResult) {
+	storageGroups := []string{}
+	for _, info := range tagResponse.GetStorageGroupInfos().StorageGroupInfo {
+		if info.ArrayId == client.SymmetrixID {
+			storageGroups = append(storageGroups, info.StorageGroupId)
+		}
+	}
+	arrayIDs := tagResponse.ArrayIds
+	if arrayIDs == nil {
+		arrayIDs = []string{}
+	}
+	tag.Name = types.StringValue(tagResponse.GetTagName())
+	tag.StorageGroups, _ = types.ListValueFrom(ctx, types.StringType, storageGroups)
+	tag.ArrayIDs, _ = types.ListValueFrom(ctx, types.StringType, arrayIDs)
+}
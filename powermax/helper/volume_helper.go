@@ -204,6 +204,18 @@ func UpdateVol(ctx context.Context, client *client.Client, planVol, stateVol mod
 			updatedParameters = append(updatedParameters, "size")
 		}
 	}
+	// An imported volume has no storage group in its state to move it from
+	if stateVol.StorageGroupName.ValueString() != "" && planVol.StorageGroupName.ValueString() != stateVol.StorageGroupName.ValueString() {
+		err := MoveVolumes(ctx, client, stateVol.StorageGroupName.ValueString(), planVol.StorageGroupName.ValueString(), []string{stateVol.ID.ValueString()})
+		if err != nil {
+			errStr := ""
+			message := GetErrorString(err, errStr)
+			updateFailedParameters = append(updateFailedParameters, "sg_name")
+			errorMessages = append(errorMessages, fmt.Sprintf("Failed to move the volume to storage group %s: %s", planVol.StorageGroupName.ValueString(), message))
+		} else {
+			updatedParameters = append(updatedParameters, "sg_name")
+		}
+	}
 
 	return updatedParameters, updateFailedParameters, errorMessages
 }
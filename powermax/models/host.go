@@ -19,12 +19,14 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // HostModel describes the resource data model.
 type HostModel struct {
 	HostID             types.String `tfsdk:"id"`
+	SerialNumber       types.String `tfsdk:"serial_number"`
 	Name               types.String `tfsdk:"name"`
 	NumberMaskingViews types.Int64  `tfsdk:"num_of_masking_views"`
 	NumberInitiators   types.Int64  `tfsdk:"num_of_initiators"`
@@ -42,6 +44,13 @@ type HostModel struct {
 	HostFlags HostFlags `tfsdk:"host_flags"`
 }
 
+// HostResource is the state of the host resource. HostModel is shared with the hosts data source,
+// which has no timeouts.
+type HostResource struct {
+	HostModel
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
+}
+
 // HostFlags - group of flags used as part of host creation.
 type HostFlags struct {
 	VolumeSetAddressing HostFlag `tfsdk:"volume_set_addressing"`
@@ -62,9 +71,10 @@ type HostFlag struct {
 
 // HostsDataSourceModel describes the data source data model.
 type HostsDataSourceModel struct {
-	ID      types.String   `tfsdk:"id"`
-	Timeout timeouts.Value `tfsdk:"timeouts"`
-	Hosts   []HostModel    `tfsdk:"hosts"`
+	ID           types.String   `tfsdk:"id"`
+	SerialNumber types.String   `tfsdk:"serial_number"`
+	Timeout      timeouts.Value `tfsdk:"timeouts"`
+	Hosts        []HostModel    `tfsdk:"hosts"`
 
 	//filter
 	HostFilter *HostFilterType `tfsdk:"filter"`
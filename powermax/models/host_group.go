@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -26,6 +27,8 @@ import (
 type HostGroupModel struct {
 	// ID - defines host ID
 	ID types.String `tfsdk:"id"`
+	// SerialNumber - the serial number of the array
+	SerialNumber types.String `tfsdk:"serial_number"`
 	// Name - The name of the hostgroup
 	Name types.String `tfsdk:"name"`
 	// HostFlags - Specifies the flags set for a hostgroup
@@ -46,12 +49,15 @@ type HostGroupModel struct {
 	Type types.String `tfsdk:"type"`
 	// Maskingview - Specifies the list of maskingviews for a hostgroup
 	Maskingviews types.List `tfsdk:"maskingviews"`
+	// Timeouts - the timeouts of the operations on the hostgroup
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
 }
 
 // HostGroupDataSourceModel describes the hostgroup data source model.
 type HostGroupDataSourceModel struct {
 	Timeout          timeouts.Value         `tfsdk:"timeouts"`
 	ID               types.String           `tfsdk:"id"`
+	SerialNumber     types.String           `tfsdk:"serial_number"`
 	HostGroupDetails []HostGroupDetailModal `tfsdk:"host_group_details"`
 	HostGroupFilter  *filterType            `tfsdk:"filter"`
 }
@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -26,10 +27,14 @@ import (
 type MaskingViewResourceModel struct {
 	Name           types.String `tfsdk:"name"`
 	ID             types.String `tfsdk:"id"`
+	SerialNumber   types.String `tfsdk:"serial_number"`
 	StorageGroupID types.String `tfsdk:"storage_group_id"`
 	HostID         types.String `tfsdk:"host_id"`
 	HostGroupID    types.String `tfsdk:"host_group_id"`
 	PortGroupID    types.String `tfsdk:"port_group_id"`
+
+	// The timeouts of the operations on the masking view.
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
 }
 
 // MaskingViewDataSourceModel describes the data source data model.
@@ -37,6 +42,7 @@ type MaskingViewDataSourceModel struct {
 	Timeout      timeouts.Value     `tfsdk:"timeouts"`
 	MaskingViews []MaskingViewModel `tfsdk:"masking_views"`
 	ID           types.String       `tfsdk:"id"`
+	SerialNumber types.String       `tfsdk:"serial_number"`
 	//filter
 	MaskingViewFilter *MaskingViewFilterType `tfsdk:"filter"`
 }
@@ -24,10 +24,11 @@ import (
 
 // PortDataSourceModel describes the port data source model.
 type PortDataSourceModel struct {
-	Timeout     timeouts.Value    `tfsdk:"timeouts"`
-	ID          types.String      `tfsdk:"id"`
-	PortDetails []PortDetailModal `tfsdk:"port_details"`
-	PortFilter  *portFilterType   `tfsdk:"filter"`
+	Timeout      timeouts.Value    `tfsdk:"timeouts"`
+	ID           types.String      `tfsdk:"id"`
+	SerialNumber types.String      `tfsdk:"serial_number"`
+	PortDetails  []PortDetailModal `tfsdk:"port_details"`
+	PortFilter   *portFilterType   `tfsdk:"filter"`
 }
 
 type portFilterType struct {
@@ -19,6 +19,7 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -26,6 +27,8 @@ import (
 type PortGroup struct {
 	// ID - defines portgroup ID
 	ID types.String `tfsdk:"id"`
+	// SerialNumber - the serial number of the array
+	SerialNumber types.String `tfsdk:"serial_number"`
 	// Name - The name of the portgroup
 	Name types.String `tfsdk:"name"`
 	// Ports - (Set of Ports) The ports associated with the portgroup
@@ -42,6 +45,13 @@ type PortGroup struct {
 	Maskingview types.List `tfsdk:"maskingview"`
 }
 
+// PortGroupResource is the state of the portgroup resource. PortGroup is shared with the
+// portgroups data source, which has no timeouts.
+type PortGroupResource struct {
+	PortGroup
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
+}
+
 // PortKey holds DirectorID and PortKey.
 type PortKey struct {
 	DirectorID types.String `tfsdk:"director_id"`
@@ -50,9 +60,10 @@ type PortKey struct {
 
 // PortgroupsDataSourceModel describes the data source data model.
 type PortgroupsDataSourceModel struct {
-	ID         types.String   `tfsdk:"id"`
-	PortGroups []PortGroup    `tfsdk:"port_groups"`
-	Timeout    timeouts.Value `tfsdk:"timeouts"`
+	ID           types.String   `tfsdk:"id"`
+	SerialNumber types.String   `tfsdk:"serial_number"`
+	PortGroups   []PortGroup    `tfsdk:"port_groups"`
+	Timeout      timeouts.Value `tfsdk:"timeouts"`
 	//filter
 	PgFilter *portGroupFilterType `tfsdk:"filter"`
 }
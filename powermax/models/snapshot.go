@@ -18,6 +18,7 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -25,13 +26,15 @@ import (
 type SnapshotDataSourceModel struct {
 	Timeout      timeouts.Value        `tfsdk:"timeouts"`
 	ID           types.String          `tfsdk:"id"`
+	SerialNumber types.String          `tfsdk:"serial_number"`
 	Snapshots    []SnapshotDetailModal `tfsdk:"snapshots"`
 	StorageGroup *FilterTypeSnapshot   `tfsdk:"storage_group"`
 }
 
 // SnapshotResourceModel struct.
 type SnapshotResourceModel struct {
-	ID types.String `tfsdk:"id"`
+	ID           types.String `tfsdk:"id"`
+	SerialNumber types.String `tfsdk:"serial_number"`
 	// The name of the SnapVX snapshot.
 	Name types.String `tfsdk:"name"`
 	// The number of generation for the snapshot. Using snap IDs instead of generation numbers is preferred.
@@ -72,6 +75,9 @@ type SnapshotResourceModel struct {
 	Persistent   types.Bool              `tfsdk:"persistent"`
 	StorageGroup *FilterTypeSnapshot     `tfsdk:"storage_group"`
 	Snapshot     *SnapshotResourceFields `tfsdk:"snapshot_actions"`
+
+	// The timeouts of the operations on the snapshot.
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
 }
 
 // SnapshotResourceFields The different Action fields for snapshot.
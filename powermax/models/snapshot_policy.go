@@ -18,6 +18,7 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -54,6 +55,7 @@ type SnapshotPolicyModel struct {
 // SnapshotPolicyDataSourceModel describes the snapshot policy data source model.
 type SnapshotPolicyDataSourceModel struct {
 	ID               types.String          `tfsdk:"id"`
+	SerialNumber     types.String          `tfsdk:"serial_number"`
 	SnapshotPolicies []SnapshotPolicyModel `tfsdk:"snapshot_policies"`
 	Timeout          timeouts.Value        `tfsdk:"timeouts"`
 	//filter
@@ -67,7 +69,8 @@ type SnapshotPolicyFilterType struct {
 
 // SnapshotPolicyResource structure.
 type SnapshotPolicyResource struct {
-	ID types.String `tfsdk:"id"`
+	ID           types.String `tfsdk:"id"`
+	SerialNumber types.String `tfsdk:"serial_number"`
 	// The name of the snapshot policy on this System
 	SnapshotPolicyName types.String `tfsdk:"snapshot_policy_name"`
 	// The number of snapshots that will be taken before the oldest ones are no longer required.Max value is 1024.
@@ -99,4 +102,7 @@ type SnapshotPolicyResource struct {
 	Type types.String `tfsdk:"type"`
 	// Storage Groups associated with the snapshot policy
 	StorageGroups types.Set `tfsdk:"storage_groups"`
+
+	// The timeouts of the operations on the snapshot policy.
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
 }
@@ -19,12 +19,14 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // StorageGroupResourceModel describes the resource data model.
 type StorageGroupResourceModel struct {
 	ID                    types.String `tfsdk:"id"`
+	SerialNumber          types.String `tfsdk:"serial_number"`
 	StorageGroupID        types.String `tfsdk:"name"`
 	Slo                   types.String `tfsdk:"slo"`
 	Srp                   types.String `tfsdk:"srp_id"`
@@ -50,10 +52,30 @@ type StorageGroupResourceModel struct {
 	CompressionRatio      types.String `tfsdk:"compression_ratio"`
 	CompressionRatioToOne types.Number `tfsdk:"compression_ratio_to_one"`
 	VpSavedPercent        types.Number `tfsdk:"vp_saved_percent"`
-	Tags                  types.String `tfsdk:"tags"`
 	UUID                  types.String `tfsdk:"uuid"`
 	UnreducibleDataGb     types.Number `tfsdk:"unreducible_data_gb"`
 	VolumeIDs             types.List   `tfsdk:"volume_ids"`
+	ChildStorageGroups    types.Set    `tfsdk:"child_storage_groups"`
+}
+
+// StorageGroupResource is the state of the storage group resource. StorageGroupResourceModel is
+// shared with the storage group data source, which has no timeouts.
+type StorageGroupResource struct {
+	StorageGroupResourceModel
+	Tags       types.Set              `tfsdk:"tags"`
+	VolumeSets types.List             `tfsdk:"volume_sets"`
+	Timeouts   resourcetimeouts.Value `tfsdk:"timeouts"`
+}
+
+// StorageGroupVolumeSet describes a set of volumes created in the storage group.
+type StorageGroupVolumeSet struct {
+	IdentifierPrefix types.String `tfsdk:"identifier_prefix"`
+	Count            types.Int64  `tfsdk:"count"`
+	Size             types.Number `tfsdk:"size"`
+	CapUnit          types.String `tfsdk:"cap_unit"`
+	Emulation        types.String `tfsdk:"emulation"`
+	KeepVolumes      types.Bool   `tfsdk:"keep_volumes"`
+	VolumeIDs        types.List   `tfsdk:"volume_ids"`
 }
 
 // SetHostIOLimitsParam describes the data model for setting host IO limits.
@@ -65,12 +87,41 @@ type SetHostIOLimitsParam struct {
 
 // StorageGroupDataSourceModel describes the data source data model.
 type StorageGroupDataSourceModel struct {
-	ID                 types.String                `tfsdk:"id"`
-	StorageGroups      []StorageGroupResourceModel `tfsdk:"storage_groups"`
-	Timeout            timeouts.Value              `tfsdk:"timeouts"`
-	StorageGroupFilter *sgFilterType               `tfsdk:"filter"`
+	ID                 types.String            `tfsdk:"id"`
+	SerialNumber       types.String            `tfsdk:"serial_number"`
+	StorageGroups      []StorageGroupDataModel `tfsdk:"storage_groups"`
+	Timeout            timeouts.Value          `tfsdk:"timeouts"`
+	StorageGroupFilter *sgFilterType           `tfsdk:"filter"`
+}
+
+// StorageGroupDataModel is a storage group of the data source, whose tags are the comma separated list of Unisphere.
+type StorageGroupDataModel struct {
+	StorageGroupResourceModel
+	Tags types.String `tfsdk:"tags"`
 }
 
 type sgFilterType struct {
 	IDs []types.String `tfsdk:"names"`
 }
+
+// StorageGroupSplitResourceModel describes the storage group split resource data model.
+type StorageGroupSplitResourceModel struct {
+	ID                types.String           `tfsdk:"id"`
+	SerialNumber      types.String           `tfsdk:"serial_number"`
+	StorageGroupID    types.String           `tfsdk:"storage_group_id"`
+	NewStorageGroupID types.String           `tfsdk:"new_storage_group_id"`
+	MaskingViewID     types.String           `tfsdk:"masking_view_id"`
+	VolumeIDs         types.Set              `tfsdk:"volume_ids"`
+	MergeOnDestroy    types.Bool             `tfsdk:"merge_on_destroy"`
+	Timeouts          resourcetimeouts.Value `tfsdk:"timeouts"`
+}
+
+// StorageGroupMergeResourceModel describes the storage group merge resource data model.
+type StorageGroupMergeResourceModel struct {
+	ID                   types.String           `tfsdk:"id"`
+	SerialNumber         types.String           `tfsdk:"serial_number"`
+	StorageGroupID       types.String           `tfsdk:"storage_group_id"`
+	MergedStorageGroupID types.String           `tfsdk:"merged_storage_group_id"`
+	VolumeIDs            types.Set              `tfsdk:"volume_ids"`
+	Timeouts             resourcetimeouts.Value `tfsdk:"timeouts"`
+}
@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TagsDataSourceModel describes the tags data source data model.
+type TagsDataSourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	SerialNumber types.String   `tfsdk:"serial_number"`
+	Tags         []Tag          `tfsdk:"tags"`
+	Timeout      timeouts.Value `tfsdk:"timeouts"`
+	//filter
+	TagFilter *tagFilterType `tfsdk:"filter"`
+}
+
+// Tag describes a tag and the objects carrying it.
+type Tag struct {
+	// Name - The name of the tag
+	Name types.String `tfsdk:"name"`
+	// StorageGroups - The storage groups of the array carrying the tag
+	StorageGroups types.List `tfsdk:"storage_groups"`
+	// ArrayIDs - The arrays of the Unisphere with storage groups carrying the tag
+	ArrayIDs types.List `tfsdk:"array_ids"`
+}
+
+type tagFilterType struct {
+	Names []types.String `tfsdk:"names"`
+}
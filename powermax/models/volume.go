@@ -19,12 +19,14 @@ package models
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	resourcetimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // VolumeResource holds volume schema attribute details.
 type VolumeResource struct {
 	ID                 types.String `tfsdk:"id"`
+	SerialNumber       types.String `tfsdk:"serial_number"`
 	StorageGroupName   types.String `tfsdk:"sg_name"`
 	VolumeIdentifier   types.String `tfsdk:"vol_name"`
 	Size               types.Number `tfsdk:"size"`
@@ -52,6 +54,9 @@ type VolumeResource struct {
 	OracleInstanceName types.String `tfsdk:"oracle_instance_name"`
 	SymmetrixPortKey   types.List   `tfsdk:"symmetrix_port_key"`
 	RDFGroupIDList     types.List   `tfsdk:"rdf_group_ids"`
+
+	// The timeouts of the operations on the volume.
+	Timeouts resourcetimeouts.Value `tfsdk:"timeouts"`
 }
 
 // VolumeDatasourceFilter holds volume datasource filter schema attribute details.
@@ -105,6 +110,7 @@ type VolumeDatasourceFilter struct {
 type VolumeDatasource struct {
 	// placeholder for acc testing
 	ID           types.String             `tfsdk:"id"`
+	SerialNumber types.String             `tfsdk:"serial_number"`
 	Volumes      []VolumeDatasourceEntity `tfsdk:"volumes"`
 	Timeout      timeouts.Value           `tfsdk:"timeouts"`
 	VolumeFilter *VolumeDatasourceFilter  `tfsdk:"filter"`
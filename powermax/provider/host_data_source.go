@@ -27,6 +27,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -69,7 +70,8 @@ func (d *HostDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest,
 		MarkdownDescription: "Data source for reading Hosts in PowerMax array. PowerMax hosts systems are storage hosts that use storage system logical unit number (LUN) resources. A LUN is an identifier that is used for labeling and designating subsystems of physical or virtual storage",
 		Description:         "Data source for reading Hosts in PowerMax array. PowerMax hosts systems are storage hosts that use storage system logical unit number (LUN) resources. A LUN is an identifier that is used for labeling and designating subsystems of physical or virtual storage",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description:         "Unique identifier of the host instance.",
 				MarkdownDescription: "Unique identifier of the host instance.",
@@ -81,6 +83,11 @@ func (d *HostDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest,
 				MarkdownDescription: "List of host attributes",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The serial number of the PowerMax array.",
+							MarkdownDescription: "The serial number of the PowerMax array.",
+						},
 						"id": schema.StringAttribute{
 							Computed:            true,
 							Description:         "The ID of the host.",
@@ -271,17 +278,16 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	var hostIds []string
 	// Get host IDs from config or query all if not specified
 	if state.HostFilter == nil || len(state.HostFilter.Names) == 0 {
 		// Read all the hosts
-		hostIDList, _, err := helper.GetHostList(ctx, *d.client)
+		hostIDList, _, err := helper.GetHostList(ctx, *pmaxClient)
 
 		if err != nil {
-			errStr := ""
-			msgStr := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Error reading host ids", msgStr)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading host ids", "", err, path.Empty()))
 			return
 		}
 		hostIds = hostIDList.HostId
@@ -294,7 +300,7 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	// iterate Host IDs and Get Host with each id
 	for _, id := range hostIds {
-		getHostReq := d.client.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, d.client.SymmetrixID, id)
+		getHostReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, pmaxClient.SymmetrixID, id)
 		hostResponse, _, err := getHostReq.Execute()
 		if err != nil || hostResponse == nil {
 			// Check to see if timeout was hit
@@ -302,19 +308,19 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 			if resp.Diagnostics.HasError() {
 				return
 			}
-			errStr := ""
-			msgStr := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Error reading host with id", msgStr)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading host with id", "", err, path.Empty()))
 			continue
 		}
 		var host models.HostModel
 		tflog.Debug(ctx, "Updating host state")
 		helper.UpdateHostState(&host, []string{}, hostResponse)
+		host.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 		state.Hosts = append(state.Hosts, host)
 	}
 
 	state.ID = types.StringValue("1")
 
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
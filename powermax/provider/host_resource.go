@@ -30,6 +30,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -47,6 +48,7 @@ import (
 var _ resource.Resource = &Host{}
 var _ resource.ResourceWithImportState = &Host{}
 var _ resource.ResourceWithConfigure = &Host{}
+var _ resource.ResourceWithValidateConfig = &Host{}
 
 // NewHost creates a new Host resource.
 func NewHost() resource.Resource {
@@ -231,7 +233,8 @@ func (r *Host) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 		Description:         "Resource for managing Host in PowerMax array. PowerMax hosts systems are storage hosts that use storage system logical unit number (LUN) resources. A LUN is an identifier that is used for labeling and designating subsystems of physical or virtual storage",
 
 		Attributes: map[string]schema.Attribute{
-
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The ID of the host.",
@@ -329,6 +332,31 @@ func (r *Host) Schema(ctx context.Context, req resource.SchemaRequest, resp *res
 	}
 }
 
+// ValidateConfig checks that the initiators are neither empty nor duplicated. Initiator
+// names are compared ignoring case, like Unisphere does.
+func (r *Host) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var initiators types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("initiator"), &initiators)...)
+	if resp.Diagnostics.HasError() || initiators.IsNull() || initiators.IsUnknown() {
+		return
+	}
+	seen := make(map[string]bool, len(initiators.Elements()))
+	for index, element := range initiators.Elements() {
+		initiator, ok := element.(types.String)
+		if !ok || initiator.IsUnknown() {
+			continue
+		}
+		value := strings.TrimSpace(initiator.ValueString())
+		switch {
+		case value == "":
+			resp.Diagnostics.AddAttributeError(path.Root("initiator").AtListIndex(index), "Invalid host configuration", "Empty initiator values are not allowed.")
+		case seen[strings.ToLower(value)]:
+			resp.Diagnostics.AddAttributeError(path.Root("initiator").AtListIndex(index), "Invalid host configuration", fmt.Sprintf("The initiator %s is listed more than once.", value))
+		}
+		seen[strings.ToLower(value)] = true
+	}
+}
+
 // Configure configure client for host resource.
 func (r *Host) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -353,13 +381,19 @@ func (r *Host) Configure(ctx context.Context, req resource.ConfigureRequest, res
 // Create creates a host and refresh state.
 func (r *Host) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Info(ctx, "Creating Host...")
-	var planHost models.HostModel
+	var planHost models.HostResource
 	diags := req.Plan.Get(ctx, &planHost)
 	// Read Terraform plan into the model
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, planHost.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(planHost.SerialNumber.ValueString())
 
 	initiators := make([]string, len(planHost.Initiators.Elements()))
 	if len(planHost.Initiators.Elements()) > 0 {
@@ -380,34 +414,26 @@ func (r *Host) Create(ctx context.Context, req resource.CreateRequest, resp *res
 		planHost.ConsistentLun.ValueBool(),
 	)
 
-	hostCreateReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.CreateHost(ctx, r.client.SymmetrixID)
+	hostCreateReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.CreateHost(ctx, pmaxClient.SymmetrixID)
 	createHostParam := pmax.NewCreateHostParam(planHost.Name.ValueString())
 	createHostParam.SetHostFlags(hostFlags)
 	createHostParam.SetInitiatorId(initiators)
 	hostCreateReq = hostCreateReq.CreateHostParam(*createHostParam)
-	hostCreateResp, _, err := r.client.PmaxOpenapiClient.SLOProvisioningApi.CreateHostExecute(hostCreateReq)
+	hostCreateResp, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.CreateHostExecute(hostCreateReq)
 	if err != nil {
 		hostID := planHost.Name.ValueString()
 
 		errStr := constants.CreateHostDetailErrorMsg + hostID + ": "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error creating host",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating host", errStr, err, path.Root("name")))
 
-		req := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, r.client.SymmetrixID, hostID)
+		req := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, pmaxClient.SymmetrixID, hostID)
 		hostGetResp, _, getHostErr := req.Execute()
 		if hostGetResp != nil || getHostErr == nil {
-			delReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteHost(ctx, r.client.SymmetrixID, hostID)
+			delReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteHost(ctx, pmaxClient.SymmetrixID, hostID)
 			_, err := delReq.Execute()
 			if err != nil {
 				errStr := constants.CreateHostDetailErrorMsg + hostID + "with error: "
-				message := helper.GetErrorString(err, errStr)
-				resp.Diagnostics.AddError(
-					"Error deleting the invalid host, This may be a dangling resource and needs to be deleted manually",
-					message,
-				)
+				resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting the invalid host, This may be a dangling resource and needs to be deleted manually", errStr, err, path.Empty()))
 			}
 		}
 		return
@@ -415,8 +441,9 @@ func (r *Host) Create(ctx context.Context, req resource.CreateRequest, resp *res
 	tflog.Debug(ctx, "create host response", map[string]interface{}{
 		"Create Host Response": hostCreateResp,
 	})
-	result := models.HostModel{}
-	helper.UpdateHostState(&result, initiators, hostCreateResp)
+	result := models.HostResource{Timeouts: planHost.Timeouts}
+	helper.UpdateHostState(&result.HostModel, initiators, hostCreateResp)
+	result.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -429,26 +456,28 @@ func (r *Host) Create(ctx context.Context, req resource.CreateRequest, resp *res
 // Delete Host.
 func (r *Host) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "deleting Host")
-	var hostState models.HostModel
+	var hostState models.HostResource
 	diags := req.State.Get(ctx, &hostState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, hostState.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(hostState.SerialNumber.ValueString())
 	hostID := hostState.HostID.ValueString()
 	tflog.Debug(ctx, "deleting host by host ID", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"hostID":      hostID,
 	})
-	delReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteHost(ctx, r.client.SymmetrixID, hostID)
+	delReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteHost(ctx, pmaxClient.SymmetrixID, hostID)
 	_, err := delReq.Execute()
 	if err != nil {
 		errStr := constants.DeleteHostDetailsErrorMsg + hostID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error deleting host",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting host", errStr, err, path.Empty()))
 	}
 
 	tflog.Info(ctx, "Delete host complete")
@@ -457,7 +486,7 @@ func (r *Host) Delete(ctx context.Context, req resource.DeleteRequest, resp *res
 // Update Host.
 func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Info(ctx, "updating host")
-	var plan models.HostModel
+	var plan models.HostResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -465,18 +494,24 @@ func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 	}
 	tflog.Info(ctx, "fetched host details from plan")
 
-	var state models.HostModel
+	var state models.HostResource
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	tflog.Debug(ctx, "calling update host on pmax client", map[string]interface{}{
 		"plan":  plan,
 		"state": state,
 	})
-	updatedParams, updateFailedParameters, errMessages := helper.UpdateHost(ctx, *r.client, plan, state)
+	updatedParams, updateFailedParameters, errMessages := helper.UpdateHost(ctx, *pmaxClient, plan.HostModel, state.HostModel)
 	if len(errMessages) > 0 || len(updateFailedParameters) > 0 {
 		errMessage := strings.Join(errMessages, ",\n")
 		resp.Diagnostics.AddError(
@@ -493,15 +528,11 @@ func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 	if helper.IsParamUpdated(updatedParams, "name") {
 		hostID = plan.Name.ValueString()
 	}
-	getReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, r.client.SymmetrixID, hostID)
+	getReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, pmaxClient.SymmetrixID, hostID)
 	hostResponse, _, err := getReq.Execute()
 	if err != nil {
 		errStr := constants.ReadHostDetailsErrorMsg + hostID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading host",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading host", errStr, err, path.Empty()))
 		return
 	}
 	tflog.Debug(ctx, "get host by ID response", map[string]interface{}{
@@ -528,7 +559,9 @@ func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 		"initiators":   initiators,
 		"hostResponse": hostResponse,
 	})
-	helper.UpdateHostState(&state, initiators, hostResponse)
+	helper.UpdateHostState(&state.HostModel, initiators, hostResponse)
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	state.Timeouts = plan.Timeouts
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -540,22 +573,31 @@ func (r *Host) Update(ctx context.Context, req resource.UpdateRequest, resp *res
 // Read Host.
 func (r *Host) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Info(ctx, "Reading Host...")
-	var hostState models.HostModel
+	var hostState models.HostResource
 	diags := req.State.Get(ctx, &hostState)
 	// Read Terraform prior state into the model
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, hostState.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(hostState.SerialNumber.ValueString())
 	hostID := hostState.HostID.ValueString()
-	host, _, err := helper.GetHost(ctx, *r.client, hostID)
+	host, _, err := helper.GetHost(ctx, *pmaxClient, hostID)
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Host not found, removing it from state", map[string]interface{}{
+				"id": hostID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		errStr := constants.ReadHostDetailsErrorMsg + hostID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading host",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading host", errStr, err, path.Empty()))
 		return
 	}
 	initiators := make([]string, len(hostState.Initiators.Elements()))
@@ -567,7 +609,8 @@ func (r *Host) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 	}
 
 	tflog.Debug(ctx, "Updating host state")
-	helper.UpdateHostState(&hostState, initiators, host)
+	helper.UpdateHostState(&hostState.HostModel, initiators, host)
+	hostState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, hostState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -580,23 +623,20 @@ func (r *Host) Read(ctx context.Context, req resource.ReadRequest, resp *resourc
 // ImportState imports the state of the resource from the req.
 func (r *Host) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "importing host state")
-	var hostState models.HostModel
-	hostID := req.ID
+	var hostState models.HostResource
+	serialNumber, hostID := helper.SplitImportID(req.ID)
+	pmaxClient := r.client.WithSerialNumber(serialNumber)
 	tflog.Debug(ctx, "fetching host by ID", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"hostID":      hostID,
 	})
 
-	getReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, r.client.SymmetrixID, hostID)
+	getReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHost(ctx, pmaxClient.SymmetrixID, hostID)
 	hostResponse, _, err := getReq.Execute()
 
 	if err != nil {
 		errStr := constants.ImportHostDetailsErrorMsg + hostID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading host",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading host", errStr, err, path.Empty()))
 		return
 	}
 	tflog.Debug(ctx, "Get Host By ID response", map[string]interface{}{
@@ -604,7 +644,9 @@ func (r *Host) ImportState(ctx context.Context, req resource.ImportStateRequest,
 	})
 
 	tflog.Debug(ctx, "updating host state after import")
-	helper.UpdateHostState(&hostState, hostResponse.Initiator, hostResponse)
+	helper.UpdateHostState(&hostState.HostModel, hostResponse.Initiator, hostResponse)
+	hostState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	hostState.Timeouts = helper.NullTimeouts(ctx)
 	diags := resp.State.Set(ctx, hostState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -57,7 +58,8 @@ func (d *hostGroupDataSource) Schema(ctx context.Context, _ datasource.SchemaReq
 		MarkdownDescription: "Data source for reading HostGroups in PowerMax array. PowerMax host groups are groups of PowerMax Hosts. see the host example for more information on hosts.",
 		Description:         "Data source for reading HostGroups in PowerMax array. PowerMax host groups are groups of PowerMax Hosts. see the host example for more information on hosts.",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -188,24 +190,21 @@ func (d *hostGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(plan.SerialNumber.ValueString())
 
 	// Apply Filter hostgroup filter
-	hostGroupIDs, err := helper.FilterHostGroupIds(ctx, &state, &plan, *d.client)
+	hostGroupIDs, err := helper.FilterHostGroupIds(ctx, &state, &plan, *pmaxClient)
 
 	if err != nil {
 		errStr := constants.ReadHostGroupListDetailsErrorMsg + "with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error getting the list of host group ids",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the list of host group ids", errStr, err, path.Empty()))
 		return
 	}
 
 	// Get details of each of the hostgroups
 	for _, hostGroupID := range hostGroupIDs {
 		tflog.Debug(ctx, hostGroupID)
-		groupDetailModel := d.client.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, d.client.SymmetrixID, hostGroupID)
+		groupDetailModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, pmaxClient.SymmetrixID, hostGroupID)
 		groupDetail, _, err := groupDetailModel.Execute()
 		if err != nil {
 			// Check to see if timeout was hit
@@ -214,11 +213,7 @@ func (d *hostGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 				return
 			}
 			errStr := constants.ReadHostGroupListDetailsErrorMsg + "with error: "
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError(
-				"Error getting the details of host group: "+hostGroupID,
-				message,
-			)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the details of host group: "+hostGroupID, errStr, err, path.Empty()))
 			return
 		}
 		model, diag := helper.HostGroupDetailMapper(groupDetail)
@@ -230,6 +225,7 @@ func (d *hostGroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 	state.Timeout = plan.Timeout
 	state.ID = types.StringValue("HostGroupDatasoure")
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
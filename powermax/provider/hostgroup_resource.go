@@ -31,6 +31,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -45,9 +46,10 @@ import (
 
 // Ensure implementation.
 var (
-	_ resource.Resource                = &HostGroup{}
-	_ resource.ResourceWithConfigure   = &HostGroup{}
-	_ resource.ResourceWithImportState = &HostGroup{}
+	_ resource.Resource                   = &HostGroup{}
+	_ resource.ResourceWithConfigure      = &HostGroup{}
+	_ resource.ResourceWithImportState    = &HostGroup{}
+	_ resource.ResourceWithValidateConfig = &HostGroup{}
 )
 
 // NewHostGroup is a helper function to simplify the provider implementation.
@@ -226,6 +228,8 @@ func (r *HostGroup) Schema(ctx context.Context, req resource.SchemaRequest, resp
 		MarkdownDescription: "Resource for managing HostGroups for a PowerMax Array. PowerMax host groups are groups of PowerMax Hosts. see the host example for more information on hosts.",
 		Description:         "Resource for managing HostGroups for a PowerMax Array. PowerMax host groups are groups of PowerMax Hosts. see the host example for more information on hosts.",
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The ID of the hostgroup.",
@@ -303,6 +307,21 @@ func (r *HostGroup) Schema(ctx context.Context, req resource.SchemaRequest, resp
 	}
 }
 
+// ValidateConfig checks that host_ids has no empty value.
+func (r *HostGroup) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var hostIDs types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("host_ids"), &hostIDs)...)
+	if resp.Diagnostics.HasError() || hostIDs.IsNull() || hostIDs.IsUnknown() {
+		return
+	}
+	for _, element := range hostIDs.Elements() {
+		hostID, ok := element.(types.String)
+		if ok && !hostID.IsUnknown() && strings.TrimSpace(hostID.ValueString()) == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("host_ids").AtSetValue(hostID), "Invalid host group configuration", "host_ids can not have an empty \"\" value.")
+		}
+	}
+}
+
 // Configure the HostGroup resource.
 func (r *HostGroup) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if provider is not config
@@ -331,6 +350,12 @@ func (r *HostGroup) Create(ctx context.Context, req resource.CreateRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
 
 	hostIds := make([]string, len(plan.HostIDs.Elements()))
 	diags := plan.HostIDs.ElementsAs(ctx, &hostIds, true)
@@ -351,13 +376,13 @@ func (r *HostGroup) Create(ctx context.Context, req resource.CreateRequest, resp
 	hostFlags := helper.HandleHostFlag(plan)
 
 	tflog.Info(ctx, "calling create hostgroup with client", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"host":        plan.Name.ValueString(),
 		"hostIds":     hostIds,
 		"hostFlags":   hostFlags,
 	})
 
-	newHgModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.CreateHostGroup(ctx, r.client.SymmetrixID)
+	newHgModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.CreateHostGroup(ctx, pmaxClient.SymmetrixID)
 	create := powermax.NewCreateHostGroupParam(plan.Name.ValueString())
 	create.SetHostFlags(hostFlags)
 	create.SetHostId(hostIds)
@@ -367,24 +392,19 @@ func (r *HostGroup) Create(ctx context.Context, req resource.CreateRequest, resp
 	if err != nil {
 		hostgroupID := plan.Name.ValueString()
 		errStr := constants.CreateHostGroupDetailErrorMsg + hostgroupID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error creating hostgroup", message)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating hostgroup", errStr, err, path.Root("name")))
 		if err != nil {
 			tflog.Debug(ctx, err.Error())
 		}
 		//Attempt to remove any partially created obejcts if there are any
-		hgModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, r.client.SymmetrixID, hostgroupID)
+		hgModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, pmaxClient.SymmetrixID, hostgroupID)
 		hostGroupResponse, _, getHostGroupErr := hgModel.Execute()
 		if hostGroupResponse != nil || getHostGroupErr == nil {
-			deleteModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteHostGroup(ctx, r.client.SymmetrixID, hostgroupID)
+			deleteModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteHostGroup(ctx, pmaxClient.SymmetrixID, hostgroupID)
 			_, err := deleteModel.Execute()
 			if err != nil {
 				errStr := constants.CreateHostGroupDetailErrorMsg + hostgroupID + "with error: "
-				message := helper.GetErrorString(err, errStr)
-				resp.Diagnostics.AddError(
-					"Error deleting the invalid hostGroup, This may be a dangling resource and needs to be deleted manually",
-					message,
-				)
+				resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting the invalid hostGroup, This may be a dangling resource and needs to be deleted manually", errStr, err, path.Empty()))
 			}
 		}
 		return
@@ -399,6 +419,8 @@ func (r *HostGroup) Create(ctx context.Context, req resource.CreateRequest, resp
 		"newHostGroup": newHostGroup,
 	})
 	helper.UpdateHostGroupState(&state, newHostGroup)
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -414,24 +436,33 @@ func (r *HostGroup) Read(ctx context.Context, req resource.ReadRequest, resp *re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	hostGroupID := state.ID.ValueString()
 	tflog.Debug(ctx, "fetching hostgroup by ID", map[string]interface{}{
-		"symmetricxId": r.client.SymmetrixID,
+		"symmetricxId": pmaxClient.SymmetrixID,
 		"hostGroupID":  hostGroupID,
 	})
-	hgModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, r.client.SymmetrixID, hostGroupID)
+	hgModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, pmaxClient.SymmetrixID, hostGroupID)
 	hgResponse, resp1, err := hgModel.Execute()
 	tflog.Debug(ctx, "Get HostGroup By ID response", map[string]interface{}{
 		"HostGroup Response": hgResponse,
 	})
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Host group not found, removing it from state", map[string]interface{}{
+				"id": hostGroupID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		errStr := constants.ReadHostGroupDetailsErrorMsg + hostGroupID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading hostGroup",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading hostGroup", errStr, err, path.Empty()))
 		return
 	}
 	if resp1.StatusCode != http.StatusOK {
@@ -443,6 +474,7 @@ func (r *HostGroup) Read(ctx context.Context, req resource.ReadRequest, resp *re
 	}
 	tflog.Debug(ctx, "Updating Hostgroup State")
 	helper.UpdateHostGroupState(&state, hgResponse)
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -469,12 +501,18 @@ func (r *HostGroup) Update(ctx context.Context, req resource.UpdateRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, planHostGroup.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(stateHostGroup.SerialNumber.ValueString())
 
 	tflog.Debug(ctx, "calling update hostgroup on pmax client", map[string]interface{}{
 		"plan":  planHostGroup,
 		"state": stateHostGroup,
 	})
-	updatedParams, updateFailedParameters, errMessages := helper.UpdateHostGroup(ctx, *r.client, planHostGroup, stateHostGroup)
+	updatedParams, updateFailedParameters, errMessages := helper.UpdateHostGroup(ctx, *pmaxClient, planHostGroup, stateHostGroup)
 	if len(errMessages) > 0 || len(updateFailedParameters) > 0 {
 		errMessage := strings.Join(errMessages, ",\n")
 		resp.Diagnostics.AddError(
@@ -493,18 +531,14 @@ func (r *HostGroup) Update(ctx context.Context, req resource.UpdateRequest, resp
 	}
 
 	tflog.Debug(ctx, "calling get hostgroup by ID on pmax client", map[string]interface{}{
-		"SymmetrixID": r.client.SymmetrixID,
+		"SymmetrixID": pmaxClient.SymmetrixID,
 		"hostgroupID": hostGroupID,
 	})
-	hgModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, r.client.SymmetrixID, hostGroupID)
+	hgModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, pmaxClient.SymmetrixID, hostGroupID)
 	hostGroupResponse, resp1, err := hgModel.Execute()
 	if err != nil {
 		errStr := constants.ReadHostGroupDetailsErrorMsg + hostGroupID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading hostgroup",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading hostgroup", errStr, err, path.Empty()))
 		return
 	}
 	if resp1.StatusCode != http.StatusOK {
@@ -520,6 +554,8 @@ func (r *HostGroup) Update(ctx context.Context, req resource.UpdateRequest, resp
 		"hostGroupResponse": hostGroupResponse,
 	})
 	helper.UpdateHostGroupState(&stateHostGroup, hostGroupResponse)
+	stateHostGroup.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	stateHostGroup.Timeouts = planHostGroup.Timeouts
 	diags = resp.State.Set(ctx, stateHostGroup)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -537,20 +573,22 @@ func (r *HostGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, hostGroupState.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(hostGroupState.SerialNumber.ValueString())
 	hostGroupID := hostGroupState.ID.ValueString()
 	tflog.Debug(ctx, "deleting hostgroup by hostgroup ID", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"hostGroupID": hostGroupID,
 	})
-	deleteModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteHostGroup(ctx, r.client.SymmetrixID, hostGroupID)
+	deleteModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteHostGroup(ctx, pmaxClient.SymmetrixID, hostGroupID)
 	_, err := deleteModel.Execute()
 	if err != nil {
 		errStr := constants.DeleteHostGroupDetailsErrorMsg + hostGroupID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error deleting hostGroup",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting hostGroup", errStr, err, path.Empty()))
 	}
 
 	tflog.Info(ctx, "delete hostgroup complete")
@@ -560,20 +598,17 @@ func (r *HostGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp
 func (r *HostGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "Importing Hostgroup State")
 	var hostGroupState models.HostGroupModel
-	hostGroupID := req.ID
+	serialNumber, hostGroupID := helper.SplitImportID(req.ID)
+	pmaxClient := r.client.WithSerialNumber(serialNumber)
 	tflog.Debug(ctx, "fetching Hostgroup by ID", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"hostID":      hostGroupID,
 	})
-	hgModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, r.client.SymmetrixID, hostGroupID)
+	hgModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetHostGroup(ctx, pmaxClient.SymmetrixID, hostGroupID)
 	hostGroupResponse, resp1, err := hgModel.Execute()
 	if err != nil {
 		errStr := constants.ImportHostGroupDetailsErrorMsg + hostGroupID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading hostgroup",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading hostgroup", errStr, err, path.Empty()))
 		return
 	}
 	if resp1.StatusCode != http.StatusOK {
@@ -589,6 +624,8 @@ func (r *HostGroup) ImportState(ctx context.Context, req resource.ImportStateReq
 
 	tflog.Debug(ctx, "updating hostgroup state after import")
 	helper.UpdateHostGroupState(&hostGroupState, hostGroupResponse)
+	hostGroupState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	hostGroupState.Timeouts = helper.NullTimeouts(ctx)
 	diags := resp.State.Set(ctx, hostGroupState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
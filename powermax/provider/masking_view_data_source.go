@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -68,7 +69,8 @@ func (d *maskingViewDataSource) Schema(ctx context.Context, req datasource.Schem
 		Description:         "Data source for reading Masking Views in PowerMax array. PowerMax masking views are a container of a storage group, a port group, and an initiator group, and makes the storage group visible to the host. Devices are masked and mapped automatically. The groups must contain some device entries.",
 
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "Unique identifier of the masking view instance.",
@@ -183,28 +185,24 @@ func (d *maskingViewDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	var maskingViewIds []string
 	// Get masking view IDs from config or query all if not specified
 	if state.MaskingViewFilter == nil || len(state.MaskingViewFilter.Names) == 0 {
 		// Read all the masking views
-		tflog.Debug(ctx, fmt.Sprintf("Calling api to get MaskingViewList for Symmetrix - %s", d.client.SymmetrixID))
-		maskingViews := d.client.PmaxOpenapiClient.SLOProvisioningApi.ListMaskingViews(ctx, d.client.SymmetrixID)
-		maskingViewList, _, err := d.client.PmaxOpenapiClient.SLOProvisioningApi.ListMaskingViewsExecute(maskingViews)
+		tflog.Debug(ctx, fmt.Sprintf("Calling api to get MaskingViewList for Symmetrix - %s", pmaxClient.SymmetrixID))
+		maskingViews := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListMaskingViews(ctx, pmaxClient.SymmetrixID)
+		maskingViewList, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListMaskingViewsExecute(maskingViews)
 
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError(
-				"Unable to Get PowerMax Masking View List",
-				message,
-			)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Unable to Get PowerMax Masking View List", "", err, path.Empty()))
 
 			return
 		}
 		maskingViewIds = maskingViewList.MaskingViewId
 	} else {
-		tflog.Debug(ctx, fmt.Sprintf("Get masking view Ids from filter for Symmetrix - %s", d.client.SymmetrixID))
+		tflog.Debug(ctx, fmt.Sprintf("Get masking view Ids from filter for Symmetrix - %s", pmaxClient.SymmetrixID))
 		// get ids from filter and assign to maskingViewIds
 		for _, name := range state.MaskingViewFilter.Names {
 			maskingViewIds = append(maskingViewIds, name.ValueString())
@@ -223,6 +221,7 @@ func (d *maskingViewDataSource) Read(ctx context.Context, req datasource.ReadReq
 	state.MaskingViews = models
 	state.ID = types.StringValue("placeholder")
 
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -297,12 +296,7 @@ func (d *maskingViewDataSource) getMaskingViewToConnections(ctx context.Context,
 				if err != nil {
 					lockMutex.Lock()
 					defer lockMutex.Unlock()
-					errStr := ""
-					message := helper.GetErrorString(err, errStr)
-					resp.Diagnostics.AddError(
-						fmt.Sprintf("Failed to get MaskingViewConnections - %s.", mv.MaskingViewId),
-						message,
-					)
+					resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Failed to get MaskingViewConnections - %s.", mv.MaskingViewId), "", err, path.Empty()))
 					return
 				}
 
@@ -347,12 +341,7 @@ func (d *maskingViewDataSource) getMaskingViews(ctx context.Context, resp *datas
 				if err != nil {
 					lockMutex.Lock()
 					defer lockMutex.Unlock()
-					errStr := ""
-					message := helper.GetErrorString(err, errStr)
-					resp.Diagnostics.AddError(
-						fmt.Sprintf("Failed to get MaskingView - %s.", id),
-						message,
-					)
+					resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Failed to get MaskingView - %s.", id), "", err, path.Empty()))
 					return
 				}
 
@@ -37,9 +37,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &maskingView{}
-	_ resource.ResourceWithConfigure   = &maskingView{}
-	_ resource.ResourceWithImportState = &maskingView{}
+	_ resource.Resource                   = &maskingView{}
+	_ resource.ResourceWithConfigure      = &maskingView{}
+	_ resource.ResourceWithImportState    = &maskingView{}
+	_ resource.ResourceWithValidateConfig = &maskingView{}
 )
 
 // NewMaskingView returns the masking view resource object.
@@ -63,6 +64,8 @@ func (r *maskingView) Schema(ctx context.Context, req resource.SchemaRequest, re
 		Description:         "Resource for managing MaskingViews in PowerMax array. PowerMax masking views are a container of a storage group, a port group, and an initiator group, and makes the storage group visible to the host. Devices are masked and mapped automatically. The groups must contain some device entries.",
 
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The ID of the masking view.",
@@ -111,6 +114,30 @@ func (r *maskingView) Schema(ctx context.Context, req resource.SchemaRequest, re
 	}
 }
 
+// ValidateConfig checks that exactly one of host_id and host_group_id is set, the other one being empty.
+func (r *maskingView) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var hostID, hostGroupID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("host_id"), &hostID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("host_group_id"), &hostGroupID)...)
+	if resp.Diagnostics.HasError() || hostID.IsUnknown() || hostGroupID.IsUnknown() {
+		return
+	}
+	switch {
+	case hostID.ValueString() != "" && hostGroupID.ValueString() != "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host_group_id"),
+			"Invalid masking view configuration",
+			"Specify either host_id or host_group_id, and set the other one to \"\".",
+		)
+	case hostID.ValueString() == "" && hostGroupID.ValueString() == "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host_id"),
+			"Invalid masking view configuration",
+			"Specify either host_id or host_group_id, both are empty.",
+		)
+	}
+}
+
 func (r *maskingView) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -140,6 +167,12 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
 	var hostOrHostGroupID string
 	var isHost = false
 	if plan.HostID.ValueString() != "" && plan.HostGroupID.ValueString() == "" {
@@ -157,12 +190,10 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 
 	tflog.Debug(ctx, fmt.Sprintf("Calling api to create MaskingView - %s", plan.Name.ValueString()))
 
-	maskingView, _, err := helper.CreateMaskingView(ctx, *r.client, plan, hostOrHostGroupID, isHost)
+	maskingView, _, err := helper.CreateMaskingView(ctx, *pmaxClient, plan, hostOrHostGroupID, isHost)
 
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error creating masking view", message)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating masking view", "", err, path.Root("name")))
 
 		return
 	}
@@ -172,14 +203,12 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 	})
 
 	tflog.Debug(ctx, fmt.Sprintf("Calling api to get MaskingView - %s", plan.Name.ValueString()))
-	maskingView, _, err = helper.GetMaskingView(ctx, *r.client, plan.Name.ValueString())
+	maskingView, _, err = helper.GetMaskingView(ctx, *pmaxClient, plan.Name.ValueString())
 
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error reading masking view", message)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading masking view", "", err, path.Empty()))
 		// Attempt to clean up the errored masking view after the host/hostgroup mistake
-		_, delErr := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, r.client.SymmetrixID, plan.Name.ValueString()).Execute()
+		_, delErr := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, pmaxClient.SymmetrixID, plan.Name.ValueString()).Execute()
 		if delErr != nil {
 			errStr := ""
 			message := helper.GetErrorString(delErr, errStr)
@@ -191,7 +220,7 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 	err = helper.CopyFields(ctx, maskingView, &plan)
 	if err != nil {
 		// Attempt to clean up the errored masking view after the host/hostgroup mistake
-		_, delErr := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, r.client.SymmetrixID, plan.Name.ValueString()).Execute()
+		_, delErr := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, pmaxClient.SymmetrixID, plan.Name.ValueString()).Execute()
 		if delErr != nil {
 			errStr := ""
 			message := helper.GetErrorString(delErr, errStr)
@@ -205,7 +234,7 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 	if plan.HostGroupID.ValueString() != "" && maskingView.HostId != nil {
 		resp.Diagnostics.AddError("Error creating masking view", fmt.Sprintf("The host_group_id '%s' is actually a host_id, change '%s' to host_id to create a masking view with this host", plan.HostGroupID, plan.HostGroupID))
 		// Attempt to clean up the errored masking view after the host/hostgroup mistake
-		_, err := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, r.client.SymmetrixID, plan.Name.ValueString()).Execute()
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, pmaxClient.SymmetrixID, plan.Name.ValueString()).Execute()
 		if err != nil {
 			errStr := ""
 			message := helper.GetErrorString(err, errStr)
@@ -217,7 +246,7 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 	if plan.HostID.ValueString() != "" && maskingView.HostGroupId != nil {
 		resp.Diagnostics.AddError("Error creating masking view", fmt.Sprintf("The host_id '%s' is actually a host_group_id, change '%s' to host_group_id to create a masking view with this host_group", plan.HostID, plan.HostID))
 		// Attempt to clean up the errored masking view after the host/hostgroup mistake
-		_, err := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, r.client.SymmetrixID, plan.Name.ValueString()).Execute()
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, pmaxClient.SymmetrixID, plan.Name.ValueString()).Execute()
 		if err != nil {
 			errStr := ""
 			message := helper.GetErrorString(err, errStr)
@@ -235,6 +264,7 @@ func (r *maskingView) Create(ctx context.Context, req resource.CreateRequest, re
 	plan.PortGroupID = types.StringValue(*maskingView.PortGroupId)
 	plan.Name = types.StringValue(maskingView.MaskingViewId)
 	plan.ID = types.StringValue(maskingView.MaskingViewId)
+	plan.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -253,15 +283,40 @@ func (r *maskingView) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	tflog.Debug(ctx, fmt.Sprintf("Calling api to get MaskingView - %s", state.Name.ValueString()))
-	getMaskingViewReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetMaskingView(ctx, r.client.SymmetrixID, state.Name.ValueString())
+	getMaskingViewReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetMaskingView(ctx, pmaxClient.SymmetrixID, state.Name.ValueString())
 	maskingView, _, err := getMaskingViewReq.Execute()
 
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error reading masking view", message)
+		if client.IsNotFound(err) {
+			// A merge deletes the masking view of the storage group it moved the volumes out of, keep it until it is
+			// removed from the configuration
+			merged, err := helper.IsMergedMaskingView(ctx, *pmaxClient, state.StorageGroupID.ValueString(),
+				state.HostID.ValueString()+state.HostGroupID.ValueString(), state.PortGroupID.ValueString())
+			if err != nil {
+				resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading masking view", "", err, path.Empty()))
+				return
+			}
+			if merged {
+				resp.Diagnostics.AddWarning("Masking view merged",
+					fmt.Sprintf("The storage group of the masking view %s was merged into another storage group, remove it from the configuration.",
+						state.Name.ValueString()))
+				return
+			}
+			tflog.Warn(ctx, "Masking view not found, removing it from state", map[string]interface{}{
+				"id": state.Name.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading masking view", "", err, path.Empty()))
 
 		return
 	}
@@ -282,6 +337,7 @@ func (r *maskingView) Read(ctx context.Context, req resource.ReadRequest, resp *
 	state.PortGroupID = types.StringValue(*maskingView.PortGroupId)
 	state.Name = types.StringValue(maskingView.MaskingViewId)
 	state.ID = types.StringValue(maskingView.MaskingViewId)
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 
@@ -304,6 +360,12 @@ func (r *maskingView) Update(ctx context.Context, req resource.UpdateRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	// prompt error on change in maskingView's hostGroup, portGroup or storageGroup, as we can't update the them after the creation
 	if !plan.StorageGroupID.Equal(state.StorageGroupID) || !plan.PortGroupID.Equal(state.PortGroupID) || !plan.HostID.Equal(state.HostID) ||
@@ -323,26 +385,22 @@ func (r *maskingView) Update(ctx context.Context, req resource.UpdateRequest, re
 		rename := pmax.EditMaskingViewActionParam{
 			RenameMaskingViewParam: renameMaskingViewParam,
 		}
-		modifyReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.ModifyMaskingView(ctx, r.client.SymmetrixID, state.Name.ValueString())
+		modifyReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ModifyMaskingView(ctx, pmaxClient.SymmetrixID, state.Name.ValueString())
 		editParam := pmax.NewEditMaskingViewParam(rename)
 		modifyReq = modifyReq.EditMaskingViewParam(*editParam)
 		_, _, err := modifyReq.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Error renaming masking view", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error renaming masking view", "", err, path.Root("name")))
 
 			return
 		}
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Calling api to get MaskingView - %s", plan.Name.ValueString()))
-	getMaskingViewReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetMaskingView(ctx, r.client.SymmetrixID, plan.Name.ValueString())
+	getMaskingViewReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetMaskingView(ctx, pmaxClient.SymmetrixID, plan.Name.ValueString())
 	maskingView, _, err := getMaskingViewReq.Execute()
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error reading masking view", message)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading masking view", "", err, path.Empty()))
 		return
 	}
 
@@ -362,6 +420,8 @@ func (r *maskingView) Update(ctx context.Context, req resource.UpdateRequest, re
 	state.PortGroupID = types.StringValue(*maskingView.PortGroupId)
 	state.Name = types.StringValue(maskingView.MaskingViewId)
 	state.ID = types.StringValue(maskingView.MaskingViewId)
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	state.Timeouts = plan.Timeouts
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 
@@ -378,14 +438,18 @@ func (r *maskingView) Delete(ctx context.Context, req resource.DeleteRequest, re
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	tflog.Debug(ctx, fmt.Sprintf("Calling api to delete MaskingView - %s", state.Name.ValueString()))
-	delReq := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, r.client.SymmetrixID, state.Name.ValueString())
+	delReq := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, pmaxClient.SymmetrixID, state.Name.ValueString())
 	_, err := delReq.Execute()
-	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete masking view, got error: %s", message))
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Client Error", "Unable to delete masking view, got error:", err, path.Empty()))
 		return
 	}
 
@@ -395,5 +459,9 @@ func (r *maskingView) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *maskingView) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	serialNumber, id := helper.SplitImportID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id)...)
+	if serialNumber != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("serial_number"), serialNumber)...)
+	}
 }
@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The TestAccMockUnisphere* tests run the lifecycle of the resources against the in-memory Unisphere,
+// they are skipped unless POWERMAX_MOCK_UNISPHERE is true. The tests of a single resource live with
+// its other tests, the ones in this file cover several resources together.
+
+func TestAccMockUnisphereProvisioning(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: ProviderConfig + mockProvisioningConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_host.mock", "name", "test_acc_mock_host"),
+					resource.TestCheckResourceAttr("powermax_host.mock", "num_of_initiators", "1"),
+					resource.TestCheckResourceAttr("powermax_hostgroup.mock", "numofhosts", "1"),
+					resource.TestCheckResourceAttr("powermax_hostgroup.mock", "host_flags.avoid_reset_broadcast.enabled", "true"),
+					resource.TestCheckResourceAttr("powermax_portgroup.mock", "numofports", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.mock", "slo", "Gold"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.mock", "num_of_vols", "0"),
+					resource.TestCheckResourceAttr("powermax_volume.mock", "size", "2"),
+					resource.TestCheckResourceAttr("powermax_volume.mock", "sg_name", "test_acc_mock_sg"),
+					resource.TestCheckResourceAttr("powermax_volume.mock", "timeouts.create", "5m"),
+					resource.TestCheckResourceAttr("powermax_maskingview.mock", "host_group_id", "test_acc_mock_hg"),
+					resource.TestCheckResourceAttr("powermax_maskingview.mock", "storage_group_id", "test_acc_mock_sg"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "powermax_portgroup.mock",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"protocol"},
+			},
+			{
+				ResourceName:      "powermax_maskingview.mock",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: ProviderConfig + mockProvisioningUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_host.mock", "name", "test_acc_mock_host_upd"),
+					resource.TestCheckResourceAttr("powermax_hostgroup.mock", "host_flags.avoid_reset_broadcast.enabled", "false"),
+					resource.TestCheckResourceAttr("powermax_portgroup.mock", "numofports", "2"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.mock", "slo", "Silver"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.mock", "host_io_limit.host_io_limit_io_sec", "2000"),
+					resource.TestCheckResourceAttr("powermax_volume.mock", "vol_name", "test_acc_mock_vol_upd"),
+					resource.TestCheckResourceAttr("powermax_volume.mock", "size", "4"),
+					resource.TestCheckResourceAttr("powermax_maskingview.mock", "name", "test_acc_mock_mv_upd"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccMockUnisphereReplication(t *testing.T) {
+	var snapshotTerraformName = "powermax_snapshot.mock"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: ProviderConfig + mockReplicationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "name", "test_acc_mock_snapshot"),
+					resource.TestCheckResourceAttr(snapshotTerraformName, "num_source_volumes", "1"),
+					resource.TestCheckResourceAttr("powermax_snapshotpolicy.mock", "snapshot_policy_name", "test_acc_mock_sp"),
+					resource.TestCheckResourceAttr("powermax_snapshotpolicy.mock", "interval", "1 Day"),
+					resource.TestCheckResourceAttr("powermax_snapshotpolicy.mock", "storage_groups.#", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      snapshotTerraformName,
+				ImportStateId:     "test_acc_mock_snapshot_sg.test_acc_mock_snapshot",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The actions are not part of the snapshot returned by Unisphere
+				ImportStateVerifyIgnore: []string{"snapshot_actions"},
+			},
+			// Link the snapshot and associate the policy, then Read testing
+			{
+				Config: ProviderConfig + mockReplicationUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "linked", "true"),
+					resource.TestCheckResourceAttr("powermax_snapshotpolicy.mock", "snapshot_policy_name", "test_acc_mock_sp_upd"),
+					resource.TestCheckResourceAttr("powermax_snapshotpolicy.mock", "storage_groups.#", "1"),
+				),
+			},
+			// Unlink before the target storage group is deleted
+			{
+				Config: ProviderConfig + mockReplicationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(snapshotTerraformName, "linked", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+var mockProvisioningConfig = `
+resource "powermax_host" "mock" {
+	name           = "test_acc_mock_host"
+	initiator      = ["10000000c9000001"]
+	host_flags     = {}
+}
+
+resource "powermax_hostgroup" "mock" {
+	name       = "test_acc_mock_hg"
+	host_ids   = [powermax_host.mock.id]
+	host_flags = {
+		avoid_reset_broadcast = {
+			enabled  = true
+			override = true
+		}
+	}
+}
+
+resource "powermax_portgroup" "mock" {
+	name     = "test_acc_mock_pg"
+	protocol = "SCSI_FC"
+	ports = [
+		{
+			director_id = "OR-1C"
+			port_id     = "0"
+		}
+	]
+}
+
+resource "powermax_storagegroup" "mock" {
+	name   = "test_acc_mock_sg"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+	host_io_limit = {
+		host_io_limit_io_sec = "1000"
+		host_io_limit_mb_sec = "1000"
+		dynamic_distribution = "Never"
+	}
+	lifecycle {
+		ignore_changes = [volume_ids]
+	}
+}
+
+resource "powermax_volume" "mock" {
+	vol_name = "test_acc_mock_vol"
+	size     = 2
+	cap_unit = "GB"
+	sg_name  = powermax_storagegroup.mock.id
+	timeouts = {
+		create = "5m"
+		update = "5m"
+	}
+}
+
+resource "powermax_maskingview" "mock" {
+	name             = "test_acc_mock_mv"
+	storage_group_id = powermax_storagegroup.mock.id
+	host_id          = ""
+	host_group_id    = powermax_hostgroup.mock.id
+	port_group_id    = powermax_portgroup.mock.id
+}
+`
+
+var mockProvisioningUpdateConfig = `
+resource "powermax_host" "mock" {
+	name           = "test_acc_mock_host_upd"
+	initiator      = ["10000000c9000001", "10000000c9000002"]
+	host_flags     = {}
+}
+
+resource "powermax_hostgroup" "mock" {
+	name       = "test_acc_mock_hg"
+	host_ids   = [powermax_host.mock.id]
+	host_flags = {}
+}
+
+resource "powermax_portgroup" "mock" {
+	name     = "test_acc_mock_pg"
+	protocol = "SCSI_FC"
+	ports = [
+		{
+			director_id = "OR-1C"
+			port_id     = "0"
+		},
+		{
+			director_id = "OR-2C"
+			port_id     = "1"
+		}
+	]
+}
+
+resource "powermax_storagegroup" "mock" {
+	name   = "test_acc_mock_sg"
+	srp_id = "SRP_1"
+	slo    = "Silver"
+	host_io_limit = {
+		host_io_limit_io_sec = "2000"
+		host_io_limit_mb_sec = "2000"
+		dynamic_distribution = "Never"
+	}
+	lifecycle {
+		ignore_changes = [volume_ids]
+	}
+}
+
+resource "powermax_volume" "mock" {
+	vol_name = "test_acc_mock_vol_upd"
+	size     = 4
+	cap_unit = "GB"
+	sg_name  = powermax_storagegroup.mock.id
+	timeouts = {
+		create = "5m"
+		update = "5m"
+	}
+}
+
+resource "powermax_maskingview" "mock" {
+	name             = "test_acc_mock_mv_upd"
+	storage_group_id = powermax_storagegroup.mock.id
+	host_id          = ""
+	host_group_id    = powermax_hostgroup.mock.id
+	port_group_id    = powermax_portgroup.mock.id
+}
+`
+
+var mockReplicationStorageConfig = `
+resource "powermax_storagegroup" "source" {
+	name   = "test_acc_mock_snapshot_sg"
+	srp_id = "SRP_1"
+	slo    = "Diamond"
+	lifecycle {
+		ignore_changes = [volume_ids]
+	}
+}
+
+resource "powermax_volume" "source" {
+	vol_name = "test_acc_mock_snapshot_vol"
+	size     = 1
+	cap_unit = "GB"
+	sg_name  = powermax_storagegroup.source.id
+}
+
+resource "powermax_storagegroup" "target" {
+	name   = "test_acc_mock_target_sg"
+	srp_id = "SRP_1"
+	slo    = "Diamond"
+}
+`
+
+var mockReplicationConfig = mockReplicationStorageConfig + `
+resource "powermax_snapshot" "mock" {
+	storage_group {
+		name = powermax_volume.source.sg_name
+	}
+	snapshot_actions {
+		name = "test_acc_mock_snapshot"
+	}
+}
+
+resource "powermax_snapshotpolicy" "mock" {
+	snapshot_policy_name = "test_acc_mock_sp"
+	interval             = "1 Day"
+}
+`
+
+var mockReplicationUpdateConfig = mockReplicationStorageConfig + `
+resource "powermax_snapshot" "mock" {
+	storage_group {
+		name = powermax_volume.source.sg_name
+	}
+	snapshot_actions {
+		name = "test_acc_mock_snapshot"
+		link = {
+			enable               = true
+			target_storage_group = powermax_storagegroup.target.id
+			no_compression       = true
+			remote               = false
+			copy                 = false
+		}
+	}
+}
+
+resource "powermax_snapshotpolicy" "mock" {
+	snapshot_policy_name = "test_acc_mock_sp_upd"
+	interval             = "1 Day"
+	storage_groups       = [powermax_storagegroup.source.id]
+}
+`
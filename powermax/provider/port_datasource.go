@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -57,7 +58,8 @@ func (d *portDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest,
 		MarkdownDescription: "Data source for reading ports in PowerMax array. A port typically refers to the interface that allows for connections between the PowerMax system and other devices.",
 		Description:         "Data source for reading ports in PowerMax array. A port typically refers to the interface that allows for connections between the PowerMax system and other devices.",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -442,19 +444,16 @@ func (d *portDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(plan.SerialNumber.ValueString())
 
-	portIds, err := helper.FilterPortIds(ctx, &state, &plan, *d.client)
+	portIds, err := helper.FilterPortIds(ctx, &state, &plan, *pmaxClient)
 	if err != nil {
 		errStr := constants.ReadPortDetailErrorMsg + "with error:"
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error getting the list of ports",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the list of ports", errStr, err, path.Empty()))
 		return
 	}
 	for _, val := range portIds {
-		port, _, err := helper.GetPort(ctx, *d.client, val.DirectorId, val.PortId)
+		port, _, err := helper.GetPort(ctx, *pmaxClient, val.DirectorId, val.PortId)
 		if err != nil {
 			// Check to see if timeout was hit
 			helper.ExceedTimeoutErrorCheck(err, resp)
@@ -462,11 +461,7 @@ func (d *portDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 				return
 			}
 			errStr := constants.ReadPortDetailErrorMsg + "with error: "
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError(
-				"Error getting the details of port: "+val.DirectorId+":"+val.PortId,
-				message,
-			)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the details of port: "+val.DirectorId+":"+val.PortId, errStr, err, path.Empty()))
 			return
 		}
 		model, err := helper.PortDetailMapper(ctx, port)
@@ -481,6 +476,7 @@ func (d *portDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 	state.ID = types.StringValue("port-datasource")
 	state.Timeout = plan.Timeout
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -27,6 +27,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -57,7 +58,8 @@ func (d *PortgroupDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		MarkdownDescription: "Data source for reading PortGroups in PowerMax array. PowerMax port groups contain director and port identification and belong to a masking view. Ports can be added to and removed from the port group. Port groups that are no longer associated with a masking view can be deleted. Note the following recommendations: Port groups should contain four or more ports. Each port in a port group should be on a different director. A port can belong to more than one port group. However, for storage systems running HYPERMAX OS 5977 or higher, you cannot mix different types of ports (physical FC ports, virtual ports, and iSCSI virtual ports) within a single port group",
 		Description:         "Data source for reading PortGroups in PowerMax array. PowerMax port groups contain director and port identification and belong to a masking view. Ports can be added to and removed from the port group. Port groups that are no longer associated with a masking view can be deleted. Note the following recommendations: Port groups should contain four or more ports. Each port in a port group should be on a different director. A port can belong to more than one port group. However, for storage systems running HYPERMAX OS 5977 or higher, you cannot mix different types of ports (physical FC ports, virtual ports, and iSCSI virtual ports) within a single port group",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -68,6 +70,11 @@ func (d *PortgroupDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				MarkdownDescription: "List of port group attributes",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The serial number of the PowerMax array.",
+							MarkdownDescription: "The serial number of the PowerMax array.",
+						},
 						"id": schema.StringAttribute{
 							Description: "Identifier",
 							Computed:    true,
@@ -178,21 +185,18 @@ func (d *PortgroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(pgPlan.SerialNumber.ValueString())
 
 	var pgNames []string
 
-	portGroupIDList, _, err := helper.GetPortGroupList(ctx, *d.client, pgPlan)
+	portGroupIDList, _, err := helper.GetPortGroupList(ctx, *pmaxClient, pgPlan)
 	if err != nil {
 		// Check to see if timeout was hit
 		helper.ExceedTimeoutErrorCheck(err, resp)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		errStr := ""
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Unable to Read PowerMax Port Groups", msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Unable to Read PowerMax Port Groups", "", err, path.Empty()))
 	}
 	// Get portgroup IDs from config or query all if not specified
 	if pgPlan.PgFilter == nil || len(pgPlan.PgFilter.Names) == 0 {
@@ -215,7 +219,7 @@ func (d *PortgroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	// iterate Portgroup IDs and GetPortGroup with each id
 	for _, elemid := range pgNames {
-		pgResponse, _, err := helper.ReadPortgroupByID(ctx, *d.client, elemid)
+		pgResponse, _, err := helper.ReadPortgroupByID(ctx, *pmaxClient, elemid)
 		if err != nil || pgResponse == nil {
 			// Check to see if timeout was hit
 			helper.ExceedTimeoutErrorCheck(err, resp)
@@ -223,13 +227,13 @@ func (d *PortgroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 				return
 			}
 			errStr := fmt.Sprintf("Error reading port group with id %s", elemid)
-			msgStr := helper.GetErrorString(err, "")
-			resp.Diagnostics.AddError(errStr, msgStr)
+			resp.Diagnostics.Append(client.ErrorDiagnostic(errStr, "", err, path.Empty()))
 			return
 		}
 		var pg models.PortGroup
 		// Copy fields from the provider client data into the Terraform state
 		helper.UpdatePGState(&pg, &pg, pgResponse)
+		pg.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 		portGroups = append(portGroups, pg)
 	}
 	pgState.PortGroups = portGroups
@@ -240,6 +244,7 @@ func (d *PortgroupDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	tflog.Trace(ctx, "read PortGroup data source")
 
+	pgState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &pgState)...)
 }
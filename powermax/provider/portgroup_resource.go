@@ -38,9 +38,10 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &PortGroup{}
-	_ resource.ResourceWithConfigure   = &PortGroup{}
-	_ resource.ResourceWithImportState = &PortGroup{}
+	_ resource.Resource                   = &PortGroup{}
+	_ resource.ResourceWithConfigure      = &PortGroup{}
+	_ resource.ResourceWithImportState    = &PortGroup{}
+	_ resource.ResourceWithValidateConfig = &PortGroup{}
 )
 
 // NewPortGroup is a helper function to simplify the provider implementation.
@@ -61,6 +62,8 @@ func (r *PortGroup) Schema(ctx context.Context, req resource.SchemaRequest, resp
 		Description:         "Resource for managing PortGroups in PowerMax array. PowerMax port groups contain director and port identification and belong to a masking view. Ports can be added to and removed from the port group. Port groups that are no longer associated with a masking view can be deleted. Note the following recommendations: Port groups should contain four or more ports. Each port in a port group should be on a different director. A port can belong to more than one port group. However, for storage systems running HYPERMAX OS 5977 or higher, you cannot mix different types of ports (physical FC ports, virtual ports, and iSCSI virtual ports) within a single port group",
 
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The ID of the portgroup.",
@@ -85,9 +88,15 @@ func (r *PortGroup) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					Attributes: map[string]schema.Attribute{
 						"director_id": schema.StringAttribute{
 							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
 						"port_id": schema.StringAttribute{
 							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
 						},
 					},
 				},
@@ -132,6 +141,36 @@ func (r *PortGroup) Metadata(ctx context.Context, req resource.MetadataRequest,
 	resp.TypeName = req.ProviderTypeName + "_portgroup"
 }
 
+// ValidateConfig checks that a port is not listed more than once.
+func (r *PortGroup) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ports types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ports"), &ports)...)
+	if resp.Diagnostics.HasError() || ports.IsNull() || ports.IsUnknown() {
+		return
+	}
+	seen := make(map[string]bool, len(ports.Elements()))
+	for index, element := range ports.Elements() {
+		port, ok := element.(types.Object)
+		if !ok || port.IsNull() || port.IsUnknown() {
+			continue
+		}
+		directorID, _ := port.Attributes()["director_id"].(types.String)
+		portID, _ := port.Attributes()["port_id"].(types.String)
+		if directorID.IsUnknown() || portID.IsUnknown() {
+			continue
+		}
+		key := strings.ToUpper(directorID.ValueString()) + ":" + portID.ValueString()
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ports").AtListIndex(index),
+				"Invalid port group configuration",
+				fmt.Sprintf("The port %s:%s is listed more than once.", directorID.ValueString(), portID.ValueString()),
+			)
+		}
+		seen[key] = true
+	}
+}
+
 // Configure PortGroup.
 func (r *PortGroup) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -158,39 +197,43 @@ func (r *PortGroup) Create(ctx context.Context, req resource.CreateRequest, resp
 	//Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	tflog.Info(ctx, "creating port group")
 
-	var plan models.PortGroup
+	var plan models.PortGroupResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
 
 	tflog.Debug(ctx, "building ports", map[string]interface{}{
 		"plan": plan,
 		"resp": resp,
 	})
 
-	pgResponse, _, err := helper.CreatePortGroup(ctx, *r.client, plan)
+	pgResponse, _, err := helper.CreatePortGroup(ctx, *pmaxClient, plan.PortGroup)
 
 	if err != nil {
 		errStr := constants.CreatePGDetailErrorMsg + plan.Name.ValueString() + " with error: "
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error creating port group", msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating port group", errStr, err, path.Root("name")))
 		return
 	}
 	tflog.Debug(ctx, "create port group response", map[string]interface{}{
 		"pgResponse": pgResponse,
 	})
 
-	pgState := models.PortGroup{}
+	pgState := models.PortGroupResource{Timeouts: plan.Timeouts}
 	tflog.Debug(ctx, "updating port group state", map[string]interface{}{
 		"pgResponse": pgResponse,
 		"pgState":    pgState,
 	})
-	helper.UpdatePGState(&pgState, &plan, pgResponse)
+	helper.UpdatePGState(&pgState.PortGroup, &plan.PortGroup, pgResponse)
 
+	pgState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, pgState)
 	resp.Diagnostics.Append(diags...)
 }
@@ -198,26 +241,36 @@ func (r *PortGroup) Create(ctx context.Context, req resource.CreateRequest, resp
 // Read PortGroup.
 func (r *PortGroup) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Info(ctx, "reading portgroup")
-	var pgState models.PortGroup
+	var pgState models.PortGroupResource
 	diags := req.State.Get(ctx, &pgState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, pgState.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(pgState.SerialNumber.ValueString())
 
 	// Get portgroup ID from API and then update what is in state from what the API returns
 	pgID := pgState.ID.ValueString()
 	tflog.Debug(ctx, "getting portgroup by ID", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"portGroupID": pgID,
 	})
-	pgResponse, _, err := helper.ReadPortgroupByID(ctx, *r.client, pgID)
+	pgResponse, _, err := helper.ReadPortgroupByID(ctx, *pmaxClient, pgID)
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Port group not found, removing it from state", map[string]interface{}{
+				"id": pgID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		errStr := constants.ReadPGDetailsErrorMsg + pgID + " with error: "
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading port group", msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading port group", errStr, err, path.Empty()))
 		return
 	}
 
@@ -229,8 +282,9 @@ func (r *PortGroup) Read(ctx context.Context, req resource.ReadRequest, resp *re
 		"pgState":    pgState,
 		"pgResponse": pgResponse,
 	})
-	helper.UpdatePGState(&pgState, &pgState, pgResponse)
+	helper.UpdatePGState(&pgState.PortGroup, &pgState.PortGroup, pgResponse)
 
+	pgState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, pgState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -243,7 +297,7 @@ func (r *PortGroup) Read(ctx context.Context, req resource.ReadRequest, resp *re
 // Supported updates: name, ports.
 func (r *PortGroup) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Info(ctx, "updating portgroup")
-	var pgPlan, pgState models.PortGroup
+	var pgPlan, pgState models.PortGroupResource
 	diags := req.State.Get(ctx, &pgState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -254,8 +308,14 @@ func (r *PortGroup) Update(ctx context.Context, req resource.UpdateRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, pgPlan.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(pgState.SerialNumber.ValueString())
 
-	updatedParams, updateFailedParameters, errorMessages := helper.UpdatePortGroup(ctx, *r.client, pgPlan, pgState)
+	updatedParams, updateFailedParameters, errorMessages := helper.UpdatePortGroup(ctx, *pmaxClient, pgPlan.PortGroup, pgState.PortGroup)
 	if len(errorMessages) > 0 || len(updateFailedParameters) > 0 {
 		errMessage := strings.Join(errorMessages, ",\n")
 		resp.Diagnostics.AddError(
@@ -270,18 +330,17 @@ func (r *PortGroup) Update(ctx context.Context, req resource.UpdateRequest, resp
 		portGroupID = pgPlan.Name.ValueString()
 	}
 
-	pgResponse, _, err := helper.ReadPortgroupByID(ctx, *r.client, portGroupID)
+	pgResponse, _, err := helper.ReadPortgroupByID(ctx, *pmaxClient, portGroupID)
 	if err != nil {
 		errStr := constants.UpdatePGDetailsErrMsg + pgPlan.Name.ValueString() + " with error: "
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading port group", msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading port group", errStr, err, path.Empty()))
 		return
 	}
 
-	helper.UpdatePGState(&pgState, &pgPlan, pgResponse)
+	helper.UpdatePGState(&pgState.PortGroup, &pgPlan.PortGroup, pgResponse)
 
+	pgState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	pgState.Timeouts = pgPlan.Timeouts
 	diags = resp.State.Set(ctx, pgState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -294,25 +353,28 @@ func (r *PortGroup) Update(ctx context.Context, req resource.UpdateRequest, resp
 // Delete PortGroup.
 func (r *PortGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "deleting portgroup")
-	var pgState models.PortGroup
+	var pgState models.PortGroupResource
 	diags := req.State.Get(ctx, &pgState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, pgState.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(pgState.SerialNumber.ValueString())
 	pgID := pgState.ID.ValueString()
 	tflog.Debug(ctx, "calling delete port group on pmax client", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"portGroupID": pgID,
 	})
-	_, err := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeletePortGroup(ctx, r.client.SymmetrixID, pgID).Execute()
+	_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeletePortGroup(ctx, pmaxClient.SymmetrixID, pgID).Execute()
 
 	if err != nil {
 		errStr := constants.DeletePGDetailsErrorMsg + pgID + " with error: "
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error deleting port group", msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting port group", errStr, err, path.Empty()))
 	}
 	tflog.Info(ctx, "delete portgroup completed")
 }
@@ -320,5 +382,9 @@ func (r *PortGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp
 // ImportState import resource.
 func (r *PortGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "importing port group state")
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	serialNumber, id := helper.SplitImportID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	if serialNumber != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("serial_number"), serialNumber)...)
+	}
 }
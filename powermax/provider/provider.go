@@ -19,14 +19,20 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"terraform-provider-powermax/client"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -50,12 +56,29 @@ type PmaxProvider struct {
 
 // Data describes the provider data model.
 type Data struct {
-	Endpoint     types.String `tfsdk:"endpoint"`
-	Username     types.String `tfsdk:"username"`
-	Password     types.String `tfsdk:"password"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	PmaxVersion  types.String `tfsdk:"pmax_version"`
-	Insecure     types.Bool   `tfsdk:"insecure"`
+	Endpoint               types.String `tfsdk:"endpoint"`
+	Username               types.String `tfsdk:"username"`
+	Password               types.String `tfsdk:"password"`
+	PasswordFile           types.String `tfsdk:"password_file"`
+	CredentialProcess      types.String `tfsdk:"credential_process"`
+	SerialNumber           types.String `tfsdk:"serial_number"`
+	PmaxVersion            types.String `tfsdk:"pmax_version"`
+	Insecure               types.Bool   `tfsdk:"insecure"`
+	Timeout                types.Int64  `tfsdk:"timeout"`
+	MaxRetries             types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait           types.Int64  `tfsdk:"retry_min_wait"`
+	RetryMaxWait           types.Int64  `tfsdk:"retry_max_wait"`
+	CACertificate          types.String `tfsdk:"ca_certificate"`
+	ClientCertificate      types.String `tfsdk:"client_certificate"`
+	ClientKey              types.String `tfsdk:"client_key"`
+	CertificateFingerprint types.String `tfsdk:"certificate_fingerprint"`
+	ProxyURL               types.String `tfsdk:"proxy_url"`
+	NoProxy                types.String `tfsdk:"no_proxy"`
+	MaxIdleConns           types.Int64  `tfsdk:"max_idle_conns"`
+	KeepAlive              types.Int64  `tfsdk:"keepalive"`
+	MaxConcurrentRequests  types.Int64  `tfsdk:"max_concurrent_requests"`
+	TraceRequests          types.Bool   `tfsdk:"trace_requests"`
+	CacheTTL               types.Int64  `tfsdk:"cache_ttl"`
 }
 
 // Metadata returns the provider metadata.
@@ -88,8 +111,8 @@ func (p *PmaxProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				},
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "The password of the PowerMax host. This can also be set using the environment variable POWERMAX_PASSWORD",
-				Description:         "The password of the PowerMax host. This can also be set using the environment variable POWERMAX_PASSWORD",
+				MarkdownDescription: "The password of the PowerMax host. It accepts ephemeral values, like an ephemeral variable, which are never written to the plan or the state. Conflicts with password_file and credential_process. This can also be set using the environment variable POWERMAX_PASSWORD",
+				Description:         "The password of the PowerMax host. It accepts ephemeral values, like an ephemeral variable, which are never written to the plan or the state. Conflicts with password_file and credential_process. This can also be set using the environment variable POWERMAX_PASSWORD",
 				// This should remain optional so user can use environment variables if they choose.
 				Optional:  true,
 				Sensitive: true,
@@ -97,6 +120,26 @@ func (p *PmaxProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"password_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file holding the password of the PowerMax host, like a mounted secret. The file is read when the provider is configured and a trailing line break is ignored. Used when password is not set. Conflicts with password and credential_process. This can also be set using the environment variable POWERMAX_PASSWORD_FILE",
+				Description:         "Path of a file holding the password of the PowerMax host, like a mounted secret. The file is read when the provider is configured and a trailing line break is ignored. Used when password is not set. Conflicts with password and credential_process. This can also be set using the environment variable POWERMAX_PASSWORD_FILE",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("password"), path.MatchRoot("credential_process")),
+				},
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "Command run when the provider is configured to get the credentials of the PowerMax host, IE: (vault kv get -field=password secret/powermax). The command prints either the password or a JSON object with the password and optionally the username, which is used when username is not set. Arguments are separated by spaces and can be quoted, no shell is run. Used when password and password_file are not set. Conflicts with password. This can also be set using the environment variable POWERMAX_CREDENTIAL_PROCESS",
+				Description:         "Command run when the provider is configured to get the credentials of the PowerMax host, IE: (vault kv get -field=password secret/powermax). The command prints either the password or a JSON object with the password and optionally the username, which is used when username is not set. Arguments are separated by spaces and can be quoted, no shell is run. Used when password and password_file are not set. Conflicts with password. This can also be set using the environment variable POWERMAX_CREDENTIAL_PROCESS",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+				},
+			},
 			"serial_number": schema.StringAttribute{
 				MarkdownDescription: "The serial_number of the PowerMax host. This can also be set using the environment variable POWERMAX_SERIAL_NUMBER",
 				Description:         "The serial_number of the PowerMax host. This can also be set using the environment variable POWERMAX_SERIAL_NUMBER",
@@ -113,14 +156,155 @@ func (p *PmaxProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 				Optional: true,
 			},
 			"pmax_version": schema.StringAttribute{
-				MarkdownDescription: "The version of the PowerMax host. This can also be set using the environment variable POWERMAX_POWERMAX_VERSION",
-				Description:         "The version of the PowerMax host. This can also be set using the environment variable POWERMAX_POWERMAX_VERSION",
+				MarkdownDescription: "The Unisphere REST API version used to manage the PowerMax host, IE: 100. It is validated against the versions served by Unisphere. PowerMax host versions, like 10.1, are deprecated and ignored with a warning. This can also be set using the environment variable POWERMAX_VERSION",
+				Description:         "The Unisphere REST API version used to manage the PowerMax host, IE: 100. It is validated against the versions served by Unisphere. PowerMax host versions, like 10.1, are deprecated and ignored with a warning. This can also be set using the environment variable POWERMAX_VERSION",
 				// This should remain optional so user can use environment variables if they choose.
 				Optional: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA bundle, or path to a PEM file, trusted in addition to the system certificates to verify the PowerMax host. This can also be set using the environment variable POWERMAX_CA_CERTIFICATE",
+				Description:         "PEM encoded CA bundle, or path to a PEM file, trusted in addition to the system certificates to verify the PowerMax host. This can also be set using the environment variable POWERMAX_CA_CERTIFICATE",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate, or path to a PEM file, used for mutual TLS. Requires client_key. This can also be set using the environment variable POWERMAX_CLIENT_CERTIFICATE",
+				Description:         "PEM encoded client certificate, or path to a PEM file, used for mutual TLS. Requires client_key. This can also be set using the environment variable POWERMAX_CLIENT_CERTIFICATE",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate, or path to a PEM file, used for mutual TLS. Requires client_certificate. This can also be set using the environment variable POWERMAX_CLIENT_KEY",
+				Description:         "PEM encoded private key of the client certificate, or path to a PEM file, used for mutual TLS. Requires client_certificate. This can also be set using the environment variable POWERMAX_CLIENT_KEY",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("client_certificate")),
+				},
+			},
+			"certificate_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 fingerprint (hex, colons optional) of the PowerMax host certificate. When set, the certificate is pinned: only a certificate with this fingerprint is accepted, even if it is self-signed. This can also be set using the environment variable POWERMAX_CERTIFICATE_FINGERPRINT",
+				Description:         "SHA-256 fingerprint (hex, colons optional) of the PowerMax host certificate. When set, the certificate is pinned: only a certificate with this fingerprint is accepted, even if it is self-signed. This can also be set using the environment variable POWERMAX_CERTIFICATE_FINGERPRINT",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^([0-9a-fA-F]{2}:?){31}[0-9a-fA-F]{2}$`),
+						"must be a SHA-256 fingerprint in hex",
+					),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP(S) proxy used to reach the PowerMax host, IE: (http://proxy.example.com:3128). When not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored. This can also be set using the environment variable POWERMAX_PROXY_URL",
+				Description:         "URL of the HTTP(S) proxy used to reach the PowerMax host, IE: (http://proxy.example.com:3128). When not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored. This can also be set using the environment variable POWERMAX_PROXY_URL",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^(http|https|socks5)://`),
+						"must be a URL starting with http://, https:// or socks5://",
+					),
+				},
+			},
+			"no_proxy": schema.StringAttribute{
+				MarkdownDescription: "Comma separated list of hosts, domains or CIDRs which are reached without the proxy. Overrides the NO_PROXY environment variable. This can also be set using the environment variable POWERMAX_NO_PROXY",
+				Description:         "Comma separated list of hosts, domains or CIDRs which are reached without the proxy. Overrides the NO_PROXY environment variable. This can also be set using the environment variable POWERMAX_NO_PROXY",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of idle connections kept open to the PowerMax host. Defaults to 10. This can also be set using the environment variable POWERMAX_MAX_IDLE_CONNS",
+				Description:         "The maximum number of idle connections kept open to the PowerMax host. Defaults to 10. This can also be set using the environment variable POWERMAX_MAX_IDLE_CONNS",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"keepalive": schema.Int64Attribute{
+				MarkdownDescription: "The TCP keep-alive period in seconds of the connections to the PowerMax host. Defaults to 30. This can also be set using the environment variable POWERMAX_KEEPALIVE",
+				Description:         "The TCP keep-alive period in seconds of the connections to the PowerMax host. Defaults to 30. This can also be set using the environment variable POWERMAX_KEEPALIVE",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of requests sent in parallel to the PowerMax host. Modifications of a same storage group, host, host group, port group, masking view or volume are always sent one at a time. Defaults to 8. This can also be set using the environment variable POWERMAX_MAX_CONCURRENT_REQUESTS",
+				Description:         "The maximum number of requests sent in parallel to the PowerMax host. Modifications of a same storage group, host, host group, port group, masking view or volume are always sent one at a time. Defaults to 8. This can also be set using the environment variable POWERMAX_MAX_CONCURRENT_REQUESTS",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"trace_requests": schema.BoolAttribute{
+				MarkdownDescription: "Logs the method, URL, status, latency and JSON bodies of every request sent to the PowerMax host at the DEBUG level, with the Authorization header, passwords and CHAP secrets redacted. The requests are also logged at the TRACE level when TF_LOG is set to TRACE. This can also be set using the environment variable POWERMAX_TRACE_REQUESTS",
+				Description:         "Logs the method, URL, status, latency and JSON bodies of every request sent to the PowerMax host at the DEBUG level, with the Authorization header, passwords and CHAP secrets redacted. The requests are also logged at the TRACE level when TF_LOG is set to TRACE. This can also be set using the environment variable POWERMAX_TRACE_REQUESTS",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+			},
+			"cache_ttl": schema.Int64Attribute{
+				MarkdownDescription: "The time in seconds during which the objects read from an array are served from memory, so that the storage groups, port groups, hosts and volumes read by several resources and data sources during a plan are fetched once. Any modification of an array drops the objects cached for it. Defaults to 0, which disables the cache. This can also be set using the environment variable POWERMAX_CACHE_TTL",
+				Description:         "The time in seconds during which the objects read from an array are served from memory, so that the storage groups, port groups, hosts and volumes read by several resources and data sources during a plan are fetched once. Any modification of an array drops the objects cached for it. Defaults to 0, which disables the cache. This can also be set using the environment variable POWERMAX_CACHE_TTL",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "The timeout in seconds of every request sent to the PowerMax host. Defaults to 60. This can also be set using the environment variable POWERMAX_TIMEOUT",
+				Description:         "The timeout in seconds of every request sent to the PowerMax host. Defaults to 60. This can also be set using the environment variable POWERMAX_TIMEOUT",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of retries of a request failing with a transient error (HTTP 429, 502, 503, 504 or a connection error). Requests which are not idempotent are only retried when the array did not process them. Defaults to 3, 0 disables retries. This can also be set using the environment variable POWERMAX_MAX_RETRIES",
+				Description:         "The maximum number of retries of a request failing with a transient error (HTTP 429, 502, 503, 504 or a connection error). Requests which are not idempotent are only retried when the array did not process them. Defaults to 3, 0 disables retries. This can also be set using the environment variable POWERMAX_MAX_RETRIES",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_min_wait": schema.Int64Attribute{
+				MarkdownDescription: "The minimum wait time in seconds before retrying a request, doubled on every retry with jitter. Defaults to 1. This can also be set using the environment variable POWERMAX_RETRY_MIN_WAIT",
+				Description:         "The minimum wait time in seconds before retrying a request, doubled on every retry with jitter. Defaults to 1. This can also be set using the environment variable POWERMAX_RETRY_MIN_WAIT",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "The maximum wait time in seconds between two retries of a request. Defaults to 30. This can also be set using the environment variable POWERMAX_RETRY_MAX_WAIT",
+				Description:         "The maximum wait time in seconds between two retries of a request. Defaults to 30. This can also be set using the environment variable POWERMAX_RETRY_MAX_WAIT",
+				// This should remain optional so user can use environment variables if they choose.
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -145,6 +329,16 @@ func (p *PmaxProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		data.Password = types.StringValue(passEnv)
 	}
 
+	passwordFileEnv := os.Getenv("POWERMAX_PASSWORD_FILE")
+	if passwordFileEnv != "" {
+		data.PasswordFile = types.StringValue(passwordFileEnv)
+	}
+
+	credentialProcessEnv := os.Getenv("POWERMAX_CREDENTIAL_PROCESS")
+	if credentialProcessEnv != "" {
+		data.CredentialProcess = types.StringValue(credentialProcessEnv)
+	}
+
 	endpointEnv := os.Getenv("POWERMAX_ENDPOINT")
 	if endpointEnv != "" {
 		data.Endpoint = types.StringValue(endpointEnv)
@@ -165,6 +359,114 @@ func (p *PmaxProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		data.Insecure = types.BoolValue(insecureEnv)
 	}
 
+	caCertificateEnv := os.Getenv("POWERMAX_CA_CERTIFICATE")
+	if caCertificateEnv != "" {
+		data.CACertificate = types.StringValue(caCertificateEnv)
+	}
+
+	clientCertificateEnv := os.Getenv("POWERMAX_CLIENT_CERTIFICATE")
+	if clientCertificateEnv != "" {
+		data.ClientCertificate = types.StringValue(clientCertificateEnv)
+	}
+
+	clientKeyEnv := os.Getenv("POWERMAX_CLIENT_KEY")
+	if clientKeyEnv != "" {
+		data.ClientKey = types.StringValue(clientKeyEnv)
+	}
+
+	fingerprintEnv := os.Getenv("POWERMAX_CERTIFICATE_FINGERPRINT")
+	if fingerprintEnv != "" {
+		data.CertificateFingerprint = types.StringValue(fingerprintEnv)
+	}
+
+	proxyURLEnv := os.Getenv("POWERMAX_PROXY_URL")
+	if proxyURLEnv != "" {
+		data.ProxyURL = types.StringValue(proxyURLEnv)
+	}
+
+	noProxyEnv := os.Getenv("POWERMAX_NO_PROXY")
+	if noProxyEnv != "" {
+		data.NoProxy = types.StringValue(noProxyEnv)
+	}
+
+	maxIdleConnsEnv, errMaxIdleConns := strconv.ParseInt(os.Getenv("POWERMAX_MAX_IDLE_CONNS"), 10, 64)
+	if errMaxIdleConns == nil {
+		data.MaxIdleConns = types.Int64Value(maxIdleConnsEnv)
+	}
+
+	keepAliveEnv, errKeepAlive := strconv.ParseInt(os.Getenv("POWERMAX_KEEPALIVE"), 10, 64)
+	if errKeepAlive == nil {
+		data.KeepAlive = types.Int64Value(keepAliveEnv)
+	}
+
+	maxConcurrentRequestsEnv, errMaxConcurrentRequests := strconv.ParseInt(os.Getenv("POWERMAX_MAX_CONCURRENT_REQUESTS"), 10, 64)
+	if errMaxConcurrentRequests == nil {
+		data.MaxConcurrentRequests = types.Int64Value(maxConcurrentRequestsEnv)
+	}
+
+	traceRequestsEnv, errTraceRequests := strconv.ParseBool(os.Getenv("POWERMAX_TRACE_REQUESTS"))
+	if errTraceRequests == nil {
+		data.TraceRequests = types.BoolValue(traceRequestsEnv)
+	}
+
+	cacheTTLEnv, errCacheTTL := strconv.ParseInt(os.Getenv("POWERMAX_CACHE_TTL"), 10, 64)
+	if errCacheTTL == nil {
+		data.CacheTTL = types.Int64Value(cacheTTLEnv)
+	}
+
+	timeoutEnv, errTimeout := strconv.ParseInt(os.Getenv("POWERMAX_TIMEOUT"), 10, 64)
+	if errTimeout == nil {
+		data.Timeout = types.Int64Value(timeoutEnv)
+	}
+
+	maxRetriesEnv, errMaxRetries := strconv.ParseInt(os.Getenv("POWERMAX_MAX_RETRIES"), 10, 64)
+	if errMaxRetries == nil {
+		data.MaxRetries = types.Int64Value(maxRetriesEnv)
+	}
+
+	retryMinWaitEnv, errRetryMinWait := strconv.ParseInt(os.Getenv("POWERMAX_RETRY_MIN_WAIT"), 10, 64)
+	if errRetryMinWait == nil {
+		data.RetryMinWait = types.Int64Value(retryMinWaitEnv)
+	}
+
+	retryMaxWaitEnv, errRetryMaxWait := strconv.ParseInt(os.Getenv("POWERMAX_RETRY_MAX_WAIT"), 10, 64)
+	if errRetryMaxWait == nil {
+		data.RetryMaxWait = types.Int64Value(retryMaxWaitEnv)
+	}
+
+	// The secrets of the password file and the credential process are read now, they are not part
+	// of the configuration saved in the plan.
+	if data.Password.ValueString() == "" {
+		switch {
+		case data.PasswordFile.ValueString() != "":
+			password, err := client.ReadPasswordFile(data.PasswordFile.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("password_file"), "Unable to read the password", err.Error())
+				return
+			}
+			data.Password = types.StringValue(password)
+		case data.CredentialProcess.ValueString() != "":
+			credentials, err := client.RunCredentialProcess(ctx, data.CredentialProcess.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("credential_process"), "Unable to get the credentials", err.Error())
+				return
+			}
+			data.Password = types.StringValue(credentials.Password)
+			if data.Username.ValueString() == "" && credentials.Username != "" {
+				data.Username = types.StringValue(credentials.Username)
+			}
+		}
+	}
+
+	retry := client.RetryConfig{
+		MaxRetries: client.DefaultMaxRetries,
+		MinWait:    time.Duration(data.RetryMinWait.ValueInt64()) * time.Second,
+		MaxWait:    time.Duration(data.RetryMaxWait.ValueInt64()) * time.Second,
+	}
+	if !data.MaxRetries.IsNull() {
+		retry.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
 	// Configuration values are now available.
 	pmaxClient, err := client.NewClient(
 		ctx,
@@ -174,6 +476,25 @@ func (p *PmaxProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		data.SerialNumber.ValueString(),
 		data.PmaxVersion.ValueString(),
 		data.Insecure.ValueBool(),
+		client.ClientOptions{
+			Retry: retry,
+			TLS: client.TLSOptions{
+				CACertificate:     data.CACertificate.ValueString(),
+				ClientCertificate: data.ClientCertificate.ValueString(),
+				ClientKey:         data.ClientKey.ValueString(),
+				Fingerprint:       data.CertificateFingerprint.ValueString(),
+			},
+			Transport: client.TransportOptions{
+				ProxyURL:     data.ProxyURL.ValueString(),
+				NoProxy:      data.NoProxy.ValueString(),
+				MaxIdleConns: int(data.MaxIdleConns.ValueInt64()),
+				KeepAlive:    time.Duration(data.KeepAlive.ValueInt64()) * time.Second,
+			},
+			Timeout:               time.Duration(data.Timeout.ValueInt64()) * time.Second,
+			MaxConcurrentRequests: int(data.MaxConcurrentRequests.ValueInt64()),
+			Trace:                 data.TraceRequests.ValueBool(),
+			CacheTTL:              time.Duration(data.CacheTTL.ValueInt64()) * time.Second,
+		},
 	)
 
 	if err != nil {
@@ -184,13 +505,24 @@ func (p *PmaxProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// Do a dummy call to validate the client configuration
-	_, _, errClient := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListHosts(ctx, pmaxClient.SymmetrixID).Execute()
-	if errClient != nil {
-		resp.Diagnostics.AddError(
-			"Unable to create powermax client",
-			"Please validate that the endpoint, username, serial_number and password are correct.",
+	if client.IsLegacyPmaxVersion(data.PmaxVersion.ValueString()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("pmax_version"),
+			"Deprecated pmax_version value",
+			fmt.Sprintf("pmax_version %s is a PowerMax host version and is ignored. pmax_version is now the Unisphere REST API version used by the provider, %s; set it to %s or remove it.",
+				data.PmaxVersion.ValueString(), client.SupportedAPIVersion, client.SupportedAPIVersion),
 		)
+	}
+
+	// Validate the connection with lightweight calls (version and array lookup)
+	errValidate := pmaxClient.ValidateConnection(ctx, data.PmaxVersion.ValueString())
+	if errValidate != nil {
+		var connErr *client.ConnectionError
+		if errors.As(errValidate, &connErr) && connErr.Attribute != "" {
+			resp.Diagnostics.AddAttributeError(path.Root(connErr.Attribute), connErr.Summary, connErr.Detail)
+		} else {
+			resp.Diagnostics.AddError("Unable to create powermax client", errValidate.Error())
+		}
 		return
 	}
 
@@ -204,6 +536,8 @@ func (p *PmaxProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 func (p *PmaxProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewStorageGroup,
+		NewStorageGroupSplit,
+		NewStorageGroupMerge,
 		NewHostGroup,
 		NewHost,
 		NewPortGroup,
@@ -226,6 +560,7 @@ func (p *PmaxProvider) DataSources(ctx context.Context) []func() datasource.Data
 		NewSnapshotDataSource,
 		NewPortDataSource,
 		NewSnapshotPolicyDataSource,
+		NewTagsDataSource,
 	}
 }
 
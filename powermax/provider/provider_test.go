@@ -23,11 +23,13 @@ import (
 	"log"
 	"os"
 	"strings"
+	"terraform-provider-powermax/client/unispheretest"
 	"testing"
 
 	"github.com/bytedance/mockey"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -42,7 +44,23 @@ var ProviderConfig = ""
 var FunctionMocker *mockey.Mocker
 var globalEnvMap = getEnvMap()
 
+// mockUnisphere is the in-memory Unisphere used instead of an array when POWERMAX_MOCK_UNISPHERE is true.
+var mockUnisphere *unispheretest.Server
+
 func init() {
+	if isMockUnisphere() {
+		mockUnisphere = unispheretest.NewServer()
+		// Environment variables override the provider block, point them to the mock as well
+		globalEnvMap["POWERMAX_USERNAME"] = unispheretest.Username
+		globalEnvMap["POWERMAX_PASSWORD"] = unispheretest.Password
+		globalEnvMap["POWERMAX_ENDPOINT"] = mockUnisphere.URL
+		globalEnvMap["POWERMAX_SERIAL_NUMBER"] = mockUnisphere.SerialNumber
+		globalEnvMap["POWERMAX_VERSION"] = "100"
+		for _, key := range []string{"POWERMAX_USERNAME", "POWERMAX_PASSWORD", "POWERMAX_ENDPOINT", "POWERMAX_SERIAL_NUMBER", "POWERMAX_VERSION"} {
+			os.Setenv(key, globalEnvMap[key])
+		}
+	}
+
 	username := globalEnvMap["POWERMAX_USERNAME"]
 	password := globalEnvMap["POWERMAX_PASSWORD"]
 	endpoint := globalEnvMap["POWERMAX_ENDPOINT"]
@@ -61,6 +79,43 @@ func init() {
 	`, username, password, endpoint, serialNumber, pmaxVersion)
 }
 
+// TestMain runs the sweepers when the -sweep flag is set, and stops the mock Unisphere once the tests are done.
+func TestMain(m *testing.M) {
+	resource.TestMain(mockUnisphereRunner{m})
+}
+
+// mockUnisphereRunner runs the tests and stops the mock Unisphere.
+type mockUnisphereRunner struct {
+	m *testing.M
+}
+
+// Run runs the tests and returns their exit code.
+func (r mockUnisphereRunner) Run() int {
+	code := r.m.Run()
+	if mockUnisphere != nil {
+		mockUnisphere.Close()
+	}
+	return code
+}
+
+// isMockUnisphere reports whether the acceptance tests run against the in-memory Unisphere.
+// It is enabled by setting POWERMAX_MOCK_UNISPHERE to true in the environment or in powermax.env.
+func isMockUnisphere() bool {
+	value := os.Getenv("POWERMAX_MOCK_UNISPHERE")
+	if value == "" {
+		value = globalEnvMap["POWERMAX_MOCK_UNISPHERE"]
+	}
+	return strings.EqualFold(value, "true")
+}
+
+// testAccMockPreCheck skips tests written for the in-memory Unisphere when running against an array.
+func testAccMockPreCheck(t *testing.T) {
+	if mockUnisphere == nil {
+		t.Skip("POWERMAX_MOCK_UNISPHERE is not set to true")
+	}
+	testAccPreCheck(t)
+}
+
 func testAccPreCheck(t *testing.T) {
 	// Check that the required environment variables are set.
 	if globalEnvMap["POWERMAX_ENDPOINT"] == "" {
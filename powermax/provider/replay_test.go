@@ -0,0 +1,300 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file replay the exchanges recorded in testdata/cassettes, they run without
+// an array. The cassettes are synthetic: they were recorded against the in-memory Unisphere of
+// client/unispheretest, not against a PowerMax array, so they only hold the answers the mock gives.
+// Setting POWERMAX_RECORD to true records the cassettes again against the array configured in
+// powermax.env, or against the in-memory Unisphere with POWERMAX_MOCK_UNISPHERE.
+
+// isRecording reports whether the cassettes are recorded instead of replayed.
+func isRecording() bool {
+	value := os.Getenv("POWERMAX_RECORD")
+	if value == "" {
+		value = globalEnvMap["POWERMAX_RECORD"]
+	}
+	return strings.EqualFold(value, "true")
+}
+
+// newCassetteClient returns a client replaying the cassette of the given name, or recording it.
+func newCassetteClient(t *testing.T, name string) *client.Client {
+	// Patches left by the acceptance tests would bypass the recorded calls
+	if FunctionMocker != nil {
+		FunctionMocker.UnPatch()
+	}
+
+	ctx := context.Background()
+	// Nothing reaches Unisphere when replaying, credentials are not needed
+	endpoint, username, password, serialNumber := "https://unisphere.invalid:8443", "", "", "000197600123"
+	recorder := client.RecorderOptions{
+		Mode:     client.RecorderReplay,
+		Cassette: filepath.Join("testdata", "cassettes", name+".json"),
+	}
+	if isRecording() {
+		testAccPreCheck(t)
+		endpoint = globalEnvMap["POWERMAX_ENDPOINT"]
+		username = globalEnvMap["POWERMAX_USERNAME"]
+		password = globalEnvMap["POWERMAX_PASSWORD"]
+		serialNumber = globalEnvMap["POWERMAX_SERIAL_NUMBER"]
+		recorder.Mode = client.RecorderRecord
+	}
+
+	pmaxClient, err := client.NewClient(ctx, endpoint, username, password, serialNumber, "100", true, client.ClientOptions{Recorder: recorder})
+	require.NoError(t, err)
+	t.Cleanup(func() { pmaxClient.Close(ctx) })
+	return pmaxClient
+}
+
+// createReplayStorageGroup creates a storage group with the given volumes and returns their IDs.
+// The storage group and its volumes are deleted at the end of the test.
+func createReplayStorageGroup(t *testing.T, pmaxClient *client.Client, sgName string, volumeNames ...string) []string {
+	ctx := context.Background()
+	plan := models.StorageGroupResourceModel{
+		StorageGroupID: types.StringValue(sgName),
+		Srp:            types.StringValue("SRP_1"),
+		Slo:            types.StringValue("Diamond"),
+	}
+	_, _, err := helper.CreateStorageGroup(ctx, pmaxClient, plan)
+	require.NoError(t, err)
+
+	var volumeIDs []string
+	for _, volumeName := range volumeNames {
+		volPlan := models.VolumeResource{
+			StorageGroupName: types.StringValue(sgName),
+			VolumeIdentifier: types.StringValue(volumeName),
+			Size:             types.NumberValue(big.NewFloat(1)),
+			CapUnit:          types.StringValue(helper.CapacityUnitGb),
+		}
+		_, _, err := helper.CreateVolume(ctx, *pmaxClient, volPlan)
+		require.NoError(t, err)
+		volumes, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListVolumes(ctx, pmaxClient.SymmetrixID).
+			StorageGroupId(sgName).VolumeIdentifier(volumeName).Execute()
+		require.NoError(t, err)
+		require.Len(t, volumes.ResultList.Result, 1)
+		volumeIDs = append(volumeIDs, fmt.Sprint(volumes.ResultList.Result[0]["volumeId"]))
+	}
+
+	t.Cleanup(func() {
+		sdk := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi
+		if len(volumeIDs) > 0 {
+			_, _, err := sdk.ModifyStorageGroup(ctx, pmaxClient.SymmetrixID, sgName).EditStorageGroupParam(pmax.EditStorageGroupParam{
+				EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+					RemoveVolumeParam: &pmax.RemoveVolumeParam{VolumeId: volumeIDs},
+				},
+			}).Execute()
+			assert.NoError(t, err)
+		}
+		for _, volumeID := range volumeIDs {
+			_, err := sdk.DeleteVolume(ctx, pmaxClient.SymmetrixID, volumeID).Execute()
+			assert.NoError(t, err)
+		}
+		_, err := sdk.DeleteStorageGroup(ctx, pmaxClient.SymmetrixID, sgName).Execute()
+		assert.NoError(t, err)
+	})
+	return volumeIDs
+}
+
+// modelFromJSON decodes into target the configuration of the resource r given as JSON,
+// for models whose nested types are not exported. Missing attributes are null.
+func modelFromJSON(ctx context.Context, r resource.Resource, config string, target interface{}) error {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	raw, err := tftypes.ValueFromJSON([]byte(config), schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		return err
+	}
+	diags := tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw}.Get(ctx, target)
+	if diags.HasError() {
+		return fmt.Errorf("unable to decode the configuration: %v", diags)
+	}
+	return nil
+}
+
+func TestReplayUpdateVol(t *testing.T) {
+	ctx := context.Background()
+	pmaxClient := newCassetteClient(t, "update_vol")
+	volumeID := createReplayStorageGroup(t, pmaxClient, "test_acc_replay_vol_sg", "test_acc_replay_vol")[0]
+
+	state := models.VolumeResource{
+		ID:                types.StringValue(volumeID),
+		StorageGroupName:  types.StringValue("test_acc_replay_vol_sg"),
+		VolumeIdentifier:  types.StringValue("test_acc_replay_vol"),
+		Size:              types.NumberValue(big.NewFloat(1)),
+		CapUnit:           types.StringValue(helper.CapacityUnitGb),
+		MobilityIDEnabled: types.BoolValue(false),
+	}
+	plan := state
+	plan.VolumeIdentifier = types.StringValue("test_acc_replay_vol_upd")
+	plan.Size = types.NumberValue(big.NewFloat(2))
+	plan.MobilityIDEnabled = types.BoolValue(true)
+
+	updated, failed, messages := helper.UpdateVol(ctx, pmaxClient, plan, state)
+	assert.Equal(t, []string{"name", "enable_mobility_id", "size"}, updated)
+	assert.Empty(t, failed)
+	assert.Empty(t, messages)
+
+	volume, _, err := helper.GetVolume(ctx, *pmaxClient, volumeID)
+	require.NoError(t, err)
+	require.NoError(t, helper.UpdateVolResourceState(ctx, &state, volume, &plan))
+	assert.Equal(t, "test_acc_replay_vol_upd", volume.GetVolumeIdentifier())
+	assert.Equal(t, "2", state.Size.ValueBigFloat().String())
+	assert.True(t, state.MobilityIDEnabled.ValueBool())
+}
+
+func TestReplayModifySnapshot(t *testing.T) {
+	ctx := context.Background()
+	pmaxClient := newCassetteClient(t, "modify_snapshot")
+	createReplayStorageGroup(t, pmaxClient, "test_acc_replay_snap_sg", "test_acc_replay_snap_vol")
+	createReplayStorageGroup(t, pmaxClient, "test_acc_replay_target_sg")
+
+	state := models.SnapshotResourceModel{
+		StorageGroup: &models.FilterTypeSnapshot{Name: types.StringValue("test_acc_replay_snap_sg")},
+		Snapshot:     &models.SnapshotResourceFields{Name: types.StringValue("test_acc_replay_snapshot")},
+	}
+	_, _, err := helper.CreateSnapshot(ctx, *pmaxClient, state.StorageGroup.Name.ValueString(), state)
+	require.NoError(t, err)
+	snapIDs, _, err := helper.GetStorageGroupSnapshotSnapIDs(ctx, *pmaxClient, "test_acc_replay_snap_sg", "test_acc_replay_snapshot")
+	require.NoError(t, err)
+	require.NotEmpty(t, snapIDs.Snapids)
+	state.Snapid = types.Int64Value(snapIDs.Snapids[0])
+	t.Cleanup(func() {
+		_, err := pmaxClient.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, pmaxClient.SymmetrixID, "test_acc_replay_snap_sg", "test_acc_replay_snapshot_upd", state.Snapid.ValueInt64()).Execute()
+		assert.NoError(t, err)
+	})
+
+	// Rename, link and set the time to live at once
+	var plan models.SnapshotResourceModel
+	require.NoError(t, modelFromJSON(ctx, NewSnapshotResource(), `{
+		"storage_group": {"name": "test_acc_replay_snap_sg"},
+		"snapshot_actions": {
+			"name": "test_acc_replay_snapshot_upd",
+			"link": {"enable": true, "target_storage_group": "test_acc_replay_target_sg", "no_compression": true, "remote": false, "copy": false},
+			"time_to_live": {"enable": true, "time_to_live": 2, "time_in_hours": true}
+		}
+	}`, &plan))
+	require.NoError(t, helper.ModifySnapshot(ctx, *pmaxClient, &plan, &state))
+
+	snapshot, _, err := helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, "test_acc_replay_snap_sg", "test_acc_replay_snapshot_upd", state.Snapid.ValueInt64())
+	require.NoError(t, err)
+	assert.Equal(t, "test_acc_replay_snapshot_upd", snapshot.GetName())
+	assert.True(t, snapshot.GetLinked())
+	assert.NotEmpty(t, snapshot.GetTimeToLiveExpiryDate())
+
+	// Unlink so the storage groups can be deleted
+	state = plan
+	state.Snapid = types.Int64Value(snapIDs.Snapids[0])
+	require.NoError(t, modelFromJSON(ctx, NewSnapshotResource(), `{
+		"storage_group": {"name": "test_acc_replay_snap_sg"},
+		"snapshot_actions": {
+			"name": "test_acc_replay_snapshot_upd",
+			"link": {"enable": false, "target_storage_group": "test_acc_replay_target_sg", "no_compression": true, "remote": false, "copy": false},
+			"time_to_live": {"enable": true, "time_to_live": 2, "time_in_hours": true}
+		}
+	}`, &plan))
+	require.NoError(t, helper.ModifySnapshot(ctx, *pmaxClient, &plan, &state))
+
+	snapshot, _, err = helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, "test_acc_replay_snap_sg", "test_acc_replay_snapshot_upd", state.Snapid.ValueInt64())
+	require.NoError(t, err)
+	assert.False(t, snapshot.GetLinked())
+}
+
+func TestReplayStorageGroupUpdate(t *testing.T) {
+	ctx := context.Background()
+	pmaxClient := newCassetteClient(t, "storagegroup_update")
+	r := &StorageGroup{client: pmaxClient}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	_, _, err := helper.CreateStorageGroup(ctx, pmaxClient, models.StorageGroupResourceModel{
+		StorageGroupID: types.StringValue("test_acc_replay_sg"),
+		Srp:            types.StringValue("SRP_1"),
+		Slo:            types.StringValue("Gold"),
+	})
+	require.NoError(t, err)
+	sgName := "test_acc_replay_sg"
+	t.Cleanup(func() {
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteStorageGroup(ctx, pmaxClient.SymmetrixID, sgName).Execute()
+		assert.NoError(t, err)
+	})
+
+	var stateModel models.StorageGroupResourceModel
+	storageGroup, err := helper.UpdateSgState(ctx, pmaxClient, sgName, &stateModel)
+	require.NoError(t, err)
+	planModel := stateModel
+	planModel.StorageGroupID = types.StringValue("test_acc_replay_sg_upd")
+	planModel.Slo = types.StringValue("Silver")
+	planModel.Compression = types.BoolValue(false)
+	planModel.HostIOLimit = types.ObjectValueMust(
+		map[string]attr.Type{
+			"host_io_limit_io_sec": types.StringType,
+			"host_io_limit_mb_sec": types.StringType,
+			"dynamic_distribution": types.StringType,
+		},
+		map[string]attr.Value{
+			"host_io_limit_io_sec": types.StringValue("2000"),
+			"host_io_limit_mb_sec": types.StringValue("2000"),
+			"dynamic_distribution": types.StringValue("Never"),
+		})
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	timeouts := helper.NullTimeouts(ctx)
+	volumeSets := types.ListNull(helper.VolumeSetType)
+	tags := helper.StorageGroupTags(ctx, storageGroup)
+	require.False(t, req.Plan.Set(ctx, &models.StorageGroupResource{StorageGroupResourceModel: planModel, Tags: tags, VolumeSets: volumeSets, Timeouts: timeouts}).HasError())
+	require.False(t, req.State.Set(ctx, &models.StorageGroupResource{StorageGroupResourceModel: stateModel, Tags: tags, VolumeSets: volumeSets, Timeouts: timeouts}).HasError())
+	resp := resource.UpdateResponse{State: req.State}
+	r.Update(ctx, req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	sgName = "test_acc_replay_sg_upd"
+
+	var updated models.StorageGroupResource
+	require.False(t, resp.State.Get(ctx, &updated).HasError())
+	assert.Equal(t, "test_acc_replay_sg_upd", updated.StorageGroupID.ValueString())
+	assert.Equal(t, "Silver", updated.Slo.ValueString())
+	assert.False(t, updated.Compression.ValueBool())
+	hostIOLimit := helper.ConstructHostIOLimit(updated.StorageGroupResourceModel)
+	require.NotNil(t, hostIOLimit)
+	assert.Equal(t, "2000", hostIOLimit.HostIOLimitIOSec.ValueString())
+	assert.Equal(t, "2000", hostIOLimit.HostIOLimitMBSec.ValueString())
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -57,7 +58,8 @@ func (d *snapshotDataSource) Schema(ctx context.Context, _ datasource.SchemaRequ
 		MarkdownDescription: "Data source for a specific StorageGroup Snapshots in PowerMax array. PowerMax Snaphots is a local replication solution that is designed to nondisruptively create point-in-time copies (snapshots) of critical data.",
 		Description:         "Data source for a specific StorageGroup Snapshots in PowerMax array. PowerMax Snaphots is a local replication solution that is designed to nondisruptively create point-in-time copies (snapshots) of critical data.",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -290,25 +292,22 @@ func (d *snapshotDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(plan.SerialNumber.ValueString())
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	list, _, err := helper.GetStorageGroupSnapshots(ctx, *d.client, plan.StorageGroup.Name.ValueString())
+	list, _, err := helper.GetStorageGroupSnapshots(ctx, *pmaxClient, plan.StorageGroup.Name.ValueString())
 	if err != nil {
 		errStr := constants.ReadSnapshots + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error getting the list of snapshots",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the list of snapshots", errStr, err, path.Empty()))
 		return
 	}
 
 	// Get the list of snapids
 	for _, sngc := range list.SnapshotNamesAndCounts {
-		val, _, err := helper.GetStorageGroupSnapshotSnapIDs(ctx, *d.client, plan.StorageGroup.Name.ValueString(), *sngc.Name)
+		val, _, err := helper.GetStorageGroupSnapshotSnapIDs(ctx, *pmaxClient, plan.StorageGroup.Name.ValueString(), *sngc.Name)
 		if err != nil {
 			// Check to see if timeout was hit
 			helper.ExceedTimeoutErrorCheck(err, resp)
@@ -316,16 +315,12 @@ func (d *snapshotDataSource) Read(ctx context.Context, req datasource.ReadReques
 				return
 			}
 			errStr := constants.ReadSnapshots + " with error: "
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError(
-				"Error getting the list of snapshots Ids",
-				message,
-			)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the list of snapshots Ids", errStr, err, path.Empty()))
 			return
 		}
 		for _, id := range val.Snapids {
 			var detail models.SnapshotDetailModal
-			snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *d.client, plan.StorageGroup.Name.ValueString(), *sngc.Name, id)
+			snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, plan.StorageGroup.Name.ValueString(), *sngc.Name, id)
 			if err != nil {
 				// Check to see if timeout was hit
 				helper.ExceedTimeoutErrorCheck(err, resp)
@@ -333,11 +328,7 @@ func (d *snapshotDataSource) Read(ctx context.Context, req datasource.ReadReques
 					return
 				}
 				errStr := constants.ReadSnapshots + " with error: "
-				message := helper.GetErrorString(err, errStr)
-				resp.Diagnostics.AddError(
-					"Error getting the list of snapshots snapIds",
-					message,
-				)
+				resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the list of snapshots snapIds", errStr, err, path.Empty()))
 				return
 			}
 			errState := helper.UpdateSnapshotDatasourceState(ctx, snapDetail, &detail)
@@ -348,11 +339,7 @@ func (d *snapshotDataSource) Read(ctx context.Context, req datasource.ReadReques
 					return
 				}
 				errStr := constants.ReadSnapshots + " with error: "
-				message := helper.GetErrorString(errState, errStr)
-				resp.Diagnostics.AddError(
-					"Error getting the list of snapshots details",
-					message,
-				)
+				resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the list of snapshots details", errStr, errState, path.Empty()))
 				return
 			}
 			state.Snapshots = append(state.Snapshots, detail)
@@ -361,6 +348,7 @@ func (d *snapshotDataSource) Read(ctx context.Context, req datasource.ReadReques
 	state.Timeout = plan.Timeout
 	state.ID = types.StringValue("snapshot-datasource")
 
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 
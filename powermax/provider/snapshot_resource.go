@@ -26,6 +26,7 @@ import (
 	"terraform-provider-powermax/powermax/helper"
 	"terraform-provider-powermax/powermax/models"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -39,6 +40,7 @@ import (
 var _ resource.Resource = &snapshotResource{}
 var _ resource.ResourceWithConfigure = &snapshotResource{}
 var _ resource.ResourceWithImportState = &snapshotResource{}
+var _ resource.ResourceWithValidateConfig = &snapshotResource{}
 
 // NewSnapshotResource is a helper function to simplify the provider implementation.
 func NewSnapshotResource() resource.Resource {
@@ -64,6 +66,8 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 		Description:         "Resource for managing Snapshots in PowerMax array. PowerMax Snaphots is a local replication solution that is designed to nondisruptively create point-in-time copies (snapshots) of critical data.",
 
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -402,6 +406,44 @@ func (r *snapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+// ValidateConfig checks that the storage_group and snapshot_actions blocks are set, and that
+// linking the snapshot names the target storage group.
+func (r *snapshotResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var storageGroup, actions types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("storage_group"), &storageGroup)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snapshot_actions"), &actions)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if storageGroup.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("storage_group"), "Invalid snapshot configuration",
+			"The storage_group block is required to create a snapshot.")
+	} else if name, ok := storageGroup.Attributes()["name"].(types.String); ok && !name.IsUnknown() && name.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("storage_group").AtName("name"), "Invalid snapshot configuration",
+			"The storage group name cannot be empty.")
+	}
+
+	if actions.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("snapshot_actions"), "Invalid snapshot configuration",
+			"The snapshot_actions block is required to name the snapshot.")
+		return
+	}
+	if actions.IsUnknown() {
+		return
+	}
+	link, ok := actions.Attributes()["link"].(types.Object)
+	if !ok || link.IsNull() || link.IsUnknown() {
+		return
+	}
+	enable, _ := link.Attributes()["enable"].(types.Bool)
+	target, _ := link.Attributes()["target_storage_group"].(types.String)
+	if enable.ValueBool() && !target.IsUnknown() && target.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("snapshot_actions").AtName("link").AtName("target_storage_group"),
+			"Invalid snapshot configuration", "The target_storage_group is required to link the snapshot.")
+	}
+}
+
 // Configure the resource.
 func (r *snapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -433,6 +475,12 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
 	if plan.StorageGroup.Name.ValueString() == "" {
 		resp.Diagnostics.AddError(
 			"Error creating snapshot",
@@ -440,38 +488,26 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 		)
 		return
 	}
-	_, _, err := helper.CreateSnapshot(ctx, *r.client, plan.StorageGroup.Name.ValueString(), plan)
+	_, _, err := helper.CreateSnapshot(ctx, *pmaxClient, plan.StorageGroup.Name.ValueString(), plan)
 	if err != nil {
 		errStr := fmt.Sprintf("Could not create snapshot %s with error:", plan.Snapshot.Name)
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error creating snapshot",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating snapshot", errStr, err, path.Root("snapshot_actions").AtName("name")))
 		return
 	}
 
 	// Get the new snapID Id
-	val, _, err := helper.GetStorageGroupSnapshotSnapIDs(ctx, *r.client, plan.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString())
+	val, _, err := helper.GetStorageGroupSnapshotSnapIDs(ctx, *pmaxClient, plan.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString())
 	if err != nil {
 		errStr := constants.ReadSnapshots + "with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error getting the new snapID",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting the new snapID", errStr, err, path.Empty()))
 		return
 	}
 
 	// Get the new Snapshot
-	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *r.client, plan.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), val.Snapids[0])
+	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, plan.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), val.Snapids[0])
 	if err != nil {
 		errStr := fmt.Sprintf("Could not find snapshot %s after create with error:", plan.Snapshot.Name)
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error creating snapshot",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating snapshot", errStr, err, path.Root("snapshot_actions").AtName("name")))
 		return
 	}
 	errState := helper.UpdateSnapshotResourceState(ctx, snapDetail, &state)
@@ -485,6 +521,8 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 	state.ID = types.StringValue("snapshot-resource")
 	state.StorageGroup = plan.StorageGroup
 	state.Snapshot = plan.Snapshot
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	state.Timeouts = plan.Timeouts
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -501,14 +539,23 @@ func (r *snapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *r.client, state.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64())
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
+	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, state.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Snapshot not found, removing it from state", map[string]interface{}{
+				"id": state.Name.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		errStr := fmt.Sprintf("Could not find snapshot %s with error:", state.Name)
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading snapshot",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading snapshot", errStr, err, path.Empty()))
 		return
 	}
 	errState := helper.UpdateSnapshotResourceState(ctx, snapDetail, &state)
@@ -519,6 +566,7 @@ func (r *snapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		)
 		return
 	}
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -541,27 +589,25 @@ func (r *snapshotResource) Update(ctx context.Context, req resource.UpdateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
-	err := helper.ModifySnapshot(ctx, *r.client, &plan, &state)
+	err := helper.ModifySnapshot(ctx, *pmaxClient, &plan, &state)
 	if err != nil {
 		errStr := constants.UpdateSnapshot + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error updating snapshot",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error updating snapshot", errStr, err, path.Root("snapshot_actions")))
 		return
 	}
 	// Read and update state after the modification
-	getParam := r.client.PmaxOpenapiClient.ReplicationApi.GetSnapshotSnapIDSG(ctx, r.client.SymmetrixID, state.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), state.Snapid.ValueInt64())
+	getParam := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetSnapshotSnapIDSG(ctx, pmaxClient.SymmetrixID, state.StorageGroup.Name.ValueString(), plan.Snapshot.Name.ValueString(), state.Snapid.ValueInt64())
 	snapDetail, _, err := getParam.Execute()
 	if err != nil {
 		errStr := fmt.Sprintf("Error reading snapshot %s after update with error:", state.Name)
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading snapshot",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading snapshot", errStr, err, path.Empty()))
 		return
 	}
 	errState := helper.UpdateSnapshotResourceState(ctx, snapDetail, &state)
@@ -574,6 +620,8 @@ func (r *snapshotResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 	state.StorageGroup = plan.StorageGroup
 	state.Snapshot = plan.Snapshot
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	state.Timeouts = plan.Timeouts
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -591,15 +639,17 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	deleteParam := r.client.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, r.client.SymmetrixID, state.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64())
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
+	deleteParam := pmaxClient.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotSnapID(ctx, pmaxClient.SymmetrixID, state.StorageGroup.Name.ValueString(), state.Name.ValueString(), state.Snapid.ValueInt64())
 	_, err := deleteParam.Execute()
 	if err != nil {
 		errStr := fmt.Sprintf("Could not delete snapshot %s with error:", state.Name)
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error deleting snapshot",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting snapshot", errStr, err, path.Empty()))
 		return
 	}
 }
@@ -607,7 +657,8 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 // ImportState imports a Snapshot.
 func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "importing snapshot")
-	id := req.ID
+	serialNumber, id := helper.SplitImportID(req.ID)
+	pmaxClient := r.client.WithSerialNumber(serialNumber)
 	ids := strings.Split(id, ".")
 	tflog.Info(ctx, fmt.Sprintf("id: %s ids %v length %v", id, ids, len(ids)))
 	sgName := ""
@@ -625,26 +676,18 @@ func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportS
 
 	var state models.SnapshotResourceModel
 	// Get the snapID Id
-	snapIDParam := r.client.PmaxOpenapiClient.ReplicationApi.GetStorageGroupSnapshotSnapIDs(ctx, r.client.SymmetrixID, sgName, snapshotName)
+	snapIDParam := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetStorageGroupSnapshotSnapIDs(ctx, pmaxClient.SymmetrixID, sgName, snapshotName)
 	val, _, err := snapIDParam.Execute()
 	if err != nil {
 		errStr := constants.ReadSnapshots + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error importing snapshot",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error importing snapshot", errStr, err, path.Empty()))
 		return
 	}
 	// Get the details
-	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *r.client, sgName, snapshotName, val.Snapids[0])
+	snapDetail, _, err := helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, sgName, snapshotName, val.Snapids[0])
 	if err != nil {
 		errStr := fmt.Sprintf("Could not find snapshot %s with error:", state.Name)
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error importing snapshot",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error importing snapshot", errStr, err, path.Empty()))
 		return
 	}
 	errState := helper.UpdateSnapshotResourceState(ctx, snapDetail, &state)
@@ -664,7 +707,9 @@ func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportS
 	state.StorageGroup = &models.FilterTypeSnapshot{
 		Name: basetypes.NewStringValue(sgName),
 	}
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save plan into Terraform state
+	state.Timeouts = helper.NullTimeouts(ctx)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
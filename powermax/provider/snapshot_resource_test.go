@@ -164,7 +164,7 @@ func TestAccSnapshotResourceSGNameError(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      ProviderConfig + SnapshotResourceConfigSgNameError,
-				ExpectError: regexp.MustCompile(`.*Error creating snapshot*.`),
+				ExpectError: regexp.MustCompile(`.*storage group name cannot be empty*.`),
 			},
 		},
 	})
@@ -27,6 +27,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -56,7 +57,8 @@ func (d *snapshotPolicyDataSource) Schema(ctx context.Context, _ datasource.Sche
 		MarkdownDescription: "Data source for a specific Snapshot Policy in PowerMax array. PowerMax snapshot policy feature provides snapshot orchestration at scale (1,024 snaps per storage group). The resource simplifies snapshot management for standard and cloud snapshots.",
 		Description:         "Data source for a specific Snapshot Policy in PowerMax array. PowerMax snapshot policy feature provides snapshot orchestration at scale (1,024 snaps per storage group). The resource simplifies snapshot management for standard and cloud snapshots.",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -183,16 +185,15 @@ func (d *snapshotPolicyDataSource) Read(ctx context.Context, req datasource.Read
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	var snapshotPolicyIds []string
 	// Get snapshot policy IDs from config or query all if not specified
 	if state.SnapshotPolicyFilter == nil || len(state.SnapshotPolicyFilter.Names) == 0 {
 		// Read all the snapshot policies
-		snapshotPolicyList, _, err := helper.GetSnapshotPolicies(ctx, *d.client)
+		snapshotPolicyList, _, err := helper.GetSnapshotPolicies(ctx, *pmaxClient)
 		if err != nil {
-			errStr := ""
-			msgStr := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Error reading Snapshot Policy ids", msgStr)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading Snapshot Policy ids", "", err, path.Empty()))
 			return
 		}
 		snapshotPolicyIds = snapshotPolicyList.Name
@@ -203,16 +204,14 @@ func (d *snapshotPolicyDataSource) Read(ctx context.Context, req datasource.Read
 		}
 	}
 	for _, id := range snapshotPolicyIds {
-		snapshotPolicyResponse, _, err := helper.GetSnapshotPolicy(ctx, *d.client, id)
+		snapshotPolicyResponse, _, err := helper.GetSnapshotPolicy(ctx, *pmaxClient, id)
 		if err != nil || snapshotPolicyResponse == nil {
 			// Check to see if timeout was hit
 			helper.ExceedTimeoutErrorCheck(err, resp)
 			if resp.Diagnostics.HasError() {
 				return
 			}
-			errStr := ""
-			msgStr := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Error reading snapshot policy with id", msgStr)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading snapshot policy with id", "", err, path.Empty()))
 			continue
 		}
 		var snapshotPolicy models.SnapshotPolicyModel
@@ -226,6 +225,7 @@ func (d *snapshotPolicyDataSource) Read(ctx context.Context, req datasource.Read
 		state.SnapshotPolicies = append(state.SnapshotPolicies, snapshotPolicy)
 	}
 	state.ID = types.StringValue("snapshot-policy-datasource")
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
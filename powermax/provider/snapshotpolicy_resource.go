@@ -29,6 +29,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -44,6 +45,7 @@ import (
 var _ resource.Resource = &SnapshotPolicy{}
 var _ resource.ResourceWithImportState = &SnapshotPolicy{}
 var _ resource.ResourceWithConfigure = &SnapshotPolicy{}
+var _ resource.ResourceWithValidateConfig = &SnapshotPolicy{}
 
 // NewSnapshotPolicy creates a new Snapshot Policy resource.
 func NewSnapshotPolicy() resource.Resource {
@@ -68,6 +70,8 @@ func (r *SnapshotPolicy) Schema(ctx context.Context, req resource.SchemaRequest,
 		MarkdownDescription: "Resource for a specific Snapshot Policy in PowerMax array. PowerMax snapshot policy feature provides snapshot orchestration at scale (1,024 snaps per storage group). The resource simplifies snapshot management for standard and cloud snapshots.",
 		Description:         "Resource for a specific Snapshot Policy in PowerMax array. PowerMax snapshot policy feature provides snapshot orchestration at scale (1,024 snaps per storage group). The resource simplifies snapshot management for standard and cloud snapshots.",
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Description: "Identifier",
 				Computed:    true,
@@ -182,6 +186,30 @@ func (r *SnapshotPolicy) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+// ValidateConfig checks that the compliance thresholds are ordered: compliance_count_critical
+// cannot exceed compliance_count_warning, which cannot exceed snapshot_count. Only the values set
+// in the configuration are compared.
+func (r *SnapshotPolicy) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var snapshotCount, warning, critical types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snapshot_count"), &snapshotCount)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("compliance_count_warning"), &warning)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("compliance_count_critical"), &critical)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	isSet := func(value types.Int64) bool {
+		return !value.IsNull() && !value.IsUnknown()
+	}
+	if isSet(warning) && isSet(snapshotCount) && warning.ValueInt64() > snapshotCount.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("compliance_count_warning"), "Invalid snapshot policy configuration",
+			fmt.Sprintf("compliance_count_warning (%d) cannot exceed snapshot_count (%d).", warning.ValueInt64(), snapshotCount.ValueInt64()))
+	}
+	if isSet(critical) && isSet(warning) && critical.ValueInt64() > warning.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("compliance_count_critical"), "Invalid snapshot policy configuration",
+			fmt.Sprintf("compliance_count_critical (%d) cannot exceed compliance_count_warning (%d).", critical.ValueInt64(), warning.ValueInt64()))
+	}
+}
+
 // Configure configure client for Snapshot policy resource.
 func (r *SnapshotPolicy) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -213,6 +241,12 @@ func (r *SnapshotPolicy) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, planSnapPolicy.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(planSnapPolicy.SerialNumber.ValueString())
 
 	if !planSnapPolicy.StorageGroups.IsNull() && len(planSnapPolicy.StorageGroups.Elements()) > 0 {
 		resp.Diagnostics.AddError(
@@ -222,27 +256,19 @@ func (r *SnapshotPolicy) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	snapPolicyCreateResp, _, err := helper.CreateSnapshotPolicy(ctx, *r.client, planSnapPolicy)
+	snapPolicyCreateResp, _, err := helper.CreateSnapshotPolicy(ctx, *pmaxClient, planSnapPolicy)
 	if err != nil {
 		snapPolicyID := planSnapPolicy.SnapshotPolicyName.ValueString()
 		errStr := constants.CreateSnapPolicyDetailErrorMsg + snapPolicyID + ": "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error creating snapshot policy",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error creating snapshot policy", errStr, err, path.Root("snapshot_policy_name")))
 
-		req := r.client.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicy(ctx, r.client.SymmetrixID, snapPolicyID)
+		req := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicy(ctx, pmaxClient.SymmetrixID, snapPolicyID)
 		snapPolicyGetResp, _, getSnapPolicyErr := req.Execute()
 		if snapPolicyGetResp != nil || getSnapPolicyErr == nil {
-			_, err := helper.DeleteSnapshotPolicy(ctx, *r.client, snapPolicyID)
+			_, err := helper.DeleteSnapshotPolicy(ctx, *pmaxClient, snapPolicyID)
 			if err != nil {
 				errStr := constants.CreateSnapPolicyDetailErrorMsg + snapPolicyID + "with error: "
-				message := helper.GetErrorString(err, errStr)
-				resp.Diagnostics.AddError(
-					"Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually",
-					message,
-				)
+				resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually", errStr, err, path.Empty()))
 			}
 		}
 		return
@@ -251,20 +277,14 @@ func (r *SnapshotPolicy) Create(ctx context.Context, req resource.CreateRequest,
 		"Create Snapshot Policy Response": snapPolicyCreateResp,
 	})
 	//Get Storage Groups associated with the snapshot policy
-	storageGroups, _, errStorageGroup := helper.GetSnapshotPolicyStorageGroups(ctx, *r.client, planSnapPolicy.SnapshotPolicyName.ValueString())
+	storageGroups, _, errStorageGroup := helper.GetSnapshotPolicyStorageGroups(ctx, *pmaxClient, planSnapPolicy.SnapshotPolicyName.ValueString())
 	if errStorageGroup != nil {
-		errStr := ""
-		msgStr := helper.GetErrorString(errStorageGroup, errStr)
-		resp.Diagnostics.AddError("Error getting Snapshot Policy storage groups", msgStr)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting Snapshot Policy storage groups", "", errStorageGroup, path.Empty()))
 		// Attempt to cleanup after failure
-		_, err := helper.DeleteSnapshotPolicy(ctx, *r.client, planSnapPolicy.SnapshotPolicyName.ValueString())
+		_, err := helper.DeleteSnapshotPolicy(ctx, *pmaxClient, planSnapPolicy.SnapshotPolicyName.ValueString())
 		if err != nil {
 			errStr := constants.CreateSnapPolicyDetailErrorMsg + planSnapPolicy.SnapshotPolicyName.ValueString() + "with error: "
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError(
-				"Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually",
-				message,
-			)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually", errStr, err, path.Empty()))
 		}
 		return
 	}
@@ -276,17 +296,15 @@ func (r *SnapshotPolicy) Create(ctx context.Context, req resource.CreateRequest,
 	if errCpy != nil {
 		resp.Diagnostics.AddError("Error copying Snapshot Policy", errCpy.Error())
 		// Attempt to cleanup after failure
-		_, err := helper.DeleteSnapshotPolicy(ctx, *r.client, planSnapPolicy.SnapshotPolicyName.ValueString())
+		_, err := helper.DeleteSnapshotPolicy(ctx, *pmaxClient, planSnapPolicy.SnapshotPolicyName.ValueString())
 		if err != nil {
 			errStr := constants.CreateSnapPolicyDetailErrorMsg + planSnapPolicy.SnapshotPolicyName.ValueString() + "with error: "
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError(
-				"Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually",
-				message,
-			)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting the invalid snapshot policy, This may be a dangling resource and needs to be deleted manually", errStr, err, path.Empty()))
 		}
 		return
 	}
+	result.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	result.Timeouts = planSnapPolicy.Timeouts
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -305,6 +323,12 @@ func (r *SnapshotPolicy) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, snapPolicyState.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(snapPolicyState.SerialNumber.ValueString())
 	snapPolicyID := snapPolicyState.SnapshotPolicyName.ValueString()
 
 	// Remove any associated storage groups from snapshot policy before deleting the snapshot policy
@@ -320,30 +344,25 @@ func (r *SnapshotPolicy) Delete(ctx context.Context, req resource.DeleteRequest,
 			DisassociateFromStorageGroup: removeSnapshotPolicyParam,
 		}
 
-		updateReq := r.client.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotPolicy(ctx, r.client.SymmetrixID, snapPolicyID)
+		updateReq := pmaxClient.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotPolicy(ctx, pmaxClient.SymmetrixID, snapPolicyID)
 		updateReq = updateReq.SnapshotPolicyUpdate(snapshotPolicyUpdate)
 		_, _, err := updateReq.Execute()
 
 		if err != nil {
 			errStr := constants.DeleteSnapPolicyDetailErrorMsg + snapPolicyID + "with error: "
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Could not remove associated storage groups from Snapshot Policy", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Could not remove associated storage groups from Snapshot Policy", errStr, err, path.Empty()))
 			return
 		}
 	}
 	tflog.Debug(ctx, "deleting snapshot policy by snapPolicyId", map[string]interface{}{
-		"symmetrixID":  r.client.SymmetrixID,
+		"symmetrixID":  pmaxClient.SymmetrixID,
 		"snapPolicyID": snapPolicyID,
 	})
-	delReq := r.client.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotPolicy(ctx, r.client.SymmetrixID, snapPolicyID)
+	delReq := pmaxClient.PmaxOpenapiClient.ReplicationApi.DeleteSnapshotPolicy(ctx, pmaxClient.SymmetrixID, snapPolicyID)
 	_, err := delReq.Execute()
 	if err != nil {
 		errStr := constants.DeleteSnapPolicyDetailErrorMsg + snapPolicyID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error deleting snapshot policy",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error deleting snapshot policy", errStr, err, path.Empty()))
 	}
 
 	tflog.Info(ctx, "Delete snapshot policy complete")
@@ -366,41 +385,37 @@ func (r *SnapshotPolicy) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
 	tflog.Debug(ctx, "calling update host on pmax client", map[string]interface{}{
 		"plan":  plan,
 		"state": state,
 	})
 
-	err := helper.ModifySnapshotPolicy(ctx, *r.client, &plan, &state)
+	err := helper.ModifySnapshotPolicy(ctx, *pmaxClient, &plan, &state)
 	if err != nil {
 		errStr := constants.UpdateSnapshotPolicy + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error updating snapshot Policy",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error updating snapshot Policy", errStr, err, path.Root("snapshot_policy_name")))
 		return
 	}
 	// Read and update state after the modification
-	getReq := r.client.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicy(ctx, r.client.SymmetrixID, plan.SnapshotPolicyName.ValueString())
+	getReq := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicy(ctx, pmaxClient.SymmetrixID, plan.SnapshotPolicyName.ValueString())
 	snapPolicyDetail, _, err := getReq.Execute()
 	if err != nil {
 		errStr := fmt.Sprintf("Error reading snapshot policy %s after update with error:", state.SnapshotPolicyName.ValueString())
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading snapshot policy",
-			msgStr,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading snapshot policy", errStr, err, path.Empty()))
 		return
 	}
 	// Get Storage Groups associated with the snapshot policy
-	storageGroupReq := r.client.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicyStorageGroups(ctx, r.client.SymmetrixID, snapPolicyDetail.SnapshotPolicyName)
+	storageGroupReq := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicyStorageGroups(ctx, pmaxClient.SymmetrixID, snapPolicyDetail.SnapshotPolicyName)
 	storageGroups, _, errStorageGroup := storageGroupReq.Execute()
 	if errStorageGroup != nil {
-		errStr := ""
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error getting Snapshot Policy storage groups", msgStr)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting Snapshot Policy storage groups", "", err, path.Empty()))
 	}
 
 	errState := helper.UpdateSnapshotPolicyResourceState(ctx, snapPolicyDetail, &state, storageGroups)
@@ -412,6 +427,8 @@ func (r *SnapshotPolicy) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	state.Timeouts = plan.Timeouts
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -431,23 +448,30 @@ func (r *SnapshotPolicy) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, snapPolicyState.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(snapPolicyState.SerialNumber.ValueString())
 	snapshotPolicyID := snapPolicyState.SnapshotPolicyName.ValueString()
-	snapshotPolicy, _, err := helper.GetSnapshotPolicy(ctx, *r.client, snapshotPolicyID)
+	snapshotPolicy, _, err := helper.GetSnapshotPolicy(ctx, *pmaxClient, snapshotPolicyID)
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Snapshot policy not found, removing it from state", map[string]interface{}{
+				"id": snapshotPolicyID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		errStr := constants.ReadSnapPolicyDetailsErrorMsg + snapshotPolicyID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading snapshot policy",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading snapshot policy", errStr, err, path.Empty()))
 		return
 	}
 	// Get Storage Groups associated with the snapshot policy
-	storageGroups, _, errStorageGroup := helper.GetSnapshotPolicyStorageGroups(ctx, *r.client, snapshotPolicyID)
+	storageGroups, _, errStorageGroup := helper.GetSnapshotPolicyStorageGroups(ctx, *pmaxClient, snapshotPolicyID)
 	if errStorageGroup != nil {
-		errStr := ""
-		msgStr := helper.GetErrorString(errStorageGroup, errStr)
-		resp.Diagnostics.AddError("Error getting snapshot policy storage groups", msgStr)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting snapshot policy storage groups", "", errStorageGroup, path.Empty()))
 	}
 
 	tflog.Debug(ctx, "Updating snapshot policy state")
@@ -458,6 +482,7 @@ func (r *SnapshotPolicy) Read(ctx context.Context, req resource.ReadRequest, res
 		resp.Diagnostics.AddError("Error reading snapshot policy", errCpy.Error())
 		return
 	}
+	snapPolicyState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, snapPolicyState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -471,34 +496,29 @@ func (r *SnapshotPolicy) Read(ctx context.Context, req resource.ReadRequest, res
 func (r *SnapshotPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	tflog.Info(ctx, "importing Snapshot Policy state")
 	var snapPolicyState models.SnapshotPolicyResource
-	snapshotPolicyID := req.ID
+	serialNumber, snapshotPolicyID := helper.SplitImportID(req.ID)
+	pmaxClient := r.client.WithSerialNumber(serialNumber)
 	tflog.Debug(ctx, "fetching snapshot policy by ID", map[string]interface{}{
-		"symmetrixID":      r.client.SymmetrixID,
+		"symmetrixID":      pmaxClient.SymmetrixID,
 		"snapshotPolicyID": snapshotPolicyID,
 	})
 
-	getReq := r.client.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicy(ctx, r.client.SymmetrixID, snapshotPolicyID)
+	getReq := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicy(ctx, pmaxClient.SymmetrixID, snapshotPolicyID)
 	snapshotPolicyResponse, _, err := getReq.Execute()
 
 	if err != nil {
 		errStr := constants.ImportHostDetailsErrorMsg + snapshotPolicyID + " with error: "
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError(
-			"Error reading snapshot policy",
-			message,
-		)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading snapshot policy", errStr, err, path.Empty()))
 		return
 	}
 	tflog.Debug(ctx, "Get snapshot policy By ID response", map[string]interface{}{
 		"Snapshot Policy Response": snapshotPolicyResponse,
 	})
 	// Get Storage Groups associated with the snapshot policy
-	storageGroupReq := r.client.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicyStorageGroups(ctx, r.client.SymmetrixID, snapshotPolicyID)
+	storageGroupReq := pmaxClient.PmaxOpenapiClient.ReplicationApi.GetSnapshotPolicyStorageGroups(ctx, pmaxClient.SymmetrixID, snapshotPolicyID)
 	storageGroups, _, errStorageGroup := storageGroupReq.Execute()
 	if errStorageGroup != nil {
-		errStr := ""
-		msgStr := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Error getting Snapshot Policy storage groups", msgStr)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error getting Snapshot Policy storage groups", "", err, path.Empty()))
 	}
 
 	tflog.Debug(ctx, "updating snapshot policy state after import")
@@ -508,6 +528,8 @@ func (r *SnapshotPolicy) ImportState(ctx context.Context, req resource.ImportSta
 		return
 	}
 
+	snapPolicyState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	snapPolicyState.Timeouts = helper.NullTimeouts(ctx)
 	diags := resp.State.Set(ctx, snapPolicyState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -29,6 +29,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -60,7 +61,8 @@ func (d *StorageGroupDataSource) Schema(ctx context.Context, req datasource.Sche
 		Description:         "Data Source for reading StorageGroups in PowerMax array. PowerMax storage groups are a collection of devices that are stored on the array. An application, a server, or a collection of servers use them.",
 
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "Placeholder value to run tests",
@@ -71,6 +73,11 @@ func (d *StorageGroupDataSource) Schema(ctx context.Context, req datasource.Sche
 				MarkdownDescription: "List of storage group attributes",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
+						"serial_number": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The serial number of the PowerMax array.",
+							MarkdownDescription: "The serial number of the PowerMax array.",
+						},
 						"id": schema.StringAttribute{
 							Computed:            true,
 							Description:         "The ID of the storage group",
@@ -231,6 +238,12 @@ func (d *StorageGroupDataSource) Schema(ctx context.Context, req datasource.Sche
 							Description:         "The IDs of the volume associated with the storage group.",
 							MarkdownDescription: "The IDs of the volume associated with the storage group.",
 						},
+						"child_storage_groups": schema.SetAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							Description:         "The names of the child storage groups of the storage group.",
+							MarkdownDescription: "The names of the child storage groups of the storage group.",
+						},
 					},
 				},
 			},
@@ -287,15 +300,14 @@ func (d *StorageGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(data.SerialNumber.ValueString())
 
 	var sgIDs []string
 	// Get storage group IDs from config or query all if not specified
 	if data.StorageGroupFilter == nil || len(data.StorageGroupFilter.IDs) == 0 {
-		storageGroupIDList, _, err := helper.GetStorageGroupList(ctx, d.client)
+		storageGroupIDList, _, err := helper.GetStorageGroupList(ctx, pmaxClient)
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Error reading storage group ids:", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading storage group ids:", "", err, path.Empty()))
 			return
 		}
 		sgIDs = storageGroupIDList.StorageGroupId
@@ -308,8 +320,8 @@ func (d *StorageGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	// iterate sgIDs and GetStorageGroup with each id
 	for _, sgID := range sgIDs {
-		var sg models.StorageGroupResourceModel
-		err := helper.UpdateSgState(ctx, d.client, sgID, &sg)
+		var sg models.StorageGroupDataModel
+		storageGroup, err := helper.UpdateSgState(ctx, pmaxClient, sgID, &sg.StorageGroupResourceModel)
 		if err != nil {
 			// Check to see if timeout was hit
 			helper.ExceedTimeoutErrorCheck(err, resp)
@@ -319,6 +331,7 @@ func (d *StorageGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 			resp.Diagnostics.AddError("Error reading storage group", err.Error())
 			return
 		}
+		sg.Tags = types.StringPointerValue(storageGroup.Tags)
 		state.StorageGroups = append(state.StorageGroups, sg)
 	}
 	state.ID = types.StringValue("storage-group-data-source")
@@ -329,6 +342,7 @@ func (d *StorageGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 		tflog.Info(ctx, fmt.Sprintf("State: %v", state.StorageGroups[0].VolumeIDs))
 	}
 
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -100,7 +100,7 @@ func TestAccStorageGroupDataSourceMapperError(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				PreConfig: func() {
-					FunctionMocker = mockey.Mock(helper.UpdateSgState).Return(fmt.Errorf("mock error")).Build()
+					FunctionMocker = mockey.Mock(helper.UpdateSgState).Return(nil, fmt.Errorf("mock error")).Build()
 				},
 				Config:      ProviderConfig + SgDataSourceConfig,
 				ExpectError: regexp.MustCompile(`.*mock error*.`),
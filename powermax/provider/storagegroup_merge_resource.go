@@ -0,0 +1,217 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &StorageGroupMerge{}
+	_ resource.ResourceWithConfigure      = &StorageGroupMerge{}
+	_ resource.ResourceWithValidateConfig = &StorageGroupMerge{}
+)
+
+// NewStorageGroupMerge returns the storage group merge resource object.
+func NewStorageGroupMerge() resource.Resource {
+	return &StorageGroupMerge{}
+}
+
+// StorageGroupMerge defines the resource implementation.
+type StorageGroupMerge struct {
+	client *client.Client
+}
+
+func (r *StorageGroupMerge) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storagegroup_merge"
+}
+
+func (r *StorageGroupMerge) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for merging a standalone storage group of a PowerMax array into another storage group masked to the same host and port group. The volumes of the merged storage group are moved to the storage group, then the merged storage group and its masking view are deleted. Destroying the resource does not undo the merge. Changing any attribute performs a new merge. The volume_ids of the resource must be listed in the volume_ids of the powermax_storagegroup managing storage_group_id, otherwise its next apply removes them. The powermax_storagegroup and powermax_maskingview resources managing the merged storage group and its masking view keep their state with a warning until they are removed from the configuration.",
+		Description:         "Resource for merging a standalone storage group of a PowerMax array into another storage group masked to the same host and port group. The volumes of the merged storage group are moved to the storage group, then the merged storage group and its masking view are deleted. Destroying the resource does not undo the merge. Changing any attribute performs a new merge. The volume_ids of the resource must be listed in the volume_ids of the powermax_storagegroup managing storage_group_id, otherwise its next apply removes them. The powermax_storagegroup and powermax_maskingview resources managing the merged storage group and its masking view keep their state with a warning until they are removed from the configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the merge, the name of the merged storage group.",
+				MarkdownDescription: "The ID of the merge, the name of the merged storage group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_group_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the masked storage group receiving the volumes.",
+				MarkdownDescription: "The name of the masked storage group receiving the volumes.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"merged_storage_group_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the masked standalone storage group merged into storage_group_id, and deleted with its masking view.",
+				MarkdownDescription: "The name of the masked standalone storage group merged into storage_group_id, and deleted with its masking view.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"volume_ids": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				Description:         "The IDs of the volumes moved from merged_storage_group_id to storage_group_id.",
+				MarkdownDescription: "The IDs of the volumes moved from merged_storage_group_id to storage_group_id.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the storage group is merged into another storage group.
+func (r *StorageGroupMerge) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var sgID, mergedID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("storage_group_id"), &sgID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("merged_storage_group_id"), &mergedID)...)
+	if resp.Diagnostics.HasError() || sgID.IsUnknown() || mergedID.IsUnknown() || sgID.IsNull() {
+		return
+	}
+	if sgID.ValueString() == mergedID.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("merged_storage_group_id"),
+			"Invalid storage group merge configuration",
+			fmt.Sprintf("The storage group %s cannot be merged into itself.", sgID.ValueString()),
+		)
+	}
+}
+
+func (r *StorageGroupMerge) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+func (r *StorageGroupMerge) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Merging Storage Group...")
+	var plan models.StorageGroupMergeResourceModel
+
+	// Read Terraform plan into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
+	sgID := plan.StorageGroupID.ValueString()
+	mergedID := plan.MergedStorageGroupID.ValueString()
+
+	// The merged storage group is deleted by the merge, its volumes are listed beforehand
+	volumeIDs, err := helper.StorageGroupVolumeIDs(ctx, pmaxClient, mergedID)
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error reading storage group", fmt.Sprintf("Could not read the volumes of storage group %s with error:", mergedID), err, path.Root("merged_storage_group_id")))
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Calling api to merge storage group %s into %s", mergedID, sgID), map[string]interface{}{
+		"volumeIDs": volumeIDs,
+	})
+	err = helper.MergeStorageGroup(ctx, pmaxClient, sgID, mergedID)
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error merging storage group", fmt.Sprintf("Could not merge storage group %s into %s with error:", mergedID, sgID), err, path.Root("merged_storage_group_id")))
+		return
+	}
+
+	plan.ID = types.StringValue(mergedID)
+	plan.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	plan.VolumeIDs, _ = types.SetValueFrom(ctx, types.StringType, volumeIDs)
+	// Save plan into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	tflog.Info(ctx, "Done with Create Storage Group Merge resource")
+}
+
+// Read keeps the state: the merge is an operation, the storage group it results in is read by its own resource.
+func (r *StorageGroupMerge) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading Storage Group Merge...")
+	var state models.StorageGroupMergeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Info(ctx, "Done with Read Storage Group Merge resource")
+}
+
+// Update Supported updates: timeouts.
+func (r *StorageGroupMerge) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "Updating Storage Group Merge...")
+	var plan models.StorageGroupMergeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	tflog.Info(ctx, "Done with Update Storage Group Merge resource")
+}
+
+// Delete removes the merge from the state, the merged storage group is not restored.
+func (r *StorageGroupMerge) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Storage group merge removed from state, the merge is not undone")
+}
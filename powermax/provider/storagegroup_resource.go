@@ -21,20 +21,31 @@ import (
 	"context"
 	"dell/powermax-go-client"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/helper"
 	"terraform-provider-powermax/powermax/models"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -42,6 +53,8 @@ import (
 var _ resource.Resource = &StorageGroup{}
 var _ resource.ResourceWithConfigure = &StorageGroup{}
 var _ resource.ResourceWithImportState = &StorageGroup{}
+var _ resource.ResourceWithValidateConfig = &StorageGroup{}
+var _ resource.ResourceWithUpgradeState = &StorageGroup{}
 
 // NewStorageGroup is a helper function to simplify the provider implementation.
 func NewStorageGroup() resource.Resource {
@@ -64,8 +77,12 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Resource for managing StorageGroups in PowerMax array. PowerMax storage groups are a collection of devices that are stored on the array. An application, a server, or a collection of servers use them.",
 		Description:         "Resource for managing StorageGroups in PowerMax array. PowerMax storage groups are a collection of devices that are stored on the array. An application, a server, or a collection of servers use them.",
+		// Version 1 manages the tags as a set
+		Version: 1,
 
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The ID of the storage group",
@@ -221,10 +238,21 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:         "VP saved percentage figure",
 				MarkdownDescription: "VP saved percentage figure",
 			},
-			"tags": schema.StringAttribute{
+			"tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
 				Computed:            true,
-				Description:         "The tags associated with the storage group",
-				MarkdownDescription: "The tags associated with the storage group",
+				Description:         "The tags associated with the storage group, e.g. its application or cost center. (Update Supported)",
+				MarkdownDescription: "The tags associated with the storage group, e.g. its application or cost center. (Update Supported)",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,]*$`), "must not contain a comma"),
+					),
+				},
 			},
 			"uuid": schema.StringAttribute{
 				Computed:            true,
@@ -240,13 +268,205 @@ func (r *StorageGroup) Schema(ctx context.Context, req resource.SchemaRequest, r
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
-				Description:         "The IDs of the volume associated with the storage group. Only pre-existing volumes are considered here. (Update Supported)",
-				MarkdownDescription: "The IDs of the volume associated with the storage group. Only pre-existing volumes are considered here. (Update Supported)",
+				Description:         "The IDs of the volume associated with the storage group. Only pre-existing volumes are considered here. When the storage group has a Srp, a volume added while it is in another storage group with a Srp is moved from it in one step, without unmasking it from the hosts. A volume split to another storage group masked to the same host and port group stays in the list and is not added back. (Update Supported)",
+				MarkdownDescription: "The IDs of the volume associated with the storage group. Only pre-existing volumes are considered here. When the storage group has a Srp, a volume added while it is in another storage group with a Srp is moved from it in one step, without unmasking it from the hosts. A volume split to another storage group masked to the same host and port group stays in the list and is not added back. (Update Supported)",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"child_storage_groups": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Description:         "The names of the existing storage groups cascaded under the storage group, which becomes their parent. A parent storage group holds the volumes of its children and has no volumes of its own. (Update Supported)",
+				MarkdownDescription: "The names of the existing storage groups cascaded under the storage group, which becomes their parent. A parent storage group holds the volumes of its children and has no volumes of its own. (Update Supported)",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"volume_sets": schema.ListNestedAttribute{
+				Optional:            true,
+				Description:         "The sets of new volumes created in the storage group. The sets are matched by position, only their count can be updated: increasing it creates the missing volumes, decreasing it or removing the last sets removes their last volumes from the storage group and deletes them unless keep_volumes is set. Destroying the storage group deletes the volumes of its sets the same way. (Update Supported)",
+				MarkdownDescription: "The sets of new volumes created in the storage group. The sets are matched by position, only their count can be updated: increasing it creates the missing volumes, decreasing it or removing the last sets removes their last volumes from the storage group and deletes them unless keep_volumes is set. Destroying the storage group deletes the volumes of its sets the same way. (Update Supported)",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identifier_prefix": schema.StringAttribute{
+							Required:            true,
+							Description:         "The prefix of the volume identifiers, Unisphere appends the number of the volume in the set to it.",
+							MarkdownDescription: "The prefix of the volume identifiers, Unisphere appends the number of the volume in the set to it.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"count": schema.Int64Attribute{
+							Required:            true,
+							Description:         "The number of volumes of the set. (Update Supported)",
+							MarkdownDescription: "The number of volumes of the set. (Update Supported)",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"size": schema.NumberAttribute{
+							Required:            true,
+							Description:         "The size of each volume of the set.",
+							MarkdownDescription: "The size of each volume of the set.",
+						},
+						"cap_unit": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(helper.CapacityUnitGb),
+							Description:         "The Capacity Unit corresponding to the size.",
+							MarkdownDescription: "The Capacity Unit corresponding to the size.",
+							Validators: []validator.String{
+								stringvalidator.OneOf([]string{
+									helper.CapacityUnitMb,
+									helper.CapacityUnitGb,
+									helper.CapacityUnitTb,
+									helper.CapacityUnitCyl,
+								}...),
+							},
+						},
+						"emulation": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("FBA"),
+							Description:         "The emulation of the volumes of the set.",
+							MarkdownDescription: "The emulation of the volumes of the set.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("FBA", "CELERRA_FBA", "CKD-3390", "CKD-3380", "FILE_FBA", "AS/400_D910_099"),
+							},
+						},
+						"keep_volumes": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+							Description:         "Whether the volumes removed from the set or with the storage group are kept on the array. Otherwise they are deleted, except the ones still in another storage group. (Update Supported)",
+							MarkdownDescription: "Whether the volumes removed from the set or with the storage group are kept on the array. Otherwise they are deleted, except the ones still in another storage group. (Update Supported)",
+						},
+						"volume_ids": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							Description:         "The IDs of the volumes of the set.",
+							MarkdownDescription: "The IDs of the volumes of the set.",
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+// ValidateConfig checks that a storage group without Srp has no service level nor compression,
+// and that the dynamic distribution of the host IO limit is supported.
+func (r *StorageGroup) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var srp, slo types.String
+	var compression types.Bool
+	var hostIOLimit types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("srp_id"), &srp)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("slo"), &slo)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("compression"), &compression)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("host_io_limit"), &hostIOLimit)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !srp.IsUnknown() && strings.EqualFold(srp.ValueString(), "None") {
+		if !slo.IsUnknown() && slo.ValueString() != "" && !strings.EqualFold(slo.ValueString(), "None") {
+			resp.Diagnostics.AddAttributeError(path.Root("slo"), "Invalid storage group configuration",
+				fmt.Sprintf("The service level %s cannot be set on a storage group without Srp, set srp_id to an Srp of the array.", slo.ValueString()))
+		}
+		if compression.ValueBool() {
+			resp.Diagnostics.AddAttributeError(path.Root("compression"), "Invalid storage group configuration",
+				"Compression cannot be enabled on a storage group without Srp, set srp_id to an Srp of the array.")
+		}
+	}
+
+	r.validateChildStorageGroups(ctx, req, resp)
+	r.validateVolumeSets(ctx, req, resp)
+
+	if hostIOLimit.IsNull() || hostIOLimit.IsUnknown() {
+		return
+	}
+	distribution, _ := hostIOLimit.Attributes()["dynamic_distribution"].(types.String)
+	if distribution.IsNull() || distribution.IsUnknown() {
+		return
+	}
+	switch distribution.ValueString() {
+	case "Never", "Always", "OnFailure":
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("host_io_limit").AtName("dynamic_distribution"), "Invalid storage group configuration",
+			fmt.Sprintf("The dynamic_distribution must be one of Never, Always or OnFailure, got %q.", distribution.ValueString()))
+	}
+}
+
+// validateChildStorageGroups checks that a parent storage group is not its own child and has no volumes of its own.
+func (r *StorageGroup) validateChildStorageGroups(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var diags diag.Diagnostics
+	var name types.String
+	var volumeIDs types.List
+	var children types.Set
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("volume_ids"), &volumeIDs)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("child_storage_groups"), &children)...)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || children.IsNull() || children.IsUnknown() || len(children.Elements()) == 0 {
+		return
+	}
+
+	if !volumeIDs.IsNull() && !volumeIDs.IsUnknown() && len(volumeIDs.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("volume_ids"), "Invalid storage group configuration",
+			"A parent storage group holds the volumes of its child storage groups, volume_ids cannot be set with child_storage_groups.")
+	}
+	if name.IsNull() || name.IsUnknown() {
+		return
+	}
+	for _, element := range children.Elements() {
+		if child, ok := element.(types.String); ok && !child.IsUnknown() && child.ValueString() == name.ValueString() {
+			resp.Diagnostics.AddAttributeError(path.Root("child_storage_groups").AtSetValue(child), "Invalid storage group configuration",
+				fmt.Sprintf("The storage group %s cannot be a child of itself.", name.ValueString()))
+		}
+	}
+}
+
+// validateVolumeSets checks that the volumes of the volume sets are not also managed by volume_ids, nor created in a
+// parent storage group, and that their size in CYL is an integer.
+func (r *StorageGroup) validateVolumeSets(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var diags diag.Diagnostics
+	var volumeIDs types.List
+	var children types.Set
+	var volumeSets types.List
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("volume_ids"), &volumeIDs)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("child_storage_groups"), &children)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("volume_sets"), &volumeSets)...)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || volumeSets.IsNull() || volumeSets.IsUnknown() || len(volumeSets.Elements()) == 0 {
+		return
+	}
+
+	if !volumeIDs.IsNull() && !volumeIDs.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("volume_ids"), "Invalid storage group configuration",
+			"The volumes of the volume sets would be removed from the storage group by volume_ids, volume_ids cannot be set with volume_sets.")
+	}
+	if !children.IsNull() && !children.IsUnknown() && len(children.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("volume_sets"), "Invalid storage group configuration",
+			"A parent storage group has no volumes of its own, volume_sets cannot be set with child_storage_groups.")
+	}
+
+	var sets []models.StorageGroupVolumeSet
+	resp.Diagnostics.Append(volumeSets.ElementsAs(ctx, &sets, true)...)
+	for i, set := range sets {
+		if set.Size.IsNull() || set.Size.IsUnknown() || set.CapUnit.ValueString() != helper.CapacityUnitCyl || set.Size.ValueBigFloat().IsInt() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("volume_sets").AtListIndex(i).AtName("size"), "Invalid volume size",
+			fmt.Sprintf("The size of a volume with cap_unit %q must be an integer, got %s.", helper.CapacityUnitCyl, set.Size.ValueBigFloat().String()))
+	}
+}
+
 // Configure the resource.
 func (r *StorageGroup) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
@@ -271,20 +491,24 @@ func (r *StorageGroup) Configure(ctx context.Context, req resource.ConfigureRequ
 // Create a storage group.
 func (r *StorageGroup) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Info(ctx, "Creating Storage Group...")
-	var plan models.StorageGroupResourceModel
-	var state models.StorageGroupResourceModel
+	var plan models.StorageGroupResource
+	var state models.StorageGroupResource
 
 	// Read Terraform plan into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
 
-	sg, _, err := helper.CreateStorageGroup(ctx, r.client, plan)
+	sg, _, err := helper.CreateStorageGroup(ctx, pmaxClient, plan.StorageGroupResourceModel)
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create storage group, got error: %s", message))
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Client Error", "Unable to create storage group, got error:", err, path.Root("name")))
 		return
 	}
 
@@ -293,36 +517,62 @@ func (r *StorageGroup) Create(ctx context.Context, req resource.CreateRequest, r
 	})
 
 	// Add or remove existing volumes to the storage group based on volume attributes
-	err = helper.AddRemoveVolume(ctx, &plan, &state, r.client, plan.StorageGroupID.ValueString())
+	err = helper.AddRemoveVolume(ctx, &plan.StorageGroupResourceModel, &state.StorageGroupResourceModel, pmaxClient, plan.StorageGroupID.ValueString())
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Failed to update volume", message)
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update volume", "", err, path.Root("volume_ids")))
 		// Should attempt delete since it failed to fully create
-		_, err := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteStorageGroup(ctx, r.client.SymmetrixID, plan.StorageGroupID.ValueString()).Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			tflog.Debug(ctx, message)
-			return
-		}
+		rollbackStorageGroupCreate(ctx, pmaxClient, plan.StorageGroupID.ValueString(), nil, &resp.Diagnostics)
 		return
 	}
 
-	err = helper.UpdateSgState(ctx, r.client, plan.StorageGroupID.ValueString(), &state)
+	// Cascade the existing storage groups under the storage group based on the child storage groups attribute
+	err = helper.AddRemoveChildStorageGroups(ctx, &plan.StorageGroupResourceModel, &state.StorageGroupResourceModel, pmaxClient, plan.StorageGroupID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating state for storage group", err.Error())
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update child storage groups", "", err, path.Root("child_storage_groups")))
 		// Should attempt delete since it failed to fully create
-		_, err := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteStorageGroup(ctx, r.client.SymmetrixID, plan.StorageGroupID.ValueString()).Execute()
-		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			tflog.Debug(ctx, message)
-			return
-		}
+		rollbackStorageGroupCreate(ctx, pmaxClient, plan.StorageGroupID.ValueString(), nil, &resp.Diagnostics)
+		return
+	}
+
+	// Tag the storage group
+	err = helper.AddRemoveTags(ctx, &plan, &state, pmaxClient, plan.StorageGroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update tags", "", err, path.Root("tags")))
+		// Should attempt delete since it failed to fully create
+		rollbackStorageGroupCreate(ctx, pmaxClient, plan.StorageGroupID.ValueString(), nil, &resp.Diagnostics)
+		return
+	}
+
+	// Remember the volumes already in the storage group to tell apart the ones created for the volume sets
+	existingVolumeIDs, err := helper.StorageGroupVolumeIDs(ctx, pmaxClient, plan.StorageGroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to list volumes of storage group", "", err, path.Empty()))
+		rollbackStorageGroupCreate(ctx, pmaxClient, plan.StorageGroupID.ValueString(), nil, &resp.Diagnostics)
+		return
+	}
+	if existingVolumeIDs == nil {
+		existingVolumeIDs = []string{}
+	}
+
+	// Create the new volumes of the volume sets in the storage group
+	err = helper.AddRemoveVolumeSets(ctx, &plan, &state, pmaxClient, plan.StorageGroupID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to create volume sets", "", err, path.Root("volume_sets")))
+		// Should attempt delete since it failed to fully create, along with the volumes of the volume sets
+		rollbackStorageGroupCreate(ctx, pmaxClient, plan.StorageGroupID.ValueString(), existingVolumeIDs, &resp.Diagnostics)
 		return
 	}
 
+	storageGroup, err := helper.UpdateSgState(ctx, pmaxClient, plan.StorageGroupID.ValueString(), &state.StorageGroupResourceModel)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating state for storage group", err.Error())
+		// Should attempt delete since it failed to fully create, along with the volumes of the volume sets
+		rollbackStorageGroupCreate(ctx, pmaxClient, plan.StorageGroupID.ValueString(), existingVolumeIDs, &resp.Diagnostics)
+		return
+	}
+	state.Tags = helper.StorageGroupTags(ctx, storageGroup)
+
+	state.Timeouts = plan.Timeouts
 	// Save plan into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -330,18 +580,96 @@ func (r *StorageGroup) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
+// rollbackStorageGroupCreate deletes a storage group which failed to fully create. When existingVolumeIDs is not nil,
+// the volumes of the storage group which are not listed in it were created for the volume sets, they are removed from
+// the storage group and deleted as well. The cleanup steps which fail are reported as warnings.
+func rollbackStorageGroupCreate(ctx context.Context, pmaxClient *client.Client, sgID string, existingVolumeIDs []string, diags *diag.Diagnostics) {
+	var createdVolumeIDs []string
+	if existingVolumeIDs != nil {
+		volumeIDs, err := helper.StorageGroupVolumeIDs(ctx, pmaxClient, sgID)
+		if err != nil {
+			diags.AddWarning("Unable to clean up storage group "+sgID,
+				"The volumes created for the volume sets could not be listed, they must be deleted manually: "+helper.GetErrorString(err, ""))
+		}
+		for _, volumeID := range volumeIDs {
+			if !slices.Contains(existingVolumeIDs, volumeID) {
+				createdVolumeIDs = append(createdVolumeIDs, volumeID)
+			}
+		}
+	}
+
+	if len(createdVolumeIDs) > 0 {
+		err := helper.EditStorageGroupJob(ctx, pmaxClient, sgID, "Remove volumes of volume sets from storage group "+sgID, powermax.EditStorageGroupActionParam{
+			RemoveVolumeParam: &powermax.RemoveVolumeParam{
+				VolumeId: createdVolumeIDs,
+			},
+		})
+		if err != nil {
+			diags.AddWarning("Unable to clean up storage group "+sgID,
+				fmt.Sprintf("The volumes %s could not be removed from the storage group: %s", strings.Join(createdVolumeIDs, ", "), helper.GetErrorString(err, "")))
+		}
+	}
+
+	_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteStorageGroup(ctx, pmaxClient.SymmetrixID, sgID).Execute()
+	if err != nil {
+		diags.AddWarning("Unable to clean up storage group "+sgID,
+			"The storage group could not be deleted, it must be deleted manually: "+helper.GetErrorString(err, ""))
+	}
+
+	for _, volumeID := range createdVolumeIDs {
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteVolume(ctx, pmaxClient.SymmetrixID, volumeID).Execute()
+		if err != nil {
+			diags.AddWarning("Unable to clean up storage group "+sgID,
+				fmt.Sprintf("The volume %s could not be deleted, it must be deleted manually: %s", volumeID, helper.GetErrorString(err, "")))
+		}
+	}
+}
+
 // Read a storage group.
 func (r *StorageGroup) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Info(ctx, "Reading Storage Group...")
-	var state models.StorageGroupResourceModel
+	var state models.StorageGroupResource
 
 	// Read Terraform prior state into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 
-	err := helper.UpdateSgState(ctx, r.client, state.StorageGroupID.ValueString(), &state)
+	storageGroup, err := helper.UpdateSgState(ctx, pmaxClient, state.StorageGroupID.ValueString(), &state.StorageGroupResourceModel)
+	if err != nil {
+		if client.IsNotFound(err) {
+			// A merge deletes the storage group it moved the volumes out of, keep it until it is removed from the configuration
+			var volumeIDs []string
+			resp.Diagnostics.Append(state.VolumeIDs.ElementsAs(ctx, &volumeIDs, true)...)
+			mergedInto, err := helper.MergedInto(ctx, pmaxClient, volumeIDs)
+			if err != nil {
+				resp.Diagnostics.AddError("Error updating state for storage group", err.Error())
+				return
+			}
+			if mergedInto != "" {
+				resp.Diagnostics.AddWarning("Storage group merged",
+					fmt.Sprintf("The volumes of the storage group %s were merged into the storage group %s, remove it from the configuration.",
+						state.StorageGroupID.ValueString(), mergedInto))
+				return
+			}
+			tflog.Warn(ctx, "Storage group not found, removing it from state", map[string]interface{}{
+				"id": state.StorageGroupID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error updating state for storage group", err.Error())
+		return
+	}
+	state.Tags = helper.StorageGroupTags(ctx, storageGroup)
+	err = helper.UpdateVolumeSetsState(ctx, &state)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating state for storage group", err.Error())
 		return
@@ -355,22 +683,28 @@ func (r *StorageGroup) Read(ctx context.Context, req resource.ReadRequest, resp
 func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Info(ctx, "Updating Storage group...")
 	// Read Terraform plan into the model
-	var plan models.StorageGroupResourceModel
+	var plan models.StorageGroupResource
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Read Terraform state into the model
-	var state models.StorageGroupResourceModel
+	var state models.StorageGroupResource
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
 	// Read Storage Group ID from state in case of renaming
 	stateID := state.StorageGroupID.ValueString()
 	sgID := stateID
-	payload := r.client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, r.client.SymmetrixID, sgID)
+	payload := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, pmaxClient.SymmetrixID, sgID)
 	updateName := false
 	// Storage Group update need to be done separately because only one payload is accepted by the REST API
 	// Rename
@@ -385,10 +719,8 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 		})
 		_, _, err := payload.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update Storage Group ID(name)", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update Storage Group ID(name): %s", message))
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update Storage Group ID(name)", "", err, path.Root("name")))
+			tflog.Error(ctx, fmt.Sprintf("Failed to update Storage Group ID(name): %s", err.Error()))
 		} else {
 			tflog.Debug(ctx, fmt.Sprintf("Update Storage Group ID(name): %s", planID))
 			sgID = planID
@@ -399,7 +731,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// Recreate the modify storage group param with the new name on a rename job
 	if updateName {
-		payload = r.client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, r.client.SymmetrixID, planID)
+		payload = pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, pmaxClient.SymmetrixID, planID)
 	}
 
 	// Edit Compression
@@ -415,9 +747,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 		})
 		_, _, err := payload.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update compression:", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update compression:", "", err, path.Root("compression")))
 			tflog.Error(ctx, fmt.Sprintf("Failed to update compression: %s", err.Error()))
 
 		} else {
@@ -428,7 +758,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	// SetHostIOLimit
 	if !plan.HostIOLimit.IsNull() && !plan.HostIOLimit.Equal(state.HostIOLimit) {
-		hostIOLimit := helper.ConstructHostIOLimit(plan)
+		hostIOLimit := helper.ConstructHostIOLimit(plan.StorageGroupResourceModel)
 		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
 			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
 				SetHostIOLimitsParam: &powermax.SetHostIOLimitsParam{
@@ -440,9 +770,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 		})
 		_, _, err := payload.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update hostIOLimit:", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update hostIOLimit:", "", err, path.Root("host_io_limit")))
 			tflog.Error(ctx, fmt.Sprintf("Failed to update hostIOLimit: %s", err.Error()))
 
 		} else {
@@ -463,9 +791,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 		})
 		_, _, err := payload.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update workload:", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update workload:", "", err, path.Root("workload")))
 			tflog.Error(ctx, fmt.Sprintf("Failed to update workload: %s", err.Error()))
 		} else {
 			tflog.Debug(ctx, fmt.Sprintf("Update workload: %s", planWorkload))
@@ -486,9 +812,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 		})
 		_, _, err := payload.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update Slo:", message)
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update Slo:", "", err, path.Root("slo")))
 			tflog.Error(ctx, fmt.Sprintf("Failed to update Slo: %s", err.Error()))
 		} else {
 			tflog.Debug(ctx, fmt.Sprintf("Update Slo: %s", planSLO))
@@ -500,19 +824,15 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 	planSRP := plan.Srp.ValueString()
 	stateSRP := state.Srp.ValueString()
 	if planSRP != stateSRP {
-		payload = payload.EditStorageGroupParam(powermax.EditStorageGroupParam{
-			EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
-				EditStorageGroupSRPParam: &powermax.EditStorageGroupSRPParam{
-					SrpId: planSRP,
-				},
+		// Moving the storage group to another Srp moves its data, it runs as a Unisphere job
+		err := helper.EditStorageGroupJob(ctx, pmaxClient, sgID, "Move storage group "+sgID+" to Srp "+planSRP, powermax.EditStorageGroupActionParam{
+			EditStorageGroupSRPParam: &powermax.EditStorageGroupSRPParam{
+				SrpId: planSRP,
 			},
 		})
-		_, _, err := payload.Execute()
 		if err != nil {
-			errStr := ""
-			message := helper.GetErrorString(err, errStr)
-			resp.Diagnostics.AddError("Failed to update Srp:", message)
-			tflog.Error(ctx, fmt.Sprintf("Failed to update Srp: %s", message))
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Failed to update Srp:", "", err, path.Root("srp_id")))
+			tflog.Error(ctx, fmt.Sprintf("Failed to update Srp: %s", err.Error()))
 		} else {
 			tflog.Debug(ctx, fmt.Sprintf("Update Srp: %s", planSLO))
 			state.Srp = types.StringValue(planSRP)
@@ -520,19 +840,42 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Update Volume
-	err := helper.AddRemoveVolume(ctx, &plan, &state, r.client, sgID)
+	err := helper.AddRemoveVolume(ctx, &plan.StorageGroupResourceModel, &state.StorageGroupResourceModel, pmaxClient, sgID)
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Failed to update volume on storage group %s:", sgID), "", err, path.Root("volume_ids")))
+		return
+	}
+
+	// Update child storage groups
+	err = helper.AddRemoveChildStorageGroups(ctx, &plan.StorageGroupResourceModel, &state.StorageGroupResourceModel, pmaxClient, sgID)
 	if err != nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update volume on storage group %s:", sgID), err.Error())
+		resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Failed to update child storage groups on storage group %s:", sgID), "", err, path.Root("child_storage_groups")))
 		return
 	}
 
-	err = helper.UpdateSgState(ctx, r.client, sgID, &state)
+	// Update tags
+	err = helper.AddRemoveTags(ctx, &plan, &state, pmaxClient, sgID)
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Failed to update tags on storage group %s:", sgID), "", err, path.Root("tags")))
+		return
+	}
+
+	// Update volume sets
+	err = helper.AddRemoveVolumeSets(ctx, &plan, &state, pmaxClient, sgID)
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Failed to update volume sets on storage group %s:", sgID), "", err, path.Root("volume_sets")))
+		return
+	}
+
+	storageGroup, err := helper.UpdateSgState(ctx, pmaxClient, sgID, &state.StorageGroupResourceModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating state for storage group:", err.Error())
 		return
 	}
+	state.Tags = helper.StorageGroupTags(ctx, storageGroup)
 
 	tflog.Info(ctx, fmt.Sprintf("Applying this State!!! %v", state))
+	state.Timeouts = plan.Timeouts
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -540,7 +883,7 @@ func (r *StorageGroup) Update(ctx context.Context, req resource.UpdateRequest, r
 // Delete deletes a Storage Group.
 func (r *StorageGroup) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "Deleting Storage Group...")
-	var data models.StorageGroupResourceModel
+	var data models.StorageGroupResource
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -548,19 +891,91 @@ func (r *StorageGroup) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	deletePayload := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteStorageGroup(ctx, r.client.SymmetrixID, data.StorageGroupID.ValueString())
-	_, err := deletePayload.Execute()
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, data.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(data.SerialNumber.ValueString())
+	sgID := data.StorageGroupID.ValueString()
+
+	// The volumes created for the volume sets are removed from the storage group, then deleted with it
+	volumeSetVolumeIDs, err := helper.VolumeSetVolumeIDs(ctx, &data)
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete storage group, got error: %s", message))
+		resp.Diagnostics.AddError("Unable to delete storage group", err.Error())
+		return
+	}
+	if len(volumeSetVolumeIDs) > 0 {
+		err = helper.EditStorageGroupJob(ctx, pmaxClient, sgID, "Remove volumes of volume sets from storage group "+sgID, powermax.EditStorageGroupActionParam{
+			RemoveVolumeParam: &powermax.RemoveVolumeParam{
+				VolumeId: volumeSetVolumeIDs,
+			},
+		})
+		if err != nil && !client.IsNotFound(err) {
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Client Error", "Unable to remove the volumes of the volume sets from the storage group, got error:", err, path.Root("volume_sets")))
+			return
+		}
+	}
+
+	deletePayload := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteStorageGroup(ctx, pmaxClient.SymmetrixID, sgID)
+	_, err = deletePayload.Execute()
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Client Error", "Unable to delete storage group, got error:", err, path.Empty()))
+		return
+	}
+
+	err = helper.DeleteVolumeSetVolumes(ctx, pmaxClient, volumeSetVolumeIDs)
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Client Error", "Unable to delete the volumes of the volume sets, got error:", err, path.Root("volume_sets")))
 		return
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
+// UpgradeState upgrades the state of the storage groups whose tags were a comma separated string.
+func (r *StorageGroup) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	currentType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+	priorSchema.Attributes = maps.Clone(priorSchema.Attributes)
+	priorSchema.Attributes["tags"] = schema.StringAttribute{Computed: true}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var values map[string]tftypes.Value
+				var tags *string
+				if err := req.State.Raw.As(&values); err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade the storage group state", err.Error())
+					return
+				}
+				if err := values["tags"].As(&tags); err != nil {
+					resp.Diagnostics.AddError("Unable to upgrade the storage group state", err.Error())
+					return
+				}
+				var elements []tftypes.Value
+				if tags != nil {
+					for _, tag := range helper.SplitTags(*tags) {
+						elements = append(elements, tftypes.NewValue(tftypes.String, tag))
+					}
+				}
+				values["tags"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+				resp.State.Raw = tftypes.NewValue(currentType, values)
+			},
+		},
+	}
+}
+
 // ImportState imports a Storage Group.
 func (r *StorageGroup) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	serialNumber, id := helper.SplitImportID(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id)...)
+	if serialNumber != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("serial_number"), serialNumber)...)
+	}
 }
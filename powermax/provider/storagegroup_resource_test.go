@@ -90,7 +90,7 @@ func TestAccStorageGroupResourceA(t *testing.T) {
 			// Read Mapping Error Check
 			{
 				PreConfig: func() {
-					FunctionMocker = mockey.Mock(helper.UpdateSgState).Return(fmt.Errorf("mock error")).Build()
+					FunctionMocker = mockey.Mock(helper.UpdateSgState).Return(nil, fmt.Errorf("mock error")).Build()
 				},
 				Config:      ProviderConfig + StorageGroupResourceConfig,
 				ExpectError: regexp.MustCompile(`.*mock error*.`),
@@ -117,7 +117,7 @@ func TestAccStorageGroupResourceCreateErrors(t *testing.T) {
 					if FunctionMocker != nil {
 						FunctionMocker.UnPatch()
 					}
-					FunctionMocker = mockey.Mock(helper.UpdateSgState).Return(fmt.Errorf("mock error")).Build()
+					FunctionMocker = mockey.Mock(helper.UpdateSgState).Return(nil, fmt.Errorf("mock error")).Build()
 				},
 				Config:      ProviderConfig + StorageGroupResourceConfig,
 				ExpectError: regexp.MustCompile(`.*mock error*.`),
@@ -296,3 +296,134 @@ resource "powermax_storagegroup" "test_error_update" {
 	volume_ids = ["non_existent_vol_id"]
 }
 `
+
+func TestAccMockUnisphereCascadedStorageGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: ProviderConfig + mockCascadedStorageGroupsConfig(`[powermax_storagegroup.child_1.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.parent", "child_storage_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("powermax_storagegroup.parent", "child_storage_groups.*", "test_acc_mock_child_1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.parent", "type", "Parent"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "powermax_storagegroup.parent",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: ProviderConfig + mockCascadedStorageGroupsConfig(`[powermax_storagegroup.child_1.id, powermax_storagegroup.child_2.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.parent", "child_storage_groups.#", "2"),
+					resource.TestCheckTypeSetElemAttr("powermax_storagegroup.parent", "child_storage_groups.*", "test_acc_mock_child_2"),
+				),
+			},
+			{
+				Config: ProviderConfig + mockCascadedStorageGroupsConfig(`[powermax_storagegroup.child_2.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.parent", "child_storage_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttr("powermax_storagegroup.parent", "child_storage_groups.*", "test_acc_mock_child_2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func mockCascadedStorageGroupsConfig(children string) string {
+	return `
+resource "powermax_storagegroup" "child_1" {
+	name   = "test_acc_mock_child_1"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+}
+
+resource "powermax_storagegroup" "child_2" {
+	name   = "test_acc_mock_child_2"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+}
+
+resource "powermax_storagegroup" "parent" {
+	name                 = "test_acc_mock_parent"
+	srp_id               = "SRP_1"
+	slo                  = "Gold"
+	child_storage_groups = ` + children + `
+}
+`
+}
+
+func TestAccMockUnisphereVolumeSets(t *testing.T) {
+	volumes := `
+data "powermax_volume" "data" {
+	filter {
+		volume_identifier = "<like>data_"
+	}
+}
+
+data "powermax_volume" "kept" {
+	filter {
+		volume_identifier = "<like>test_acc_mock_kept_"
+	}
+}
+`
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: ProviderConfig + mockVolumeSetsConfig(`[{ identifier_prefix = "data_", count = 2, size = 1 }]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "volume_sets.0.volume_ids.#", "2"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "volume_sets.0.cap_unit", "GB"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "num_of_vols", "2"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: ProviderConfig + mockVolumeSetsConfig(`[{ identifier_prefix = "data_", count = 3, size = 1 }, { identifier_prefix = "test_acc_mock_kept_", count = 1, size = 500, cap_unit = "MB", keep_volumes = true }]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "volume_sets.0.volume_ids.#", "3"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "volume_sets.1.volume_ids.#", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "num_of_vols", "4"),
+				),
+			},
+			{
+				Config: ProviderConfig + mockVolumeSetsConfig(`[{ identifier_prefix = "data_", count = 1, size = 1 }]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "volume_sets.#", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.volume_sets", "num_of_vols", "1"),
+				),
+			},
+			// The volumes removed from the sets are deleted, unless the set keeps them
+			{
+				Config: ProviderConfig + mockVolumeSetsConfig(`[{ identifier_prefix = "data_", count = 1, size = 1 }]`) + volumes,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powermax_volume.data", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.powermax_volume.kept", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.powermax_volume.kept", "volumes.0.num_of_storage_groups", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func mockVolumeSetsConfig(volumeSets string) string {
+	return `
+resource "powermax_storagegroup" "volume_sets" {
+	name        = "test_acc_mock_volume_sets"
+	srp_id      = "SRP_1"
+	slo         = "Gold"
+	volume_sets = ` + volumeSets + `
+}
+`
+}
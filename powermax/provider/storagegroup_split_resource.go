@@ -0,0 +1,285 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &StorageGroupSplit{}
+	_ resource.ResourceWithConfigure      = &StorageGroupSplit{}
+	_ resource.ResourceWithValidateConfig = &StorageGroupSplit{}
+)
+
+// NewStorageGroupSplit returns the storage group split resource object.
+func NewStorageGroupSplit() resource.Resource {
+	return &StorageGroupSplit{}
+}
+
+// StorageGroupSplit defines the resource implementation.
+type StorageGroupSplit struct {
+	client *client.Client
+}
+
+func (r *StorageGroupSplit) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storagegroup_split"
+}
+
+func (r *StorageGroupSplit) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Resource for splitting a masked storage group of a PowerMax array. The split moves volumes of the storage group to a new storage group, or uncascades a child storage group from its parent, and masks the resulting storage group to the same host and port group with a new masking view. The new storage group and masking view can be managed with powermax_storagegroup and powermax_maskingview once imported. Changing any attribute other than merge_on_destroy performs a new split.",
+		Description:         "Resource for splitting a masked storage group of a PowerMax array. The split moves volumes of the storage group to a new storage group, or uncascades a child storage group from its parent, and masks the resulting storage group to the same host and port group with a new masking view. The new storage group and masking view can be managed with powermax_storagegroup and powermax_maskingview once imported. Changing any attribute other than merge_on_destroy performs a new split.",
+
+		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The ID of the split, the name of the new storage group.",
+				MarkdownDescription: "The ID of the split, the name of the new storage group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage_group_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the masked storage group to split.",
+				MarkdownDescription: "The name of the masked storage group to split.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"new_storage_group_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the storage group resulting from the split. When volume_ids is set, it is the new storage group the volumes are moved to. Otherwise it is the child storage group of storage_group_id to uncascade.",
+				MarkdownDescription: "The name of the storage group resulting from the split. When volume_ids is set, it is the new storage group the volumes are moved to. Otherwise it is the child storage group of storage_group_id to uncascade.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"masking_view_id": schema.StringAttribute{
+				Required:            true,
+				Description:         "The name of the new masking view of new_storage_group_id.",
+				MarkdownDescription: "The name of the new masking view of new_storage_group_id.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"volume_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				Description:         "The IDs of the volumes of storage_group_id moved to new_storage_group_id. A child storage group is split from storage_group_id when it is not set.",
+				MarkdownDescription: "The IDs of the volumes of storage_group_id moved to new_storage_group_id. A child storage group is split from storage_group_id when it is not set.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"merge_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				Description:         "Whether new_storage_group_id is merged back into storage_group_id when the resource is destroyed, which deletes new_storage_group_id and its masking view. Requires volume_ids. Otherwise destroying the resource leaves the storage groups as they are. (Update Supported)",
+				MarkdownDescription: "Whether new_storage_group_id is merged back into storage_group_id when the resource is destroyed, which deletes new_storage_group_id and its masking view. Requires volume_ids. Otherwise destroying the resource leaves the storage groups as they are. (Update Supported)",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the split results in another storage group, and that only volumes are merged back.
+func (r *StorageGroupSplit) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config models.StorageGroupSplitResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !config.StorageGroupID.IsUnknown() && !config.NewStorageGroupID.IsUnknown() && !config.StorageGroupID.IsNull() &&
+		config.StorageGroupID.ValueString() == config.NewStorageGroupID.ValueString() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("new_storage_group_id"),
+			"Invalid storage group split configuration",
+			fmt.Sprintf("The storage group %s cannot be split into itself.", config.StorageGroupID.ValueString()),
+		)
+	}
+	if config.MergeOnDestroy.ValueBool() && config.VolumeIDs.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("merge_on_destroy"),
+			"Invalid storage group split configuration",
+			"merge_on_destroy requires volume_ids, a child storage group split from its parent is not merged back.",
+		)
+	}
+}
+
+func (r *StorageGroupSplit) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxClient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = pmaxClient
+}
+
+func (r *StorageGroupSplit) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Splitting Storage Group...")
+	var plan models.StorageGroupSplitResourceModel
+
+	// Read Terraform plan into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
+	sgID := plan.StorageGroupID.ValueString()
+	newSgID := plan.NewStorageGroupID.ValueString()
+
+	var err error
+	if plan.VolumeIDs.IsNull() {
+		tflog.Debug(ctx, fmt.Sprintf("Calling api to split child storage group %s of %s", newSgID, sgID))
+		err = helper.SplitChildStorageGroup(ctx, pmaxClient, sgID, newSgID, plan.MaskingViewID.ValueString())
+	} else {
+		var volumeIDs []string
+		resp.Diagnostics.Append(plan.VolumeIDs.ElementsAs(ctx, &volumeIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Calling api to split volumes of storage group %s to %s", sgID, newSgID), map[string]interface{}{
+			"volumeIDs": volumeIDs,
+		})
+		err = helper.SplitStorageGroupVolumes(ctx, pmaxClient, sgID, newSgID, plan.MaskingViewID.ValueString(), volumeIDs)
+	}
+	if err != nil {
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error splitting storage group", fmt.Sprintf("Could not split storage group %s with error:", sgID), err, path.Root("storage_group_id")))
+		return
+	}
+
+	plan.ID = types.StringValue(newSgID)
+	plan.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	// Save plan into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	tflog.Info(ctx, "Done with Create Storage Group Split resource")
+}
+
+// Read keeps the state: the split is an operation, the storage groups and masking views it results in
+// are read by their own resources.
+func (r *StorageGroupSplit) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading Storage Group Split...")
+	var state models.StorageGroupSplitResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	tflog.Info(ctx, "Done with Read Storage Group Split resource")
+}
+
+// Update Supported updates: merge_on_destroy, timeouts.
+func (r *StorageGroupSplit) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "Updating Storage Group Split...")
+	var plan models.StorageGroupSplitResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	tflog.Info(ctx, "Done with Update Storage Group Split resource")
+}
+
+func (r *StorageGroupSplit) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting Storage Group Split...")
+	var state models.StorageGroupSplitResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !state.MergeOnDestroy.ValueBool() {
+		tflog.Info(ctx, "Storage group split removed from state, the storage groups are left as they are")
+		return
+	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &resp.Diagnostics, state.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(state.SerialNumber.ValueString())
+	sgID := state.StorageGroupID.ValueString()
+	newSgID := state.NewStorageGroupID.ValueString()
+
+	tflog.Debug(ctx, fmt.Sprintf("Calling api to merge storage group %s back into %s", newSgID, sgID))
+	err := helper.MergeStorageGroup(ctx, pmaxClient, sgID, newSgID)
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Storage group not found, it is not merged back", map[string]interface{}{
+				"id": newSgID,
+			})
+			return
+		}
+		resp.Diagnostics.Append(client.ErrorDiagnostic("Error merging storage group", fmt.Sprintf("Could not merge storage group %s back into %s with error:", newSgID, sgID), err, path.Empty()))
+		return
+	}
+	tflog.Info(ctx, "Done with Delete Storage Group Split resource")
+}
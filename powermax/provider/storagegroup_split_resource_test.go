@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMockUnisphereStorageGroupSplitMerge(t *testing.T) {
+	tier2 := `
+data "powermax_storagegroup" "tier2" {
+	filter {
+		names = ["test_acc_mock_tier2"]
+	}
+}
+`
+	big := `
+data "powermax_storagegroup" "big" {
+	filter {
+		names = [powermax_storagegroup.big.name]
+	}
+}
+`
+	merged := `
+resource "powermax_storagegroup" "merged" {
+	name       = "test_acc_mock_merged"
+	srp_id     = "None"
+	volume_ids = [powermax_volume.tier4.id]
+}
+
+resource "powermax_maskingview" "merged" {
+	name             = "test_acc_mock_merged_mv"
+	storage_group_id = powermax_storagegroup.merged.id
+	host_id          = powermax_host.split.id
+	host_group_id    = ""
+	port_group_id    = powermax_portgroup.split.id
+}
+
+# The name of big avoids a dependency cycle with its volume_ids
+resource "powermax_storagegroup_merge" "merged" {
+	storage_group_id        = "test_acc_mock_big"
+	merged_storage_group_id = powermax_maskingview.merged.storage_group_id
+}
+`
+	volumes := "[powermax_volume.tier1.id, powermax_volume.tier2.id]"
+	grown := "[powermax_volume.tier1.id, powermax_volume.tier2.id, powermax_volume.tier3.id]"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, big keeps the split volume in its volume_ids
+			{
+				Config: ProviderConfig + mockSplitMergeConfig(volumes) + mockSplitConfig("test_acc_mock_tier2", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup_split.tier", "id", "test_acc_mock_tier2"),
+					resource.TestCheckResourceAttr("powermax_storagegroup_split.tier", "volume_ids.#", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.big", "volume_ids.#", "2"),
+				),
+			},
+			{
+				Config: ProviderConfig + mockSplitMergeConfig(volumes) + mockSplitConfig("test_acc_mock_tier2", true) + tier2 + big,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tier2", "storage_groups.0.num_of_vols", "1"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tier2", "storage_groups.0.num_of_masking_views", "1"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.big", "storage_groups.0.num_of_vols", "1"),
+				),
+			},
+			// A follow-up change of the storage group does not add the split volume back
+			{
+				Config: ProviderConfig + mockSplitMergeConfig(grown) + mockSplitConfig("test_acc_mock_tier2", true) + tier2 + big,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.big", "volume_ids.#", "3"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tier2", "storage_groups.0.num_of_vols", "1"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.big", "storage_groups.0.num_of_vols", "2"),
+				),
+			},
+			// The split is merged back when it is destroyed
+			{
+				Config: ProviderConfig + mockSplitMergeConfig(grown) + big,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.big", "storage_groups.0.num_of_vols", "3"),
+				),
+			},
+			// Merge testing, big lists the merged volumes and the merged storage group and masking view keep their state
+			{
+				Config: ProviderConfig + mockSplitMergeConfig("concat("+grown+", tolist(powermax_storagegroup_merge.merged.volume_ids))") + merged,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup_merge.merged", "id", "test_acc_mock_merged"),
+					resource.TestCheckResourceAttr("powermax_storagegroup_merge.merged", "volume_ids.#", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.merged", "volume_ids.#", "1"),
+					resource.TestCheckResourceAttr("powermax_maskingview.merged", "storage_group_id", "test_acc_mock_merged"),
+				),
+			},
+			{
+				Config: ProviderConfig + mockSplitMergeConfig("[powermax_volume.tier1.id, powermax_volume.tier2.id, powermax_volume.tier3.id, powermax_volume.tier4.id]") + big,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.big", "volume_ids.#", "4"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.big", "storage_groups.0.num_of_vols", "4"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// mockSplitMergeConfig masks the storage group big with the volumeIDs, sorted like the array lists them. The volumes
+// are created in the storage group park without Srp for big to manage its volume_ids.
+func mockSplitMergeConfig(volumeIDs string) string {
+	return `
+resource "powermax_host" "split" {
+	name       = "test_acc_mock_split_host"
+	initiator  = ["10000000c9000011"]
+	host_flags = {}
+}
+
+resource "powermax_portgroup" "split" {
+	name     = "test_acc_mock_split_pg"
+	protocol = "SCSI_FC"
+	ports = [
+		{
+			director_id = "OR-2C"
+			port_id     = "0"
+		}
+	]
+}
+
+resource "powermax_storagegroup" "park" {
+	name   = "test_acc_mock_park"
+	srp_id = "None"
+}
+
+resource "powermax_volume" "tier1" {
+	vol_name = "test_acc_mock_tier1"
+	size     = 1
+	sg_name  = powermax_storagegroup.park.id
+}
+
+resource "powermax_volume" "tier2" {
+	vol_name = "test_acc_mock_tier2"
+	size     = 1
+	sg_name  = powermax_storagegroup.park.id
+}
+
+resource "powermax_volume" "tier3" {
+	vol_name = "test_acc_mock_tier3"
+	size     = 1
+	sg_name  = powermax_storagegroup.park.id
+}
+
+resource "powermax_volume" "tier4" {
+	vol_name = "test_acc_mock_tier4"
+	size     = 1
+	sg_name  = powermax_storagegroup.park.id
+}
+
+resource "powermax_storagegroup" "big" {
+	name       = "test_acc_mock_big"
+	srp_id     = "SRP_1"
+	slo        = "Gold"
+	volume_ids = sort(` + volumeIDs + `)
+}
+
+resource "powermax_maskingview" "big" {
+	name             = "test_acc_mock_big_mv"
+	storage_group_id = powermax_storagegroup.big.id
+	host_id          = powermax_host.split.id
+	host_group_id    = ""
+	port_group_id    = powermax_portgroup.split.id
+}
+`
+}
+
+func mockSplitConfig(newStorageGroupID string, mergeOnDestroy bool) string {
+	return fmt.Sprintf(`
+resource "powermax_storagegroup_split" "tier" {
+	storage_group_id     = powermax_maskingview.big.storage_group_id
+	new_storage_group_id = "%s"
+	masking_view_id      = "%s_mv"
+	volume_ids           = [powermax_volume.tier2.id]
+	merge_on_destroy     = %t
+}
+`, newStorageGroupID, newStorageGroupID, mergeOnDestroy)
+}
@@ -0,0 +1,373 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	pmax "dell/powermax-go-client"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/constants"
+	"terraform-provider-powermax/powermax/helper"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The sweepers delete the objects left on the array by failed acceptance tests, whose names start
+// with constants.SweepTestsTemplateIdentifier. They run with `make sweep` against the array of powermax.env.
+// The dependencies are swept first: masking views before the groups they use, snapshots and snapshot
+// policies before their storage groups, and storage groups before their volumes.
+
+func init() {
+	resource.AddTestSweepers("powermax_maskingview", &resource.Sweeper{
+		Name: "powermax_maskingview",
+		F:    sweepMaskingViews,
+	})
+	resource.AddTestSweepers("powermax_snapshot", &resource.Sweeper{
+		Name: "powermax_snapshot",
+		F:    sweepSnapshots,
+	})
+	resource.AddTestSweepers("powermax_snapshotpolicy", &resource.Sweeper{
+		Name: "powermax_snapshotpolicy",
+		F:    sweepSnapshotPolicies,
+	})
+	resource.AddTestSweepers("powermax_hostgroup", &resource.Sweeper{
+		Name:         "powermax_hostgroup",
+		Dependencies: []string{"powermax_maskingview"},
+		F:            sweepHostGroups,
+	})
+	resource.AddTestSweepers("powermax_host", &resource.Sweeper{
+		Name:         "powermax_host",
+		Dependencies: []string{"powermax_maskingview", "powermax_hostgroup"},
+		F:            sweepHosts,
+	})
+	resource.AddTestSweepers("powermax_portgroup", &resource.Sweeper{
+		Name:         "powermax_portgroup",
+		Dependencies: []string{"powermax_maskingview"},
+		F:            sweepPortGroups,
+	})
+	resource.AddTestSweepers("powermax_storagegroup", &resource.Sweeper{
+		Name:         "powermax_storagegroup",
+		Dependencies: []string{"powermax_maskingview", "powermax_snapshot", "powermax_snapshotpolicy"},
+		F:            sweepStorageGroups,
+	})
+	resource.AddTestSweepers("powermax_volume", &resource.Sweeper{
+		Name:         "powermax_volume",
+		Dependencies: []string{"powermax_storagegroup"},
+		F:            sweepVolumes,
+	})
+}
+
+var (
+	sweeperClientOnce sync.Once
+	sweeperPmaxClient *client.Client
+	sweeperClientErr  error
+)
+
+// sweeperClient returns the client of the array configured in powermax.env, shared by the sweepers.
+func sweeperClient() (*client.Client, error) {
+	sweeperClientOnce.Do(func() {
+		ctx := context.Background()
+		if globalEnvMap["POWERMAX_ENDPOINT"] == "" || globalEnvMap["POWERMAX_SERIAL_NUMBER"] == "" {
+			sweeperClientErr = errors.New("POWERMAX_ENDPOINT and POWERMAX_SERIAL_NUMBER must be set to run the sweepers")
+			return
+		}
+		sweeperPmaxClient, sweeperClientErr = client.NewClient(ctx, globalEnvMap["POWERMAX_ENDPOINT"], globalEnvMap["POWERMAX_USERNAME"],
+			globalEnvMap["POWERMAX_PASSWORD"], globalEnvMap["POWERMAX_SERIAL_NUMBER"], globalEnvMap["POWERMAX_VERSION"], true, client.ClientOptions{})
+		if sweeperClientErr == nil {
+			sweeperClientErr = sweeperPmaxClient.ValidateConnection(ctx, globalEnvMap["POWERMAX_VERSION"])
+		}
+	})
+	return sweeperPmaxClient, sweeperClientErr
+}
+
+// isSweepable reports whether the object was created by an acceptance test.
+func isSweepable(name string) bool {
+	return strings.HasPrefix(name, constants.SweepTestsTemplateIdentifier)
+}
+
+// listAllVolumes executes the volume list request and walks every page of its iterator, returning the
+// IDs of all the volumes listed.
+func listAllVolumes(ctx context.Context, pmaxClient *client.Client, request pmax.ApiListVolumesRequest) ([]string, error) {
+	iterator, _, err := request.Execute()
+	if err != nil {
+		return nil, err
+	}
+	results := iterator.ResultList.Result
+	if iterator.Id != nil {
+		defer func() {
+			_, _ = pmaxClient.PmaxOpenapiClient.CommonApi.Close(ctx, iterator.GetId()).Execute()
+		}()
+		pageSize := iterator.GetMaxPageSize()
+		if pageSize <= 0 {
+			pageSize = 1000
+		}
+		for from := int32(len(results)) + 1; from <= iterator.GetCount(); from += pageSize {
+			to := min(from+pageSize-1, iterator.GetCount())
+			page, _, err := pmaxClient.PmaxOpenapiClient.CommonApi.Page(ctx, iterator.GetId()).From(from).To(to).Execute()
+			if err != nil {
+				return nil, err
+			}
+			if len(page.Result) == 0 {
+				break
+			}
+			results = append(results, page.Result...)
+		}
+	}
+	volumeIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		volumeIDs = append(volumeIDs, fmt.Sprint(result["volumeId"]))
+	}
+	return volumeIDs, nil
+}
+
+// sweep deletes the sweepable objects among names. It keeps going when a deletion fails and
+// returns all the errors.
+func sweep(kind string, names []string, deleteFunc func(name string) error) error {
+	var errs []error
+	for _, name := range names {
+		if !isSweepable(name) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping %s %s", kind, name)
+		if err := deleteFunc(name); err != nil && !client.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("unable to sweep %s %s: %w", kind, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepMaskingViews(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListMaskingViews(ctx, pmaxClient.SymmetrixID).Execute()
+	if err != nil {
+		return err
+	}
+	return sweep("masking view", list.MaskingViewId, func(name string) error {
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteMaskingView(ctx, pmaxClient.SymmetrixID, name).Execute()
+		return err
+	})
+}
+
+func sweepHostGroups(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListHostGroups(ctx, pmaxClient.SymmetrixID).Execute()
+	if err != nil {
+		return err
+	}
+	return sweep("host group", list.HostGroupId, func(name string) error {
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteHostGroup(ctx, pmaxClient.SymmetrixID, name).Execute()
+		return err
+	})
+}
+
+func sweepHosts(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := helper.GetHostList(ctx, *pmaxClient)
+	if err != nil {
+		return err
+	}
+	return sweep("host", list.HostId, func(name string) error {
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteHost(ctx, pmaxClient.SymmetrixID, name).Execute()
+		return err
+	})
+}
+
+func sweepPortGroups(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ListPortGroups(ctx, pmaxClient.SymmetrixID).Execute()
+	if err != nil {
+		return err
+	}
+	return sweep("port group", list.PortGroupId, func(name string) error {
+		_, err := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeletePortGroup(ctx, pmaxClient.SymmetrixID, name).Execute()
+		return err
+	})
+}
+
+// sweepSnapshots deletes the snapshots of the sweepable storage groups, unlinking them first.
+func sweepSnapshots(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := helper.GetStorageGroupList(ctx, pmaxClient)
+	if err != nil {
+		return err
+	}
+	return sweep("storage group snapshots of", list.StorageGroupId, func(sgName string) error {
+		snapshots, _, err := helper.GetStorageGroupSnapshots(ctx, *pmaxClient, sgName)
+		if err != nil {
+			return err
+		}
+		var errs []error
+		for _, snapshotName := range snapshots.Name {
+			snapIDs, _, err := helper.GetStorageGroupSnapshotSnapIDs(ctx, *pmaxClient, sgName, snapshotName)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, snapID := range snapIDs.Snapids {
+				errs = append(errs, deleteSnapshot(ctx, pmaxClient, sgName, snapshotName, snapID))
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// deleteSnapshot unlinks the snapshot from its target storage groups and deletes it.
+func deleteSnapshot(ctx context.Context, pmaxClient *client.Client, sgName, snapshotName string, snapID int64) error {
+	replication := pmaxClient.PmaxOpenapiClient.ReplicationApi
+	snapshot, _, err := helper.GetSnapshotSnapIDSG(ctx, *pmaxClient, sgName, snapshotName, snapID)
+	if err != nil {
+		return err
+	}
+	for _, linked := range snapshot.LinkedStorageGroup {
+		_, _, err := replication.UpdateSnapshotSnapID(ctx, pmaxClient.SymmetrixID, sgName, snapshotName, snapID).
+			StorageGroupSnapshotInstanceUpdate(pmax.StorageGroupSnapshotInstanceUpdate{
+				Action: helper.ActionSnapshotUnlink,
+				Unlink: &pmax.SnapVxUnlinkOptions{StorageGroupName: linked.Name},
+			}).Execute()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = replication.DeleteSnapshotSnapID(ctx, pmaxClient.SymmetrixID, sgName, snapshotName, snapID).Execute()
+	return err
+}
+
+// sweepSnapshotPolicies deletes the sweepable snapshot policies once their storage groups are disassociated.
+func sweepSnapshotPolicies(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := helper.GetSnapshotPolicies(ctx, *pmaxClient)
+	if err != nil {
+		return err
+	}
+	return sweep("snapshot policy", list.Name, func(name string) error {
+		storageGroups, _, err := helper.GetSnapshotPolicyStorageGroups(ctx, *pmaxClient, name)
+		if err != nil {
+			return err
+		}
+		if len(storageGroups.GetName()) > 0 {
+			disassociate := pmax.NewSnapshotPolicyStorageGroupAddRemove()
+			disassociate.SetStorageGroupName(storageGroups.GetName())
+			_, _, err := pmaxClient.PmaxOpenapiClient.ReplicationApi.UpdateSnapshotPolicy(ctx, pmaxClient.SymmetrixID, name).
+				SnapshotPolicyUpdate(pmax.SnapshotPolicyUpdate{
+					Action:                       "DisassociateFromStorageGroups",
+					DisassociateFromStorageGroup: disassociate,
+				}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+		_, err = helper.DeleteSnapshotPolicy(ctx, *pmaxClient, name)
+		return err
+	})
+}
+
+// sweepStorageGroups deletes the sweepable storage groups once their volumes are removed.
+// The volumes themselves are deleted by the volume sweeper.
+func sweepStorageGroups(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	list, _, err := helper.GetStorageGroupList(ctx, pmaxClient)
+	if err != nil {
+		return err
+	}
+	sdk := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi
+	return sweep("storage group", list.StorageGroupId, func(name string) error {
+		volumeIDs, err := listAllVolumes(ctx, pmaxClient, sdk.ListVolumes(ctx, pmaxClient.SymmetrixID).StorageGroupId(name))
+		if err != nil {
+			return err
+		}
+		if len(volumeIDs) > 0 {
+			_, _, err := sdk.ModifyStorageGroup(ctx, pmaxClient.SymmetrixID, name).EditStorageGroupParam(pmax.EditStorageGroupParam{
+				EditStorageGroupActionParam: pmax.EditStorageGroupActionParam{
+					RemoveVolumeParam: &pmax.RemoveVolumeParam{VolumeId: volumeIDs},
+				},
+			}).Execute()
+			if err != nil {
+				return err
+			}
+		}
+		_, err = sdk.DeleteStorageGroup(ctx, pmaxClient.SymmetrixID, name).Execute()
+		return err
+	})
+}
+
+// sweepVolumes deletes the sweepable volumes which are not in a storage group anymore.
+func sweepVolumes(_ string) error {
+	pmaxClient, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	sdk := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi
+	volumeIDs, err := listAllVolumes(ctx, pmaxClient, sdk.ListVolumes(ctx, pmaxClient.SymmetrixID).VolumeIdentifier("<like>"+constants.SweepTestsTemplateIdentifier))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, volumeID := range volumeIDs {
+		volume, _, err := helper.GetVolume(ctx, *pmaxClient, volumeID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !isSweepable(volume.GetVolumeIdentifier()) {
+			continue
+		}
+		if volume.GetNumOfStorageGroups() > 0 {
+			log.Printf("[INFO] Skipping volume %s (%s) which is still in a storage group", volume.GetVolumeIdentifier(), volumeID)
+			continue
+		}
+		log.Printf("[INFO] Sweeping volume %s (%s)", volume.GetVolumeIdentifier(), volumeID)
+		if _, err := sdk.DeleteVolume(ctx, pmaxClient.SymmetrixID, volumeID).Execute(); err != nil && !client.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("unable to sweep volume %s: %w", volumeID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,207 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-powermax/client"
+	"terraform-provider-powermax/powermax/helper"
+	"terraform-provider-powermax/powermax/models"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TagsDataSource{}
+var _ datasource.DataSourceWithConfigure = &TagsDataSource{}
+
+// TagsDataSource defines the data source implementation.
+type TagsDataSource struct {
+	client *client.Client
+}
+
+// NewTagsDataSource is a helper function to simplify the provider implementation.
+func NewTagsDataSource() datasource.DataSource {
+	return &TagsDataSource{}
+}
+
+// Metadata returns the metadata for the data source.
+func (d *TagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+// Schema returns the schema for the data source.
+func (d *TagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Data source for reading Tags in PowerMax array. Tags label storage groups, e.g. by application or cost center, and are managed with the tags of the storage group resource.",
+		Description:         "Data source for reading Tags in PowerMax array. Tags label storage groups, e.g. by application or cost center, and are managed with the tags of the storage group resource.",
+		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
+			"id": schema.StringAttribute{
+				Description: "Identifier",
+				Computed:    true,
+			},
+			"tags": schema.ListNestedAttribute{
+				Computed:            true,
+				Description:         "List of tags and the objects carrying them",
+				MarkdownDescription: "List of tags and the objects carrying them",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The name of the tag.",
+							MarkdownDescription: "The name of the tag.",
+						},
+						"storage_groups": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							Description:         "The storage groups of the array carrying the tag.",
+							MarkdownDescription: "The storage groups of the array carrying the tag.",
+						},
+						"array_ids": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							Description:         "The serial numbers of the arrays of the Unisphere with storage groups carrying the tag.",
+							MarkdownDescription: "The serial numbers of the arrays of the Unisphere with storage groups carrying the tag.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"names": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						Description:         "The names of the tags to read.",
+						MarkdownDescription: "The names of the tags to read.",
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the data source.
+func (d *TagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pmaxclient, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = pmaxclient
+}
+
+// Read the tags of the array, or the ones of the filter.
+func (d *TagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan models.TagsDataSourceModel
+	var state models.TagsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := helper.SetupTimeoutReadDatasource(ctx, resp, plan.Timeout)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(plan.SerialNumber.ValueString())
+
+	// Get the tag names from the filter or list all the tags of the Unisphere
+	var tagNames []string
+	allTags := plan.TagFilter == nil || len(plan.TagFilter.Names) == 0
+	if allTags {
+		tagList, _, err := helper.GetTagList(ctx, *pmaxClient)
+		if err != nil {
+			// Check to see if timeout was hit
+			helper.ExceedTimeoutErrorCheck(err, resp)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(client.ErrorDiagnostic("Unable to Read PowerMax Tags", "", err, path.Empty()))
+			return
+		}
+		tagNames = tagList.TagName
+	} else {
+		for _, name := range plan.TagFilter.Names {
+			tagNames = append(tagNames, name.ValueString())
+		}
+	}
+
+	tags := []models.Tag{}
+	for _, tagName := range tagNames {
+		tagResponse, _, err := helper.ReadTagByName(ctx, *pmaxClient, tagName)
+		if err != nil {
+			// Check to see if timeout was hit
+			helper.ExceedTimeoutErrorCheck(err, resp)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(client.ErrorDiagnostic(fmt.Sprintf("Error reading tag %s", tagName), "", err, path.Root("filter").AtName("names")))
+			return
+		}
+		var tag models.Tag
+		helper.UpdateTagState(ctx, *pmaxClient, &tag, tagResponse)
+		// Without filter, only the tags carried by the array are listed
+		if allTags && len(tag.StorageGroups.Elements()) == 0 {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	state.Tags = tags
+	state.ID = types.StringValue("tags-data-source")
+	state.TagFilter = plan.TagFilter
+	state.Timeout = plan.Timeout
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+
+	tflog.Trace(ctx, "read Tags data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
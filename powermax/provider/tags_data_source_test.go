@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMockUnisphereTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: ProviderConfig + mockTagsConfig(`["app_erp", "cc_100"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.tags", "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr("powermax_storagegroup.tags", "tags.*", "cc_100"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: ProviderConfig + mockTagsConfig(`["app_erp", "cc_200"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_storagegroup.tags", "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr("powermax_storagegroup.tags", "tags.*", "cc_200"),
+				),
+			},
+			{
+				Config: ProviderConfig + mockTagsConfig(`["app_erp", "cc_200"]`) + `
+data "powermax_tags" "all" {
+	depends_on = [powermax_storagegroup.tags]
+}
+
+data "powermax_tags" "app" {
+	depends_on = [powermax_storagegroup.tags]
+	filter {
+		names = ["app_erp"]
+	}
+}
+
+data "powermax_storagegroup" "tags" {
+	filter {
+		names = [powermax_storagegroup.tags.name]
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powermax_tags.all", "tags.#", "2"),
+					resource.TestCheckResourceAttr("data.powermax_tags.app", "tags.#", "1"),
+					resource.TestCheckResourceAttr("data.powermax_tags.app", "tags.0.storage_groups.0", "test_acc_mock_tags"),
+					resource.TestCheckResourceAttr("data.powermax_storagegroup.tags", "storage_groups.0.tags", "app_erp,cc_200"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func mockTagsConfig(tags string) string {
+	return `
+resource "powermax_storagegroup" "tags" {
+	name   = "test_acc_mock_tags"
+	srp_id = "SRP_1"
+	slo    = "Gold"
+	tags   = ` + tags + `
+}
+`
+}
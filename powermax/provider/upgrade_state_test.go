@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-powermax/powermax/models"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeState runs the state upgrader of the resource from version against the JSON state, the
+// attributes missing from it are null.
+func upgradeState(t *testing.T, r resource.ResourceWithUpgradeState, version int64, state string) tfsdk.State {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	require.True(t, ok, "no state upgrader from version %d", version)
+
+	raw, err := tftypes.ValueFromJSON([]byte(state), upgrader.PriorSchema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return resp.State
+}
+
+func TestUpgradeStateStorageGroup(t *testing.T) {
+	ctx := context.Background()
+	r := &StorageGroup{}
+
+	var upgraded models.StorageGroupResource
+	require.False(t, upgradeState(t, r, 0, `{"name": "sg", "tags": "app, cc_100", "volume_ids": ["00001"]}`).Get(ctx, &upgraded).HasError())
+	var tags []string
+	require.False(t, upgraded.Tags.ElementsAs(ctx, &tags, false).HasError())
+	assert.ElementsMatch(t, []string{"app", "cc_100"}, tags)
+	assert.Equal(t, "sg", upgraded.StorageGroupID.ValueString())
+	assert.Len(t, upgraded.VolumeIDs.Elements(), 1)
+
+	require.False(t, upgradeState(t, r, 0, `{"name": "sg"}`).Get(ctx, &upgraded).HasError())
+	assert.Empty(t, upgraded.Tags.Elements())
+}
@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2026 Dell Inc., or its subsidiaries. All Rights Reserved.
+
+Licensed under the Mozilla Public License Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://mozilla.org/MPL/2.0/
+
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validateConfig runs the ValidateConfig of the resource against the JSON configuration, the
+// attributes missing from it are null. unknown lists the attributes whose value is unknown.
+func validateConfig(t *testing.T, r resource.ResourceWithValidateConfig, config string, unknown ...path.Path) diag.Diagnostics {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	raw, err := tftypes.ValueFromJSON([]byte(config), schemaResp.Schema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: raw}
+	for _, attributePath := range unknown {
+		require.False(t, state.SetAttribute(ctx, attributePath, types.StringUnknown()).HasError())
+	}
+
+	var resp resource.ValidateConfigResponse
+	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+	return resp.Diagnostics
+}
+
+// assertAttributeErrors checks that the diagnostics are errors about the given attributes.
+func assertAttributeErrors(t *testing.T, diags diag.Diagnostics, paths ...path.Path) {
+	t.Helper()
+	require.Len(t, diags, len(paths), "%v", diags)
+	for i, d := range diags {
+		assert.Equal(t, diag.SeverityError, d.Severity())
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if assert.True(t, ok, "diagnostic %q has no attribute path", d.Summary()) {
+			assert.True(t, withPath.Path().Equal(paths[i]), "got path %s, expected %s", withPath.Path(), paths[i])
+		}
+	}
+}
+
+func TestValidateConfigVolume(t *testing.T) {
+	r := &volumeResource{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"sg_name": "sg", "size": 0.5, "cap_unit": "CYL"}`), path.Root("size"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"sg_name": "sg", "size": 3, "cap_unit": "CYL"}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"sg_name": "sg", "size": 0.5, "cap_unit": "GB"}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"sg_name": "sg", "size": 0.5}`, path.Root("cap_unit")))
+}
+
+func TestValidateConfigMaskingView(t *testing.T) {
+	r := &maskingView{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_id": "host", "host_group_id": ""}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_id": "", "host_group_id": "hg"}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_id": "host", "host_group_id": "hg"}`), path.Root("host_group_id"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_id": "", "host_group_id": ""}`), path.Root("host_id"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_id": ""}`, path.Root("host_group_id")))
+}
+
+func TestValidateConfigHost(t *testing.T) {
+	r := &Host{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"initiator": ["10000000c9a1b2c3", "10000000c9a1b2c4"]}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"initiator": ["10000000c9a1b2c3", " ", "10000000C9A1B2C3"]}`),
+		path.Root("initiator").AtListIndex(1), path.Root("initiator").AtListIndex(2))
+}
+
+func TestValidateConfigHostGroup(t *testing.T) {
+	r := &HostGroup{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_ids": ["host_1", "host_2"]}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"host_ids": ["host_1", ""]}`), path.Root("host_ids").AtSetValue(types.StringValue("")))
+}
+
+func TestValidateConfigPortGroup(t *testing.T) {
+	r := &PortGroup{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"ports": [{"director_id": "OR-1C", "port_id": "0"}, {"director_id": "OR-2C", "port_id": "0"}]}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"ports": [{"director_id": "OR-1C", "port_id": "0"}, {"director_id": "or-1c", "port_id": "0"}]}`),
+		path.Root("ports").AtListIndex(1))
+}
+
+func TestValidateConfigSnapshot(t *testing.T) {
+	r := &snapshotResource{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group": {"name": "sg"}, "snapshot_actions": {"name": "snap"}}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{}`), path.Root("storage_group"), path.Root("snapshot_actions"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group": {"name": ""}, "snapshot_actions": {"name": "snap"}}`),
+		path.Root("storage_group").AtName("name"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group": {"name": "sg"}, "snapshot_actions": {"name": "snap", "link": {"enable": true}}}`),
+		path.Root("snapshot_actions").AtName("link").AtName("target_storage_group"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group": {"name": "sg"}, "snapshot_actions": {"name": "snap", "link": {"enable": true, "target_storage_group": "target"}}}`))
+}
+
+func TestValidateConfigSnapshotPolicy(t *testing.T) {
+	r := &SnapshotPolicy{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"snapshot_count": 10, "compliance_count_warning": 9, "compliance_count_critical": 8}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"snapshot_count": 10}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"snapshot_count": 10, "compliance_count_warning": 11, "compliance_count_critical": 12}`),
+		path.Root("compliance_count_warning"), path.Root("compliance_count_critical"))
+}
+
+func TestValidateConfigStorageGroup(t *testing.T) {
+	r := &StorageGroup{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "SRP_1", "slo": "Diamond", "compression": true}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "None", "slo": "None"}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "None", "slo": "Diamond", "compression": true}`),
+		path.Root("slo"), path.Root("compression"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "SRP_1", "host_io_limit": {"host_io_limit_io_sec": "1000", "host_io_limit_mb_sec": "1000", "dynamic_distribution": "Never"}}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "SRP_1", "host_io_limit": {"host_io_limit_io_sec": "1000", "host_io_limit_mb_sec": "1000", "dynamic_distribution": "Sometimes"}}`),
+		path.Root("host_io_limit").AtName("dynamic_distribution"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"name": "parent", "srp_id": "SRP_1", "child_storage_groups": ["child_1", "child_2"]}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"name": "parent", "srp_id": "SRP_1", "child_storage_groups": ["parent"], "volume_ids": ["00001"]}`),
+		path.Root("volume_ids"), path.Root("child_storage_groups").AtSetValue(types.StringValue("parent")))
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "SRP_1", "volume_sets": [{"identifier_prefix": "data_", "count": 2, "size": 10, "cap_unit": "GB"}]}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"srp_id": "SRP_1", "volume_ids": ["00001"], "child_storage_groups": ["child"], "volume_sets": [{"identifier_prefix": "data_", "count": 2, "size": 10, "cap_unit": "GB"}, {"identifier_prefix": "log_", "count": 1, "size": 2.5, "cap_unit": "CYL"}]}`),
+		path.Root("volume_ids"), path.Root("volume_ids"), path.Root("volume_sets"), path.Root("volume_sets").AtListIndex(1).AtName("size"))
+}
+
+func TestValidateConfigStorageGroupSplit(t *testing.T) {
+	r := &StorageGroupSplit{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group_id": "big", "new_storage_group_id": "tier2", "volume_ids": ["00001"], "merge_on_destroy": true}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group_id": "parent", "new_storage_group_id": "child"}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group_id": "big", "new_storage_group_id": "big", "merge_on_destroy": true}`),
+		path.Root("new_storage_group_id"), path.Root("merge_on_destroy"))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group_id": "big"}`, path.Root("new_storage_group_id")))
+}
+
+func TestValidateConfigStorageGroupMerge(t *testing.T) {
+	r := &StorageGroupMerge{}
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group_id": "big", "merged_storage_group_id": "tier2"}`))
+	assertAttributeErrors(t, validateConfig(t, r, `{"storage_group_id": "big", "merged_storage_group_id": "big"}`), path.Root("merged_storage_group_id"))
+}
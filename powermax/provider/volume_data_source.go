@@ -53,7 +53,8 @@ func (d *volumeDataSource) Schema(ctx context.Context, _ datasource.SchemaReques
 		Description:         "Data source for reading Volumes in PowerMax array. PowerMax volumes is an identifiable unit of data storage. Storage groups are sets of volumes.",
 		MarkdownDescription: "Data source for reading Volumes in PowerMax array. PowerMax volumes is an identifiable unit of data storage. Storage groups are sets of volumes.",
 		Attributes: map[string]schema.Attribute{
-			"timeouts": timeouts.Attributes(ctx),
+			"serial_number": helper.SerialNumberDataSourceAttribute(),
+			"timeouts":      timeouts.Attributes(ctx),
 			"id": schema.StringAttribute{
 				Description: "Placeholder for acc testing",
 				Computed:    true,
@@ -516,8 +517,9 @@ func (d *volumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	defer cancel()
+	pmaxClient := d.client.WithSerialNumber(state.SerialNumber.ValueString())
 
-	param, err := helper.GetVolumeFilterParam(ctx, d.client, state)
+	param, err := helper.GetVolumeFilterParam(ctx, pmaxClient, state)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to get volume filter param",
@@ -525,7 +527,7 @@ func (d *volumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		)
 		return
 	}
-	state.Volumes, err = helper.UpdateVolumeState(ctx, d.client, param)
+	state.Volumes, err = helper.UpdateVolumeState(ctx, pmaxClient, param)
 	if err != nil {
 		// Check to see if timeout was hit
 		helper.ExceedTimeoutErrorCheck(err, resp)
@@ -540,6 +542,7 @@ func (d *volumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	state.ID = types.StringValue("place_holder")
+	state.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -22,6 +22,7 @@ import (
 	"dell/powermax-go-client"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"terraform-provider-powermax/client"
 	"terraform-provider-powermax/powermax/helper"
@@ -46,9 +47,10 @@ type volumeResource struct {
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &volumeResource{}
-	_ resource.ResourceWithConfigure   = &volumeResource{}
-	_ resource.ResourceWithImportState = &volumeResource{}
+	_ resource.Resource                   = &volumeResource{}
+	_ resource.ResourceWithConfigure      = &volumeResource{}
+	_ resource.ResourceWithImportState    = &volumeResource{}
+	_ resource.ResourceWithValidateConfig = &volumeResource{}
 )
 
 // NewVolumeResource is a helper function to simplify the provider implementation.
@@ -67,6 +69,8 @@ func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 		Description:         "Resource for managing Volumes in PowerMax array. PowerMax volumes is an identifiable unit of data storage. Storage groups are sets of volumes.",
 
 		Attributes: map[string]schema.Attribute{
+			"serial_number": helper.SerialNumberResourceAttribute(),
+			"timeouts":      helper.TimeoutsResourceAttribute(ctx),
 			"id": schema.StringAttribute{
 				Description:         "The ID of the volume.",
 				MarkdownDescription: "The ID of the volume.",
@@ -86,9 +90,12 @@ func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"sg_name": schema.StringAttribute{
-				Description:         "The name of the storage group. sg_name is required while creating the volume.",
-				MarkdownDescription: "The name of the storage group. sg_name is required while creating the volume.",
+				Description:         "The name of the storage group. sg_name is required while creating the volume. Updating it moves the volume to the new storage group in one step, without unmasking it from the hosts. (Update Supported)",
+				MarkdownDescription: "The name of the storage group. sg_name is required while creating the volume. Updating it moves the volume to the new storage group in one step, without unmasking it from the hosts. (Update Supported)",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"size": schema.NumberAttribute{
 				Description:         "The size of the volume. (Update Supported)",
@@ -256,6 +263,7 @@ func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
+					helper.UnknownIfStringChanged(path.Root("sg_name")),
 				},
 			},
 			"symmetrix_port_key": schema.ListNestedAttribute{
@@ -278,12 +286,31 @@ func (r volumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
+					helper.UnknownIfStringChanged(path.Root("sg_name")),
 				},
 			},
 		},
 	}
 }
 
+// ValidateConfig checks that a size in cylinders is an integer.
+func (r volumeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var size types.Number
+	var capUnit types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("size"), &size)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cap_unit"), &capUnit)...)
+	if resp.Diagnostics.HasError() || size.IsNull() || size.IsUnknown() || capUnit.ValueString() != helper.CapacityUnitCyl {
+		return
+	}
+	if !size.ValueBigFloat().IsInt() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size"),
+			"Invalid volume size",
+			fmt.Sprintf("The size of a volume with cap_unit %q must be an integer, got %s.", helper.CapacityUnitCyl, size.ValueBigFloat().String()),
+		)
+	}
+}
+
 // Configure - defines configuration for volume resource.
 func (r *volumeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -311,6 +338,12 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 	if response.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &response.Diagnostics, plan.Timeouts.Create, helper.DefaultCreateTimeout)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(plan.SerialNumber.ValueString())
 	if !plan.Size.IsNull() {
 		size, _ := plan.Size.ValueBigFloat().Float64()
 		if plan.CapUnit.ValueString() == "CYL" && size != float64(int(size)) {
@@ -330,12 +363,10 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 		return
 	}
 
-	volResponse, _, err := helper.CreateVolume(ctx, *r.client, plan)
+	volResponse, _, err := helper.CreateVolume(ctx, *pmaxClient, plan)
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		response.Diagnostics.AddError("Error creating volume",
-			fmt.Sprintf("Could not create volume %s with error: %s", plan.VolumeIdentifier.ValueString(), message))
+		response.Diagnostics.Append(client.ErrorDiagnostic("Error creating volume",
+			fmt.Sprintf("Could not create volume %s with error:", plan.VolumeIdentifier.ValueString()), err, path.Root("vol_name")))
 		return
 	}
 	tflog.Debug(ctx, "create volume in storage groups response", map[string]interface{}{
@@ -343,12 +374,10 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 	})
 	// Extrct the new volume ID from the storage group
 	volState := models.VolumeResource{}
-	volumeIDListInStorageGroup, _, err := helper.ListVolumes(ctx, *r.client, plan)
+	volumeIDListInStorageGroup, _, err := helper.ListVolumes(ctx, *pmaxClient, plan)
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		response.Diagnostics.AddError("Error creating volume",
-			fmt.Sprintf("Could not find volume %s after creating with error: %s", plan.VolumeIdentifier.ValueString(), message))
+		response.Diagnostics.Append(client.ErrorDiagnostic("Error creating volume",
+			fmt.Sprintf("Could not find volume %s after creating with error:", plan.VolumeIdentifier.ValueString()), err, path.Empty()))
 		return
 	}
 
@@ -358,7 +387,7 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 			// Now that we have created. We need to get the ID to get the specific volume info
 			// Loop through each volumeId in the storage group and compare to the volumeIdentifier to make sure we have the correct volume id
 			id := fmt.Sprint(v2)
-			volTemp, _, err := helper.GetVolume(ctx, *r.client, id)
+			volTemp, _, err := helper.GetVolume(ctx, *pmaxClient, id)
 
 			// If there is an error keep continuing to make sure we are able to check all of the volumes
 			// Fail if the `vol` variable is still null at the end
@@ -399,6 +428,8 @@ func (r volumeResource) Create(ctx context.Context, request resource.CreateReque
 		return
 	}
 
+	volState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	volState.Timeouts = plan.Timeouts
 	diags = response.State.Set(ctx, volState)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
@@ -415,20 +446,32 @@ func (r volumeResource) Read(ctx context.Context, request resource.ReadRequest,
 	if response.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &response.Diagnostics, volState.Timeouts.Read, helper.DefaultReadTimeout)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(volState.SerialNumber.ValueString())
 
 	volID := volState.ID.ValueString()
 	tflog.Debug(ctx, "calling get volume by ID", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"volumeID":    volID,
 	})
-	volResponse, _, err := helper.GetVolume(ctx, *r.client, volID)
+	volResponse, _, err := helper.GetVolume(ctx, *pmaxClient, volID)
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		response.Diagnostics.AddError(
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, "Volume not found, removing it from state", map[string]interface{}{
+				"id": volID,
+			})
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.Append(client.ErrorDiagnostic(
 			"Error reading volume",
-			fmt.Sprintf("Could not read volume %s with error: %s", volID, message),
-		)
+			fmt.Sprintf("Could not read volume %s with error:", volID),
+			err, path.Empty(),
+		))
 
 		return
 	}
@@ -448,6 +491,7 @@ func (r volumeResource) Read(ctx context.Context, request resource.ReadRequest,
 		)
 		return
 	}
+	volState.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
 	diags = response.State.Set(ctx, volState)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
@@ -457,7 +501,7 @@ func (r volumeResource) Read(ctx context.Context, request resource.ReadRequest,
 }
 
 // Update VolumeResource
-// Supported updates: vol_name, mobility_id_enabled, size, cap_unit.
+// Supported updates: vol_name, sg_name, mobility_id_enabled, size, cap_unit.
 func (r volumeResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
 	tflog.Info(ctx, "updating volume")
 	var planVol models.VolumeResource
@@ -473,6 +517,12 @@ func (r volumeResource) Update(ctx context.Context, request resource.UpdateReque
 	if response.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &response.Diagnostics, planVol.Timeouts.Update, helper.DefaultUpdateTimeout)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(stateVol.SerialNumber.ValueString())
 
 	if !planVol.Size.IsNull() {
 		size, _ := planVol.Size.ValueBigFloat().Float64()
@@ -489,27 +539,30 @@ func (r volumeResource) Update(ctx context.Context, request resource.UpdateReque
 		"planVol":  planVol,
 		"stateVol": stateVol,
 	})
-	updatedParams, updateFailedParameters, errMessages := helper.UpdateVol(ctx, r.client, planVol, stateVol)
+	updatedParams, updateFailedParameters, errMessages := helper.UpdateVol(ctx, pmaxClient, planVol, stateVol)
 	if len(errMessages) > 0 || len(updateFailedParameters) > 0 {
 		errMessage := strings.Join(errMessages, ",\n")
 		response.Diagnostics.AddError(
 			fmt.Sprintf("Failed to update all parameters of Volume, updated parameters are %v and parameters failed to update are %v", updatedParams, updateFailedParameters),
 			errMessage)
 	}
+	if slices.Contains(updateFailedParameters, "sg_name") {
+		// The volume is still in the storage group of the state
+		planVol.StorageGroupName = stateVol.StorageGroupName
+	}
 
 	volID := stateVol.ID.ValueString()
 	tflog.Debug(ctx, "calling get volume by ID on pmax client", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"volumeID":    volID,
 	})
-	volResponse, _, err := helper.GetVolume(ctx, *r.client, volID)
+	volResponse, _, err := helper.GetVolume(ctx, *pmaxClient, volID)
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		response.Diagnostics.AddError(
+		response.Diagnostics.Append(client.ErrorDiagnostic(
 			"Error reading volume",
-			fmt.Sprintf("Could not read volume %s with error: %s", volID, message),
-		)
+			fmt.Sprintf("Could not read volume %s with error:", volID),
+			err, path.Empty(),
+		))
 		return
 	}
 	tflog.Debug(ctx, "get volume by ID response", map[string]interface{}{
@@ -528,6 +581,8 @@ func (r volumeResource) Update(ctx context.Context, request resource.UpdateReque
 		)
 		return
 	}
+	stateVol.SerialNumber = types.StringValue(pmaxClient.SymmetrixID)
+	stateVol.Timeouts = planVol.Timeouts
 	diags = response.State.Set(ctx, stateVol)
 	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
@@ -544,6 +599,12 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 	if response.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := helper.SetupTimeoutResource(ctx, &response.Diagnostics, volumeState.Timeouts.Delete, helper.DefaultDeleteTimeout)
+	defer cancel()
+	if response.Diagnostics.HasError() {
+		return
+	}
+	pmaxClient := r.client.WithSerialNumber(volumeState.SerialNumber.ValueString())
 	volumeID := volumeState.ID.ValueString()
 	if diags.HasError() {
 		response.Diagnostics.Append(diags...)
@@ -559,21 +620,21 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 
 	for _, associatedSG := range sgAssociatedWithVolume {
 		tflog.Debug(ctx, "calling get storage group on pmax client", map[string]interface{}{
-			"symmetrixID":    r.client.SymmetrixID,
+			"symmetrixID":    pmaxClient.SymmetrixID,
 			"storageGroupID": associatedSG.StorageGroupName.ValueString(),
 		})
-		sgModel := r.client.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, r.client.SymmetrixID, associatedSG.StorageGroupName.ValueString())
+		sgModel := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.GetStorageGroup2(ctx, pmaxClient.SymmetrixID, associatedSG.StorageGroupName.ValueString())
 		sg, _, _ := sgModel.Execute()
 		tflog.Debug(ctx, "get storage group response", map[string]interface{}{
 			"associatedSG": sg,
 		})
 		if sg != nil {
 			tflog.Debug(ctx, "calling remove volumes from storage group on pmax client", map[string]interface{}{
-				"symmetrixID":    r.client.SymmetrixID,
+				"symmetrixID":    pmaxClient.SymmetrixID,
 				"storageGroupID": sg,
 				"volumeID":       volumeID,
 			})
-			deleteParam := r.client.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, r.client.SymmetrixID, associatedSG.StorageGroupName.ValueString())
+			deleteParam := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.ModifyStorageGroup(ctx, pmaxClient.SymmetrixID, associatedSG.StorageGroupName.ValueString())
 			deleteParam = deleteParam.EditStorageGroupParam(
 				powermax.EditStorageGroupParam{
 					EditStorageGroupActionParam: powermax.EditStorageGroupActionParam{
@@ -585,32 +646,29 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 			)
 			_, _, err := deleteParam.Execute()
 			if err != nil {
-				errStr := ""
-				message := helper.GetErrorString(err, errStr)
-				response.Diagnostics.AddError(
+				response.Diagnostics.Append(client.ErrorDiagnostic(
 					"Error removing volume from storage group",
-					fmt.Sprintf("Could not remove  Volume ID: %s from storage group: %s with error: %s",
-						volumeID, volumeState.StorageGroupName.ValueString(), message),
-				)
+					fmt.Sprintf("Could not remove  Volume ID: %s from storage group: %s with error:",
+						volumeID, volumeState.StorageGroupName.ValueString()),
+					err, path.Empty(),
+				))
 
 				return
 			}
 		}
 	}
 	tflog.Debug(ctx, "calling delete volume on pmax client", map[string]interface{}{
-		"symmetrixID": r.client.SymmetrixID,
+		"symmetrixID": pmaxClient.SymmetrixID,
 		"volumeID":    volumeID,
 	})
-	delParam := r.client.PmaxOpenapiClient.SLOProvisioningApi.DeleteVolume(ctx, r.client.SymmetrixID, volumeID)
+	delParam := pmaxClient.PmaxOpenapiClient.SLOProvisioningApi.DeleteVolume(ctx, pmaxClient.SymmetrixID, volumeID)
 	_, err := delParam.Execute()
 	if err != nil {
-		errStr := ""
-		message := helper.GetErrorString(err, errStr)
-		response.Diagnostics.AddError(
+		response.Diagnostics.Append(client.ErrorDiagnostic(
 			"Error deleting volume",
-			fmt.Sprintf("Could not remove Volume ID: %s with error: %s ",
-				volumeID, message),
-		)
+			fmt.Sprintf("Could not remove Volume ID: %s with error:", volumeID),
+			err, path.Empty(),
+		))
 
 	}
 	response.State.RemoveResource(ctx)
@@ -618,9 +676,13 @@ func (r volumeResource) Delete(ctx context.Context, request resource.DeleteReque
 }
 
 func (r volumeResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+	serialNumber, id := helper.SplitImportID(request.ID)
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), resource.ImportStateRequest{ID: id}, response)
 	var stateVol models.VolumeResource
 	response.State.Get(ctx, &stateVol)
+	if serialNumber != "" {
+		stateVol.SerialNumber = types.StringValue(serialNumber)
+	}
 	// For importing volume, storage group for creating should leave as empty
 	stateVol.StorageGroupName = types.StringValue("")
 	// Default cap unit
@@ -138,7 +138,7 @@ func TestAccVolumeResource_Invalid_Config(t *testing.T) {
 			// Config with invalid unit
 			{
 				Config:      ProviderConfig + VolumeConfigInvalidCYL,
-				ExpectError: regexp.MustCompile("Error creating volume"),
+				ExpectError: regexp.MustCompile("Invalid volume size"),
 			},
 			// Config with invalid SG name
 			{
@@ -167,7 +167,7 @@ func TestAccVolumeResource_Error_Updating(t *testing.T) {
 			// Invalid SG name
 			{
 				Config:      ProviderConfig + VolumeConfigInvalidSG,
-				ExpectError: regexp.MustCompile("Error updating volume"),
+				ExpectError: regexp.MustCompile("Failed to update all parameters of Volume"),
 			},
 		},
 	})
@@ -348,7 +348,7 @@ var VolumeConfigInvalidSG = fmt.Sprintf(`
 resource "powermax_volume" "volume_test" {
 	vol_name = "%s"
 	sg_name = "invalid#SG"
-	size = 0.5
+	size = 5
 	cap_unit = "CYL"
 }
 `, resourceVolName)
@@ -361,3 +361,81 @@ resource "powermax_volume" "volume_test" {
 	cap_unit = "CYL"
 }
 `
+
+func TestAccMockUnisphereVolumeMoves(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccMockPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: ProviderConfig + mockVolumeMovesConfig("test_acc_mock_move_1", `[powermax_volume.paired.id]`, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_volume.moved", "storage_groups.#", "1"),
+					resource.TestCheckResourceAttr("powermax_volume.moved", "storage_groups.0.storage_group_name", "test_acc_mock_move_1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.from", "num_of_vols", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.to", "num_of_vols", "0"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: ProviderConfig + mockVolumeMovesConfig("test_acc_mock_move_2", `[]`, `[powermax_volume.paired.id]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powermax_volume.moved", "sg_name", "test_acc_mock_move_2"),
+					resource.TestCheckResourceAttr("powermax_volume.moved", "storage_groups.#", "1"),
+					resource.TestCheckResourceAttr("powermax_volume.moved", "storage_groups.0.storage_group_name", "test_acc_mock_move_2"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.from", "num_of_vols", "0"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.to", "num_of_vols", "1"),
+					resource.TestCheckResourceAttr("powermax_storagegroup.to", "volume_ids.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func mockVolumeMovesConfig(sgName, fromVolumeIDs, toVolumeIDs string) string {
+	return `
+resource "powermax_storagegroup" "move_1" {
+	name   = "test_acc_mock_move_1"
+	srp_id = "None"
+}
+
+resource "powermax_storagegroup" "move_2" {
+	name   = "test_acc_mock_move_2"
+	srp_id = "None"
+}
+
+resource "powermax_volume" "moved" {
+	vol_name = "test_acc_mock_moved"
+	size     = 1
+	sg_name  = "` + sgName + `"
+
+	depends_on = [powermax_storagegroup.move_1, powermax_storagegroup.move_2]
+}
+
+resource "powermax_volume" "paired" {
+	vol_name = "test_acc_mock_paired"
+	size     = 1
+	sg_name  = powermax_storagegroup.move_1.name
+}
+
+# The storage group taking the volume over is updated first and moves it: both are in use by FAST,
+# the volume could not be in both at once
+resource "powermax_storagegroup" "from" {
+	name       = "test_acc_mock_move_from"
+	srp_id     = "SRP_1"
+	slo        = "Gold"
+	volume_ids = ` + fromVolumeIDs + `
+
+	depends_on = [powermax_storagegroup.to]
+}
+
+resource "powermax_storagegroup" "to" {
+	name       = "test_acc_mock_move_to"
+	srp_id     = "SRP_1"
+	slo        = "Gold"
+	volume_ids = ` + toVolumeIDs + `
+}
+`
+}